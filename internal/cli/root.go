@@ -35,6 +35,7 @@ type cliFlags struct {
 	exposuresOnly               bool
 	exposuresTag                string
 	useTableName                bool
+	namingStrategy              string
 	continueOnError             bool
 	includeModels               []string
 	excludeModels               []string
@@ -43,6 +44,16 @@ type cliFlags struct {
 	reportPath                  string
 	flatten                     bool
 	nestedViewExplicitReference bool
+	layout                      string
+	refinements                 bool
+	includeRoot                 string
+	merge                       bool
+	mergeDroppedFields          string
+	modelFiles                  bool
+	modelName                   string
+	modelGrouping               string
+	connection                  string
+	exploreRoots                []string
 }
 
 // flags is the single instance holding CLI flag values
@@ -105,11 +116,26 @@ func init() {
 
 	// Generation Options
 	rootCmd.Flags().BoolVar(&flags.useTableName, "use-table-name", false, "Use BigQuery table name instead of dbt model name for view names")
+	rootCmd.Flags().StringVar(&flags.namingStrategy, "naming-strategy", "", "How view names are derived: dbt_name, table_name, alias or fqn")
 	rootCmd.Flags().StringSliceVar(&flags.timeframes, "timeframes", []string{}, "Custom timeframes for date dimensions (e.g., 'day,week,month')")
 	rootCmd.Flags().StringVar(&flags.removeSchemaString, "remove-schema-string", "", "String to remove from schema names in output paths")
 	rootCmd.Flags().BoolVar(&flags.flatten, "flatten", false, "Generate all LookML files in output directory without subdirectories")
 	rootCmd.Flags().BoolVar(&flags.nestedViewExplicitReference, "nested-view-explicit-reference", false, "Use explicit view_name.column references in nested views instead of ${TABLE}")
 
+	// Output Layering
+	rootCmd.Flags().StringVar(&flags.layout, "layout", "inline", "File layout: inline (one file per model), split (views/ and explores/) or per_view (one file per view)")
+	rootCmd.Flags().BoolVar(&flags.refinements, "refinements", false, "Write generated views to a generated/ base layer and scaffold editable +view refinement files")
+	rootCmd.Flags().StringVar(&flags.includeRoot, "include-root", "", "Location of the output directory inside the Looker project, used for include: paths (e.g. 'views' or '//dbt_project')")
+	rootCmd.Flags().BoolVar(&flags.merge, "merge", false, "Update existing view files in place, replacing only fields generated by a previous run")
+	rootCmd.Flags().StringVar(&flags.mergeDroppedFields, "merge-dropped-fields", "comment", "How merge handles generated fields whose column was removed: comment or remove")
+
+	// Model Files
+	rootCmd.Flags().BoolVar(&flags.modelFiles, "model-files", false, "Generate .model.lkml files with connection, includes, datagroups and explore roots")
+	rootCmd.Flags().StringVar(&flags.modelName, "model-name", "dbt", "Name of the generated model file when models are not grouped")
+	rootCmd.Flags().StringVar(&flags.modelGrouping, "model-grouping", "single", "How views are grouped into model files: single, folder or tag")
+	rootCmd.Flags().StringVar(&flags.connection, "connection", "", "Looker connection name for generated model files")
+	rootCmd.Flags().StringSliceVar(&flags.exploreRoots, "explore-roots", []string{}, "Models whose explores are exposed in the model file (names, or tag:<tag>)")
+
 	// Error Handling & Logging
 	rootCmd.Flags().StringVar(&flags.logLevel, "log-level", "INFO", "Logging level: DEBUG, INFO, WARN, ERROR")
 	rootCmd.Flags().StringVar(&flags.logFormat, "log-format", "console", "Log output format: json, console")
@@ -129,10 +155,21 @@ func init() {
 	_ = viper.BindPFlag("exposures_only", rootCmd.Flags().Lookup("exposures-only"))
 	_ = viper.BindPFlag("exposures_tag", rootCmd.Flags().Lookup("exposures-tag"))
 	_ = viper.BindPFlag("use_table_name", rootCmd.Flags().Lookup("use-table-name"))
+	_ = viper.BindPFlag("naming.strategy", rootCmd.Flags().Lookup("naming-strategy"))
 	_ = viper.BindPFlag("timeframes", rootCmd.Flags().Lookup("timeframes"))
 	_ = viper.BindPFlag("remove_schema_string", rootCmd.Flags().Lookup("remove-schema-string"))
 	_ = viper.BindPFlag("flatten", rootCmd.Flags().Lookup("flatten"))
 	_ = viper.BindPFlag("nested_view_explicit_reference", rootCmd.Flags().Lookup("nested-view-explicit-reference"))
+	_ = viper.BindPFlag("layout", rootCmd.Flags().Lookup("layout"))
+	_ = viper.BindPFlag("refinements", rootCmd.Flags().Lookup("refinements"))
+	_ = viper.BindPFlag("include_root", rootCmd.Flags().Lookup("include-root"))
+	_ = viper.BindPFlag("merge", rootCmd.Flags().Lookup("merge"))
+	_ = viper.BindPFlag("merge_dropped_fields", rootCmd.Flags().Lookup("merge-dropped-fields"))
+	_ = viper.BindPFlag("model_files", rootCmd.Flags().Lookup("model-files"))
+	_ = viper.BindPFlag("model_name", rootCmd.Flags().Lookup("model-name"))
+	_ = viper.BindPFlag("model_grouping", rootCmd.Flags().Lookup("model-grouping"))
+	_ = viper.BindPFlag("connection", rootCmd.Flags().Lookup("connection"))
+	_ = viper.BindPFlag("explore_roots", rootCmd.Flags().Lookup("explore-roots"))
 	_ = viper.BindPFlag("log_level", rootCmd.Flags().Lookup("log-level"))
 	_ = viper.BindPFlag("log_format", rootCmd.Flags().Lookup("log-format"))
 	_ = viper.BindPFlag("continue_on_error", rootCmd.Flags().Lookup("continue-on-error"))
@@ -251,6 +288,14 @@ func runDbt2Lookml(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Individual warnings are logged as they occur; summarize them here
+	if len(result.Warnings) > 0 {
+		log.Warn().Int("warnings", len(result.Warnings)).Msg("Generation produced warnings")
+	}
+	if len(result.GovernedFields) > 0 {
+		log.Info().Int("fields", len(result.GovernedFields)).Msg("Fields governed as personal data")
+	}
+
 	generateTime := time.Since(generateStart)
 	totalTime := time.Since(startTime)
 
@@ -263,7 +308,7 @@ func runDbt2Lookml(cmd *cobra.Command, args []string) error {
 
 	// Generate report if requested
 	if cfg.ReportPath != "" {
-		if err := generateReport(cfg.ReportPath, models, result.FilesGenerated, parseTime, generateTime, totalTime); err != nil {
+		if err := generateReport(cfg.ReportPath, models, result, parseTime, generateTime, totalTime); err != nil {
 			log.Warn().Err(err).Msg("Failed to generate report")
 		} else {
 			log.Info().Str("path", cfg.ReportPath).Msg("Report generated")
@@ -324,17 +369,26 @@ func setupLogging(level, format string) zerolog.Logger {
 }
 
 // generateReport creates a processing report
-func generateReport(reportPath string, models []*models.DbtModel, filesGenerated int, parseTime, generateTime, totalTime time.Duration) error {
+func generateReport(reportPath string, models []*models.DbtModel, result *generators.GenerationResult, parseTime, generateTime, totalTime time.Duration) error {
 	report := map[string]interface{}{
 		"timestamp":        time.Now().Format(time.RFC3339),
 		"models_processed": len(models),
-		"files_generated":  filesGenerated,
+		"files_generated":  result.FilesGenerated,
 		"timing": map[string]string{
 			"parsing":    parseTime.String(),
 			"generation": generateTime.String(),
 			"total":      totalTime.String(),
 		},
 	}
+	if len(result.Warnings) > 0 {
+		report["warnings"] = result.Warnings
+	}
+	if len(result.GovernedFields) > 0 {
+		report["governed_fields"] = result.GovernedFields
+	}
+	if len(result.MissingTranslations) > 0 {
+		report["missing_translations"] = result.MissingTranslations
+	}
 
 	// Ensure directory exists
 	if err := os.MkdirAll(filepath.Dir(reportPath), 0755); err != nil {
@@ -3,8 +3,11 @@ package config
 import (
 	"fmt"
 	"os"
+	"path"
+	"regexp"
 	"strings"
 
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
 )
@@ -23,6 +26,199 @@ const (
 	LogFormatConsole = "console"
 )
 
+// Merge dropped field handling constants
+const (
+	MergeDroppedComment = "comment"
+	MergeDroppedRemove  = "remove"
+)
+
+// Output layout constants
+const (
+	LayoutInline  = "inline"
+	LayoutSplit   = "split"
+	LayoutPerView = "per_view"
+)
+
+// Naming strategy constants
+const (
+	NamingStrategyDbtName   = "dbt_name"
+	NamingStrategyTableName = "table_name"
+	NamingStrategyAlias     = "alias"
+	NamingStrategyFQN       = "fqn"
+)
+
+// DefaultNestedSeparator joins the parts of nested view and field names
+const DefaultNestedSeparator = "__"
+
+// nestedSeparatorPattern matches separators that keep nested names valid LookML names
+var nestedSeparatorPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Nested array overflow constants: how arrays nested beyond nested_arrays.max_depth are handled
+const (
+	NestedArrayOverflowDrop = "drop"
+	NestedArrayOverflowJSON = "json"
+)
+
+// Label dictionary defaults
+var (
+	DefaultLabelAcronyms       = []string{"GTIN", "SKU", "ID", "VAT", "EAN"}
+	DefaultLabelLowercaseWords = []string{"of", "for", "per", "and", "or"}
+)
+
+// PII masking constants
+const (
+	PIIMaskingNone     = "none"
+	PIIMaskingHidden   = "hidden"
+	PIIMaskingSHA256   = "sha256"
+	PIIMaskingRedacted = "redacted"
+)
+
+// Localization level constants, written as localization_level in the manifest
+const (
+	LocalizationLevelStrict     = "strict"
+	LocalizationLevelPermissive = "permissive"
+)
+
+// DefaultLocale is the locale of existing labels and descriptions when localization.default_locale is not set
+const DefaultLocale = "en"
+
+// localePattern matches Looker locale names such as en, sv or es_ES
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(_[A-Za-z0-9]+)*$`)
+
+// IsValidLocale reports whether a locale name can be used for a strings file
+func IsValidLocale(locale string) bool {
+	return localePattern.MatchString(locale)
+}
+
+// DefaultPIITag is the dbt column tag that marks personal data when pii.tags is not set
+const DefaultPIITag = "pii"
+
+// Model file grouping constants
+const (
+	ModelGroupingSingle = "single"
+	ModelGroupingFolder = "folder"
+	ModelGroupingTag    = "tag"
+)
+
+// DatagroupConfig defines a datagroup written to generated model files
+type DatagroupConfig struct {
+	Name            string `mapstructure:"name"`
+	Label           string `mapstructure:"label"`
+	Description     string `mapstructure:"description"`
+	SQLTrigger      string `mapstructure:"sql_trigger"`
+	IntervalTrigger string `mapstructure:"interval_trigger"`
+	MaxCacheAge     string `mapstructure:"max_cache_age"`
+}
+
+// MeasureTemplateConfig declares a measure generated automatically for every main view
+// column that matches it. Name and label patterns accept the placeholders {type},
+// {column} (the column's dimension name) and {label} (the dimension's label).
+type MeasureTemplateConfig struct {
+	Type               string   `mapstructure:"type"`
+	DataTypes          []string `mapstructure:"data_types"`
+	Columns            []string `mapstructure:"columns"`
+	PrimaryKey         bool     `mapstructure:"primary_key"`
+	Name               string   `mapstructure:"name"`
+	Label              string   `mapstructure:"label"`
+	Description        string   `mapstructure:"description"`
+	Hidden             bool     `mapstructure:"hidden"`
+	ValueFormatName    string   `mapstructure:"value_format_name"`
+	InheritValueFormat bool     `mapstructure:"inherit_value_format"`
+}
+
+// DetailSetConfig controls the detail set generated for each view, which measures drill into.
+// Primary keys and labeled dimensions are always part of the set; Columns adds dimensions
+// by column name pattern (e.g. *_name).
+type DetailSetConfig struct {
+	Disabled  bool     `mapstructure:"disabled"`
+	Columns   []string `mapstructure:"columns"`
+	MaxFields int      `mapstructure:"max_fields"`
+}
+
+// DurationRuleConfig declares duration dimension groups for pairs of date/time columns.
+// Start and End are column names that may contain a single *, which has to match the same
+// text in both (e.g. *_started_at and *_ended_at). Name may use the * as well.
+type DurationRuleConfig struct {
+	Start     string   `mapstructure:"start"`
+	End       string   `mapstructure:"end"`
+	Name      string   `mapstructure:"name"`
+	Intervals []string `mapstructure:"intervals"`
+}
+
+// AccessGrantConfig defines an access_grant written to generated model files. Users whose
+// user attribute has one of the allowed values hold the grant.
+type AccessGrantConfig struct {
+	Name          string   `mapstructure:"name"`
+	UserAttribute string   `mapstructure:"user_attribute"`
+	AllowedValues []string `mapstructure:"allowed_values"`
+}
+
+// PIIConfig controls the governance of columns holding personal data: columns with one of
+// Tags or meta.contains_pii: true. Their fields require AccessGrants (by default every
+// configured access grant) and Masking optionally hides them or adds a masked variant for
+// users without the grants.
+type PIIConfig struct {
+	Tags         []string `mapstructure:"tags"`
+	AccessGrants []string `mapstructure:"access_grants"`
+	Masking      string   `mapstructure:"masking"`
+}
+
+// LocalizationConfig controls Looker localization. When enabled, field and view labels and
+// descriptions become keys into <locale>.strings.json files: the default locale holds the
+// existing texts, other locales the translations from meta.looker.translations.
+type LocalizationConfig struct {
+	Enabled       bool     `mapstructure:"enabled"`
+	DefaultLocale string   `mapstructure:"default_locale"`
+	Locales       []string `mapstructure:"locales"`
+	Level         string   `mapstructure:"level"`
+	SkipManifest  bool     `mapstructure:"skip_manifest"` // Leave localization_settings to a hand-written manifest.lkml
+}
+
+// NamingRuleConfig rewrites generated view names: every match of Pattern, a regular
+// expression, is replaced by Replacement, which may refer to capture groups as $1.
+type NamingRuleConfig struct {
+	Pattern     string `mapstructure:"pattern"`
+	Replacement string `mapstructure:"replacement"`
+}
+
+// NamingConfig selects how view, explore and nested view names are computed. The strategy
+// picks the base name of a model, the rules rewrite it in order, and nested names join their
+// parts with NestedSeparator.
+type NamingConfig struct {
+	Strategy        string             `mapstructure:"strategy"`
+	Rules           []NamingRuleConfig `mapstructure:"rules"`
+	NestedSeparator string             `mapstructure:"nested_separator"`
+}
+
+// LabelsConfig controls the labels derived from names. Acronyms are written as listed,
+// replacements swap whole words, lowercase words stay lower case inside a label, and yes/no
+// prefix rules rephrase boolean fields: {is_: "{label}?"} labels is_active "Active?".
+type LabelsConfig struct {
+	Acronyms       []string          `mapstructure:"acronyms"`
+	Replacements   map[string]string `mapstructure:"replacements"`
+	LowercaseWords []string          `mapstructure:"lowercase_words"`
+	YesNoPrefixes  map[string]string `mapstructure:"yesno_prefixes"`
+}
+
+// NestedArraysConfig limits how deeply arrays are unnested into nested views. Arrays nested
+// beyond MaxDepth are dropped, or kept as a JSON string dimension with Overflow json.
+// Offset unnests single-value arrays WITH OFFSET and adds an offset dimension and count measure.
+type NestedArraysConfig struct {
+	MaxDepth int    `mapstructure:"max_depth"`
+	Overflow string `mapstructure:"overflow"`
+	Offset   bool   `mapstructure:"offset"` // Expose element positions of single-value arrays
+}
+
+// DimensionGroupConfig sets the defaults of time dimension groups per BigQuery type. Column
+// meta overrides them. DATE and DATETIME columns never convert time zones by default;
+// TimestampConvertTZ sets convert_tz for TIMESTAMP columns, which Looker converts unless told otherwise.
+type DimensionGroupConfig struct {
+	DateTimeframes      []string `mapstructure:"date_timeframes"`
+	DatetimeTimeframes  []string `mapstructure:"datetime_timeframes"`
+	TimestampTimeframes []string `mapstructure:"timestamp_timeframes"`
+	TimestampConvertTZ  *bool    `mapstructure:"timestamp_convert_tz"`
+}
+
 // Config holds all configuration options for dbt2lookml
 type Config struct {
 	// Core paths
@@ -42,11 +238,53 @@ type Config struct {
 	ExposuresTag  string `mapstructure:"exposures_tag"`
 
 	// Generation options
-	UseTableName                bool     `mapstructure:"use_table_name"`
-	Timeframes                  []string `mapstructure:"timeframes"`
-	RemoveSchemaString          string   `mapstructure:"remove_schema_string"`
-	Flatten                     bool     `mapstructure:"flatten"`
-	NestedViewExplicitReference bool     `mapstructure:"nested_view_explicit_reference"`
+	UseTableName                bool               `mapstructure:"use_table_name"`
+	Timeframes                  []string           `mapstructure:"timeframes"`
+	RemoveSchemaString          string             `mapstructure:"remove_schema_string"`
+	Naming                      NamingConfig       `mapstructure:"naming"`
+	Labels                      LabelsConfig       `mapstructure:"labels"`
+	Flatten                     bool               `mapstructure:"flatten"`
+	NestedViewExplicitReference bool               `mapstructure:"nested_view_explicit_reference"`
+	NestedArrays                NestedArraysConfig `mapstructure:"nested_arrays"`
+
+	// Measure options
+	MeasureTemplates []MeasureTemplateConfig `mapstructure:"measure_templates"`
+	DetailSet        DetailSetConfig         `mapstructure:"detail_set"`
+
+	// Dimension group options
+	Durations       []DurationRuleConfig `mapstructure:"durations"`
+	DimensionGroups DimensionGroupConfig `mapstructure:"dimension_groups"`
+
+	// Governance options
+	AccessGrants []AccessGrantConfig `mapstructure:"access_grants"`
+	PII          PIIConfig           `mapstructure:"pii"`
+
+	// Localization options
+	Localization LocalizationConfig `mapstructure:"localization"`
+
+	// Output layering options
+	Layout      string `mapstructure:"layout"`
+	Refinements bool   `mapstructure:"refinements"`
+	IncludeRoot string `mapstructure:"include_root"`
+	OutputPath  string `mapstructure:"output_path"` // Go template of the path of generated view and explore files
+
+	// Merge options
+	Merge              bool   `mapstructure:"merge"`
+	MergeDroppedFields string `mapstructure:"merge_dropped_fields"`
+
+	// Model file options
+	ModelFiles    bool              `mapstructure:"model_files"`
+	ModelName     string            `mapstructure:"model_name"`
+	ModelGrouping string            `mapstructure:"model_grouping"`
+	Connection    string            `mapstructure:"connection"`
+	Connections   map[string]string `mapstructure:"connections"`
+	ExploreRoots  []string          `mapstructure:"explore_roots"`
+	ModelIncludes []string          `mapstructure:"model_includes"`
+	Datagroups    []DatagroupConfig `mapstructure:"datagroups"`
+
+	// Calendar options, written into generated model files
+	FiscalMonthOffset int    `mapstructure:"fiscal_month_offset"` // Months the fiscal year starts after January (-11 to 11)
+	WeekStartDay      string `mapstructure:"week_start_day"`
 
 	// Utility options
 	LogLevel        string `mapstructure:"log_level"`
@@ -125,6 +363,15 @@ func setDefaults() {
 	viper.SetDefault("exposures_only", false)
 	viper.SetDefault("use_table_name", false)
 	viper.SetDefault("flatten", false)
+	viper.SetDefault("layout", LayoutInline)
+	viper.SetDefault("refinements", false)
+	viper.SetDefault("include_root", "")
+	viper.SetDefault("merge", false)
+	viper.SetDefault("merge_dropped_fields", MergeDroppedComment)
+	viper.SetDefault("model_files", false)
+	viper.SetDefault("model_name", "dbt")
+	viper.SetDefault("model_grouping", ModelGroupingSingle)
+	viper.SetDefault("explore_roots", []string{})
 	viper.SetDefault("continue_on_error", false)
 	viper.SetDefault("include_models", []string{})
 	viper.SetDefault("exclude_models", []string{})
@@ -171,23 +418,279 @@ func (c *Config) Validate() error {
 	}
 	c.LogFormat = logFormat
 
-	// Validate timeframes if provided
-	if len(c.Timeframes) > 0 {
-		validTimeframes := []string{"raw", "date", "week", "month", "quarter", "year", "time"}
-		for _, tf := range c.Timeframes {
+	// Validate output layout
+	if c.Layout != "" {
+		layout := strings.ToLower(c.Layout)
+		if layout != LayoutInline && layout != LayoutSplit && layout != LayoutPerView {
+			return fmt.Errorf("invalid layout: %s (must be one of: %v)", c.Layout, []string{LayoutInline, LayoutSplit, LayoutPerView})
+		}
+		c.Layout = layout
+	}
+
+	if c.OutputPath != "" {
+		if c.Flatten {
+			return fmt.Errorf("flatten and output_path cannot be used together")
+		}
+		if _, err := ParseOutputPath(c.OutputPath); err != nil {
+			return err
+		}
+	}
+
+	// Validate naming options
+	if c.Naming.Strategy != "" {
+		strategy := strings.ToLower(c.Naming.Strategy)
+		validStrategies := []string{NamingStrategyDbtName, NamingStrategyTableName, NamingStrategyAlias, NamingStrategyFQN}
+		valid := false
+		for _, validStrategy := range validStrategies {
+			if strategy == validStrategy {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("invalid naming.strategy: %s (must be one of: %v)", c.Naming.Strategy, validStrategies)
+		}
+		c.Naming.Strategy = strategy
+	}
+	for i, rule := range c.Naming.Rules {
+		if rule.Pattern == "" {
+			return fmt.Errorf("naming.rules[%d]: pattern is required", i)
+		}
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return fmt.Errorf("naming.rules[%d]: invalid pattern %q: %w", i, rule.Pattern, err)
+		}
+	}
+	if c.Naming.NestedSeparator != "" && !nestedSeparatorPattern.MatchString(c.Naming.NestedSeparator) {
+		return fmt.Errorf("invalid naming.nested_separator: %q (must contain only lowercase letters, digits and underscores)", c.Naming.NestedSeparator)
+	}
+
+	// Validate nested array options
+	if c.NestedArrays.MaxDepth < 0 {
+		return fmt.Errorf("invalid nested_arrays.max_depth: %d (must be at least 1, or 0 for the default)", c.NestedArrays.MaxDepth)
+	}
+	if c.NestedArrays.Overflow != "" {
+		overflow := strings.ToLower(c.NestedArrays.Overflow)
+		if overflow != NestedArrayOverflowDrop && overflow != NestedArrayOverflowJSON {
+			return fmt.Errorf("invalid nested_arrays.overflow: %s (must be one of: %v)", c.NestedArrays.Overflow, []string{NestedArrayOverflowDrop, NestedArrayOverflowJSON})
+		}
+		c.NestedArrays.Overflow = overflow
+	}
+
+	// Validate label options
+	for _, acronym := range c.Labels.Acronyms {
+		if acronym == "" || strings.ContainsAny(acronym, " _.") {
+			return fmt.Errorf("invalid labels.acronyms entry: %q (must be a single word)", acronym)
+		}
+	}
+	for prefix, format := range c.Labels.YesNoPrefixes {
+		if !strings.Contains(format, "{label}") {
+			return fmt.Errorf("invalid labels.yesno_prefixes.%s: %q (must contain {label})", prefix, format)
+		}
+	}
+
+	// Validate merge options
+	if c.Merge && c.Refinements {
+		return fmt.Errorf("merge and refinements cannot be used together")
+	}
+	if c.MergeDroppedFields != "" {
+		mergeDropped := strings.ToLower(c.MergeDroppedFields)
+		if mergeDropped != MergeDroppedComment && mergeDropped != MergeDroppedRemove {
+			return fmt.Errorf("invalid merge_dropped_fields: %s (must be one of: %v)", c.MergeDroppedFields, []string{MergeDroppedComment, MergeDroppedRemove})
+		}
+		c.MergeDroppedFields = mergeDropped
+	}
+
+	// Validate model file options
+	if c.ModelGrouping != "" {
+		grouping := strings.ToLower(c.ModelGrouping)
+		validGroupings := []string{ModelGroupingSingle, ModelGroupingFolder, ModelGroupingTag}
+		valid = false
+		for _, validGrouping := range validGroupings {
+			if grouping == validGrouping {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("invalid model_grouping: %s (must be one of: %v)", c.ModelGrouping, validGroupings)
+		}
+		c.ModelGrouping = grouping
+	}
+	if c.ModelFiles && c.Connection == "" && len(c.Connections) == 0 {
+		return fmt.Errorf("connection or connections is required when model_files is enabled")
+	}
+	if c.FiscalMonthOffset < -11 || c.FiscalMonthOffset > 11 {
+		return fmt.Errorf("invalid fiscal_month_offset: %d (must be between -11 and 11)", c.FiscalMonthOffset)
+	}
+	if c.WeekStartDay != "" {
+		day := strings.ToLower(c.WeekStartDay)
+		valid := false
+		for _, validDay := range enums.WeekStartDays {
+			if day == string(validDay) {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("invalid week_start_day: %s (must be one of: %v)", c.WeekStartDay, enums.WeekStartDays)
+		}
+		c.WeekStartDay = day
+	}
+	if !c.ModelFiles && (c.FiscalMonthOffset != 0 || c.WeekStartDay != "") {
+		return fmt.Errorf("fiscal_month_offset and week_start_day require model_files")
+	}
+	for i, datagroup := range c.Datagroups {
+		if datagroup.Name == "" {
+			return fmt.Errorf("datagroups[%d]: name is required", i)
+		}
+		if datagroup.SQLTrigger == "" && datagroup.IntervalTrigger == "" {
+			return fmt.Errorf("datagroup %s: sql_trigger or interval_trigger is required", datagroup.Name)
+		}
+	}
+
+	// Validate measure templates
+	for i := range c.MeasureTemplates {
+		template := &c.MeasureTemplates[i]
+		if template.Type == "" {
+			return fmt.Errorf("measure_templates[%d]: type is required", i)
+		}
+		if len(template.DataTypes) == 0 && len(template.Columns) == 0 && !template.PrimaryKey {
+			return fmt.Errorf("measure_templates[%d]: data_types, columns or primary_key is required", i)
+		}
+		if template.ValueFormatName != "" && template.InheritValueFormat {
+			return fmt.Errorf("measure_templates[%d]: value_format_name and inherit_value_format cannot both be set", i)
+		}
+		for j, pattern := range template.Columns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("measure_templates[%d]: invalid column pattern %q: %w", i, pattern, err)
+			}
+			template.Columns[j] = strings.ToLower(pattern)
+		}
+		for j, dataType := range template.DataTypes {
+			template.DataTypes[j] = strings.ToUpper(dataType)
+		}
+		template.Type = strings.ToLower(template.Type)
+	}
+
+	// Validate detail set options
+	for i, pattern := range c.DetailSet.Columns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("detail_set: invalid column pattern %q: %w", pattern, err)
+		}
+		c.DetailSet.Columns[i] = strings.ToLower(pattern)
+	}
+	if c.DetailSet.MaxFields < 0 {
+		return fmt.Errorf("detail_set: max_fields cannot be negative")
+	}
+
+	// Validate duration rules
+	validIntervals := []string{"second", "minute", "hour", "day", "week", "month", "quarter", "year"}
+	for i := range c.Durations {
+		rule := &c.Durations[i]
+		if rule.Start == "" || rule.End == "" {
+			return fmt.Errorf("durations[%d]: start and end are required", i)
+		}
+		wildcards := strings.Count(rule.Start, "*")
+		if wildcards > 1 || strings.Count(rule.End, "*") != wildcards || strings.Count(rule.Name, "*") > wildcards {
+			return fmt.Errorf("durations[%d]: start and end must both contain the same single * or none", i)
+		}
+		rule.Start = strings.ToLower(rule.Start)
+		rule.End = strings.ToLower(rule.End)
+		for j, interval := range rule.Intervals {
+			interval = strings.ToLower(interval)
 			valid := false
-			for _, validTf := range validTimeframes {
-				if strings.ToLower(tf) == validTf {
+			for _, validInterval := range validIntervals {
+				if interval == validInterval {
 					valid = true
 					break
 				}
 			}
 			if !valid {
-				return fmt.Errorf("invalid timeframe: %s (must be one of: %v)", tf, validTimeframes)
+				return fmt.Errorf("durations[%d]: invalid interval: %s (must be one of: %v)", i, rule.Intervals[j], validIntervals)
+			}
+			rule.Intervals[j] = interval
+		}
+	}
+
+	// Validate governance options
+	grants := make(map[string]bool, len(c.AccessGrants))
+	for i, grant := range c.AccessGrants {
+		if grant.Name == "" {
+			return fmt.Errorf("access_grants[%d]: name is required", i)
+		}
+		if grant.UserAttribute == "" || len(grant.AllowedValues) == 0 {
+			return fmt.Errorf("access_grant %s: user_attribute and allowed_values are required", grant.Name)
+		}
+		if grants[grant.Name] {
+			return fmt.Errorf("access_grant %s is defined more than once", grant.Name)
+		}
+		grants[grant.Name] = true
+	}
+	for _, name := range c.PII.AccessGrants {
+		if !grants[name] {
+			return fmt.Errorf("pii: access grant %s is not defined in access_grants", name)
+		}
+	}
+	if c.PII.Masking != "" {
+		masking := strings.ToLower(c.PII.Masking)
+		validMaskings := []string{PIIMaskingNone, PIIMaskingHidden, PIIMaskingSHA256, PIIMaskingRedacted}
+		valid = false
+		for _, validMasking := range validMaskings {
+			if masking == validMasking {
+				valid = true
+				break
 			}
 		}
+		if !valid {
+			return fmt.Errorf("invalid pii masking: %s (must be one of: %v)", c.PII.Masking, validMaskings)
+		}
+		c.PII.Masking = masking
+	}
+
+	// Validate localization options
+	for _, locale := range append([]string{c.GetDefaultLocale()}, c.Localization.Locales...) {
+		if !IsValidLocale(locale) {
+			return fmt.Errorf("localization: invalid locale %q", locale)
+		}
+	}
+	if c.Localization.Level != "" {
+		level := strings.ToLower(c.Localization.Level)
+		if level != LocalizationLevelStrict && level != LocalizationLevelPermissive {
+			return fmt.Errorf("invalid localization level: %s (must be one of: %v)", c.Localization.Level,
+				[]string{LocalizationLevelStrict, LocalizationLevelPermissive})
+		}
+		c.Localization.Level = level
+	}
+
+	// Validate timeframes if provided
+	if err := validateTimeframes("timeframes", c.Timeframes, false); err != nil {
+		return err
+	}
+	if err := validateTimeframes("dimension_groups.date_timeframes", c.DimensionGroups.DateTimeframes, true); err != nil {
+		return err
+	}
+	if err := validateTimeframes("dimension_groups.datetime_timeframes", c.DimensionGroups.DatetimeTimeframes, false); err != nil {
+		return err
 	}
+	if err := validateTimeframes("dimension_groups.timestamp_timeframes", c.DimensionGroups.TimestampTimeframes, false); err != nil {
+		return err
+	}
+
+	return nil
+}
 
+// validateTimeframes checks that timeframes are known and, for DATE columns, do not need a
+// time of day
+func validateTimeframes(option string, timeframes []string, date bool) error {
+	for _, tf := range timeframes {
+		timeframe := enums.LookerTimeFrame(strings.ToLower(tf))
+		if !timeframe.IsValid() {
+			return fmt.Errorf("invalid timeframe in %s: %s (must be one of: %v)", option, tf, enums.TimeFrames)
+		}
+		if date && timeframe.IsTimeOfDay() {
+			return fmt.Errorf("invalid timeframe in %s: %s needs a time of day, which DATE columns lack", option, tf)
+		}
+	}
 	return nil
 }
 
@@ -246,6 +749,116 @@ func (c *Config) GetOutputPath(filename string) string {
 	return fmt.Sprintf("%s/%s", strings.TrimRight(c.OutputDir, "/"), filename)
 }
 
+// GetIncludePath returns the LookML include path for a file relative to the output directory.
+// IncludeRoot is the location of the output directory inside the Looker project
+// (e.g. "lookml/views" or "//imported_project"); by default the output directory is the project root.
+func (c *Config) GetIncludePath(filename string) string {
+	root := strings.TrimRight(c.IncludeRoot, "/")
+	if root == "" {
+		return "/" + filename
+	}
+	if !strings.HasPrefix(root, "/") {
+		root = "/" + root
+	}
+	return fmt.Sprintf("%s/%s", root, filename)
+}
+
+// GetConnection returns the Looker connection for a dbt database (the target project),
+// falling back to the default connection. A database that is not mapped in connections
+// is an error when there is no default connection.
+func (c *Config) GetConnection(database string) (string, error) {
+	for name, connection := range c.Connections {
+		if strings.EqualFold(name, database) {
+			return connection, nil
+		}
+	}
+	if c.Connection == "" {
+		return "", fmt.Errorf("no connection for database %s: add it to connections or set a default connection", database)
+	}
+	return c.Connection, nil
+}
+
+// GetPIITags returns the dbt column tags that mark personal data
+func (c *Config) GetPIITags() []string {
+	if len(c.PII.Tags) == 0 {
+		return []string{DefaultPIITag}
+	}
+	return c.PII.Tags
+}
+
+// GetPIIAccessGrants returns the access grants required by fields holding personal data,
+// which default to all configured access grants
+func (c *Config) GetPIIAccessGrants() []string {
+	if len(c.PII.AccessGrants) > 0 {
+		return c.PII.AccessGrants
+	}
+	names := make([]string, 0, len(c.AccessGrants))
+	for _, grant := range c.AccessGrants {
+		names = append(names, grant.Name)
+	}
+	return names
+}
+
+// GetNamingStrategy returns the naming strategy, which is table_name when only the older
+// use_table_name option is set
+func (c *Config) GetNamingStrategy() string {
+	if c.Naming.Strategy != "" {
+		return c.Naming.Strategy
+	}
+	if c.UseTableName {
+		return NamingStrategyTableName
+	}
+	return NamingStrategyDbtName
+}
+
+// GetNestedSeparator returns the separator joining the parts of nested names
+func (c *Config) GetNestedSeparator() string {
+	if c.Naming.NestedSeparator == "" {
+		return DefaultNestedSeparator
+	}
+	return c.Naming.NestedSeparator
+}
+
+// GetNestedArrayOverflow returns how arrays nested beyond the depth limit are handled
+func (c *Config) GetNestedArrayOverflow() string {
+	if c.NestedArrays.Overflow == "" {
+		return NestedArrayOverflowDrop
+	}
+	return c.NestedArrays.Overflow
+}
+
+// GetLabelAcronyms returns the words written as acronyms in labels
+func (c *Config) GetLabelAcronyms() []string {
+	if len(c.Labels.Acronyms) == 0 {
+		return DefaultLabelAcronyms
+	}
+	return c.Labels.Acronyms
+}
+
+// GetLabelLowercaseWords returns the words kept in lower case inside labels
+func (c *Config) GetLabelLowercaseWords() []string {
+	if len(c.Labels.LowercaseWords) == 0 {
+		return DefaultLabelLowercaseWords
+	}
+	return c.Labels.LowercaseWords
+}
+
+// GetDefaultLocale returns the locale of existing labels and descriptions
+func (c *Config) GetDefaultLocale() string {
+	if c.Localization.DefaultLocale == "" {
+		return DefaultLocale
+	}
+	return c.Localization.DefaultLocale
+}
+
+// GetLocalizationLevel returns the localization_level written to the manifest
+func (c *Config) GetLocalizationLevel() string {
+	if c.Localization.Level == "" {
+		return LocalizationLevelPermissive
+	}
+	return c.Localization.Level
+}
+
 // GetTargetPath returns the full target path for a given filename
 func (c *Config) GetTargetPath(filename string) string {
 	if c.TargetDir == "" || c.TargetDir == "." {
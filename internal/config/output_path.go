@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// OutputPathFuncs are the functions available in output_path templates besides the
+// text/template builtins
+var OutputPathFuncs = template.FuncMap{
+	// default returns the fallback when the value is missing or empty
+	"default": func(fallback string, value interface{}) string {
+		if value == nil {
+			return fallback
+		}
+		text := fmt.Sprint(value)
+		if text == "" {
+			return fallback
+		}
+		return text
+	},
+	// tagValue returns the rest of the first tag starting with prefix, e.g. "sales" for
+	// tagValue "domain:" and the tag "domain:sales"
+	"tagValue": func(prefix string, tags []string) string {
+		for _, tag := range tags {
+			if strings.HasPrefix(tag, prefix) {
+				return strings.TrimPrefix(tag, prefix)
+			}
+		}
+		return ""
+	},
+	"lower": strings.ToLower,
+}
+
+// ParseOutputPath parses an output_path template. Referencing a missing meta key is an
+// error when the template is executed; use index and default to fall back instead.
+func ParseOutputPath(text string) (*template.Template, error) {
+	tmpl, err := template.New("output_path").Funcs(OutputPathFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output_path: %w", err)
+	}
+	return tmpl, nil
+}
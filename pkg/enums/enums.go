@@ -1,5 +1,7 @@
 package enums
 
+import "strings"
+
 // SupportedDbtAdapters represents the supported dbt adapters
 type SupportedDbtAdapters string
 
@@ -12,19 +14,42 @@ const (
 type LookerMeasureType string
 
 const (
-	MeasureNumber          LookerMeasureType = "number"
-	MeasureString          LookerMeasureType = "string"
-	MeasureAverage         LookerMeasureType = "average"
-	MeasureAverageDistinct LookerMeasureType = "average_distinct"
-	MeasureCount           LookerMeasureType = "count"
-	MeasureCountDistinct   LookerMeasureType = "count_distinct"
-	MeasureList            LookerMeasureType = "list"
-	MeasureMax             LookerMeasureType = "max"
-	MeasureMedian          LookerMeasureType = "median"
-	MeasureMedianDistinct  LookerMeasureType = "median_distinct"
-	MeasureMin             LookerMeasureType = "min"
-	MeasureSum             LookerMeasureType = "sum"
-	MeasureSumDistinct     LookerMeasureType = "sum_distinct"
+	MeasureNumber           LookerMeasureType = "number"
+	MeasureString           LookerMeasureType = "string"
+	MeasureAverage          LookerMeasureType = "average"
+	MeasureAverageDistinct  LookerMeasureType = "average_distinct"
+	MeasureCount            LookerMeasureType = "count"
+	MeasureCountDistinct    LookerMeasureType = "count_distinct"
+	MeasureList             LookerMeasureType = "list"
+	MeasureMax              LookerMeasureType = "max"
+	MeasureMedian           LookerMeasureType = "median"
+	MeasureMedianDistinct   LookerMeasureType = "median_distinct"
+	MeasureMin              LookerMeasureType = "min"
+	MeasureSum              LookerMeasureType = "sum"
+	MeasureSumDistinct      LookerMeasureType = "sum_distinct"
+	MeasurePeriodOverPeriod LookerMeasureType = "period_over_period"
+)
+
+// LookerPeriod represents the period a period_over_period measure compares against
+type LookerPeriod string
+
+const (
+	PeriodDate          LookerPeriod = "date"
+	PeriodWeek          LookerPeriod = "week"
+	PeriodMonth         LookerPeriod = "month"
+	PeriodQuarter       LookerPeriod = "quarter"
+	PeriodFiscalQuarter LookerPeriod = "fiscal_quarter"
+	PeriodYear          LookerPeriod = "year"
+	PeriodFiscalYear    LookerPeriod = "fiscal_year"
+)
+
+// LookerPeriodOverPeriodKind represents how a period_over_period measure compares periods
+type LookerPeriodOverPeriodKind string
+
+const (
+	PeriodKindPrevious       LookerPeriodOverPeriodKind = "previous"
+	PeriodKindDifference     LookerPeriodOverPeriodKind = "difference"
+	PeriodKindRelativeChange LookerPeriodOverPeriodKind = "relative_change"
 )
 
 // LookerValueFormatName represents Looker value format names
@@ -54,9 +79,10 @@ const (
 type LookerBigQueryDataType string
 
 const (
-	DataTypeNumber LookerBigQueryDataType = "number"
-	DataTypeYesNo  LookerBigQueryDataType = "yesno"
-	DataTypeString LookerBigQueryDataType = "string"
+	DataTypeNumber   LookerBigQueryDataType = "number"
+	DataTypeYesNo    LookerBigQueryDataType = "yesno"
+	DataTypeString   LookerBigQueryDataType = "string"
+	DataTypeLocation LookerBigQueryDataType = "location"
 	// Note: DATE, DATETIME, and TIMESTAMP are not valid Looker dimension types
 	// They should be dimension_groups with type "time" or "date", not regular dimensions
 	// These constants are kept for backward compatibility but should never be returned by GetLookerType
@@ -79,6 +105,8 @@ func GetLookerType(bqType string) LookerBigQueryDataType {
 		// Date/time types should be dimension_groups, not dimensions
 		// If they end up as regular dimensions, use string type
 		return DataTypeString
+	case "GEOGRAPHY":
+		return DataTypeLocation
 	default:
 		return DataTypeString
 	}
@@ -95,6 +123,134 @@ const (
 	TimeFrameQuarter LookerTimeFrame = "quarter"
 	TimeFrameYear    LookerTimeFrame = "year"
 	TimeFrameTime    LookerTimeFrame = "time"
+
+	// Time of day timeframes
+	TimeFrameTimeOfDay      LookerTimeFrame = "time_of_day"
+	TimeFrameHour           LookerTimeFrame = "hour"
+	TimeFrameHour2          LookerTimeFrame = "hour2"
+	TimeFrameHour3          LookerTimeFrame = "hour3"
+	TimeFrameHour4          LookerTimeFrame = "hour4"
+	TimeFrameHour6          LookerTimeFrame = "hour6"
+	TimeFrameHour8          LookerTimeFrame = "hour8"
+	TimeFrameHour12         LookerTimeFrame = "hour12"
+	TimeFrameHourOfDay      LookerTimeFrame = "hour_of_day"
+	TimeFrameMinute         LookerTimeFrame = "minute"
+	TimeFrameMinute2        LookerTimeFrame = "minute2"
+	TimeFrameMinute3        LookerTimeFrame = "minute3"
+	TimeFrameMinute5        LookerTimeFrame = "minute5"
+	TimeFrameMinute10       LookerTimeFrame = "minute10"
+	TimeFrameMinute15       LookerTimeFrame = "minute15"
+	TimeFrameMinute30       LookerTimeFrame = "minute30"
+	TimeFrameSecond         LookerTimeFrame = "second"
+	TimeFrameMillisecond    LookerTimeFrame = "millisecond"
+	TimeFrameMillisecond2   LookerTimeFrame = "millisecond2"
+	TimeFrameMillisecond5   LookerTimeFrame = "millisecond5"
+	TimeFrameMillisecond10  LookerTimeFrame = "millisecond10"
+	TimeFrameMillisecond20  LookerTimeFrame = "millisecond20"
+	TimeFrameMillisecond50  LookerTimeFrame = "millisecond50"
+	TimeFrameMillisecond100 LookerTimeFrame = "millisecond100"
+	TimeFrameMillisecond200 LookerTimeFrame = "millisecond200"
+	TimeFrameMillisecond250 LookerTimeFrame = "millisecond250"
+	TimeFrameMillisecond500 LookerTimeFrame = "millisecond500"
+	TimeFrameMicrosecond    LookerTimeFrame = "microsecond"
+
+	// Date part timeframes
+	TimeFrameDayOfWeek      LookerTimeFrame = "day_of_week"
+	TimeFrameDayOfWeekIndex LookerTimeFrame = "day_of_week_index"
+	TimeFrameDayOfMonth     LookerTimeFrame = "day_of_month"
+	TimeFrameDayOfYear      LookerTimeFrame = "day_of_year"
+	TimeFrameWeekOfYear     LookerTimeFrame = "week_of_year"
+	TimeFrameMonthNum       LookerTimeFrame = "month_num"
+	TimeFrameMonthName      LookerTimeFrame = "month_name"
+	TimeFrameQuarterOfYear  LookerTimeFrame = "quarter_of_year"
+	TimeFrameYesNo          LookerTimeFrame = "yesno"
+
+	// Fiscal timeframes, shifted by the model's fiscal_month_offset
+	TimeFrameFiscalMonthNum      LookerTimeFrame = "fiscal_month_num"
+	TimeFrameFiscalQuarter       LookerTimeFrame = "fiscal_quarter"
+	TimeFrameFiscalQuarterOfYear LookerTimeFrame = "fiscal_quarter_of_year"
+	TimeFrameFiscalYear          LookerTimeFrame = "fiscal_year"
+)
+
+// TimeFrames lists every timeframe of a time dimension group
+var TimeFrames = []LookerTimeFrame{
+	TimeFrameRaw, TimeFrameTime, TimeFrameTimeOfDay, TimeFrameDate,
+	TimeFrameHour, TimeFrameHour2, TimeFrameHour3, TimeFrameHour4, TimeFrameHour6, TimeFrameHour8, TimeFrameHour12, TimeFrameHourOfDay,
+	TimeFrameMinute, TimeFrameMinute2, TimeFrameMinute3, TimeFrameMinute5, TimeFrameMinute10, TimeFrameMinute15, TimeFrameMinute30,
+	TimeFrameSecond, TimeFrameMillisecond, TimeFrameMillisecond2, TimeFrameMillisecond5, TimeFrameMillisecond10,
+	TimeFrameMillisecond20, TimeFrameMillisecond50, TimeFrameMillisecond100, TimeFrameMillisecond200,
+	TimeFrameMillisecond250, TimeFrameMillisecond500, TimeFrameMicrosecond,
+	TimeFrameWeek, TimeFrameDayOfWeek, TimeFrameDayOfWeekIndex, TimeFrameDayOfMonth, TimeFrameDayOfYear, TimeFrameWeekOfYear,
+	TimeFrameMonth, TimeFrameMonthNum, TimeFrameMonthName,
+	TimeFrameQuarter, TimeFrameQuarterOfYear, TimeFrameYear, TimeFrameYesNo,
+	TimeFrameFiscalMonthNum, TimeFrameFiscalQuarter, TimeFrameFiscalQuarterOfYear, TimeFrameFiscalYear,
+}
+
+// IsValid reports whether the timeframe is part of the Looker vocabulary
+func (t LookerTimeFrame) IsValid() bool {
+	for _, timeframe := range TimeFrames {
+		if t == timeframe {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTimeOfDay reports whether a timeframe needs a time of day, which DATE columns lack
+func (t LookerTimeFrame) IsTimeOfDay() bool {
+	switch t {
+	case TimeFrameTime, TimeFrameTimeOfDay, TimeFrameHourOfDay, TimeFrameSecond, TimeFrameMicrosecond:
+		return true
+	}
+	value := string(t)
+	return strings.HasPrefix(value, "hour") || strings.HasPrefix(value, "minute") || strings.HasPrefix(value, "millisecond")
+}
+
+// IsFiscal reports whether a timeframe depends on the model's fiscal_month_offset
+func (t LookerTimeFrame) IsFiscal() bool {
+	return strings.HasPrefix(string(t), "fiscal_")
+}
+
+// LookerWeekStartDay represents the first day of the week of a model's week timeframes
+type LookerWeekStartDay string
+
+const (
+	WeekStartMonday    LookerWeekStartDay = "monday"
+	WeekStartTuesday   LookerWeekStartDay = "tuesday"
+	WeekStartWednesday LookerWeekStartDay = "wednesday"
+	WeekStartThursday  LookerWeekStartDay = "thursday"
+	WeekStartFriday    LookerWeekStartDay = "friday"
+	WeekStartSaturday  LookerWeekStartDay = "saturday"
+	WeekStartSunday    LookerWeekStartDay = "sunday"
+)
+
+// WeekStartDays lists the valid week_start_day values
+var WeekStartDays = []LookerWeekStartDay{
+	WeekStartMonday, WeekStartTuesday, WeekStartWednesday, WeekStartThursday,
+	WeekStartFriday, WeekStartSaturday, WeekStartSunday,
+}
+
+// LookerTimeDatatype represents the datatype of the column of a time dimension group
+type LookerTimeDatatype string
+
+const (
+	TimeDatatypeDate      LookerTimeDatatype = "date"
+	TimeDatatypeDatetime  LookerTimeDatatype = "datetime"
+	TimeDatatypeTimestamp LookerTimeDatatype = "timestamp"
+)
+
+// LookerDurationInterval represents the intervals of a duration dimension group
+type LookerDurationInterval string
+
+const (
+	IntervalSecond  LookerDurationInterval = "second"
+	IntervalMinute  LookerDurationInterval = "minute"
+	IntervalHour    LookerDurationInterval = "hour"
+	IntervalDay     LookerDurationInterval = "day"
+	IntervalWeek    LookerDurationInterval = "week"
+	IntervalMonth   LookerDurationInterval = "month"
+	IntervalQuarter LookerDurationInterval = "quarter"
+	IntervalYear    LookerDurationInterval = "year"
 )
 
 // LookerRelationshipType represents relationship types in Looker
@@ -140,3 +296,15 @@ const (
 	ResourceUnitTest      DbtResourceType = "unit_test"
 	ResourceFixture       DbtResourceType = "fixture"
 )
+
+// DbtConstraintType represents the type of a dbt model or column constraint
+type DbtConstraintType string
+
+const (
+	ConstraintNotNull    DbtConstraintType = "not_null"
+	ConstraintUnique     DbtConstraintType = "unique"
+	ConstraintPrimaryKey DbtConstraintType = "primary_key"
+	ConstraintForeignKey DbtConstraintType = "foreign_key"
+	ConstraintCheck      DbtConstraintType = "check"
+	ConstraintCustom     DbtConstraintType = "custom"
+)
@@ -36,7 +36,13 @@ func TestGetLookerType(t *testing.T) {
 		{"TIMESTAMP", "TIMESTAMP", DataTypeString},
 		{"TIME", "TIME", DataTypeString},
 
+		// Geography and interval types
+		{"GEOGRAPHY", "GEOGRAPHY", DataTypeLocation},
+		{"INTERVAL", "INTERVAL", DataTypeString},
+		{"JSON", "JSON", DataTypeString},
+
 		// Complex types
+		{"RANGE<DATE>", "RANGE<DATE>", DataTypeString},
 		{"ARRAY<STRING>", "ARRAY<STRING>", DataTypeString},
 		{"STRUCT<field STRING>", "STRUCT<field STRING>", DataTypeString},
 
@@ -81,6 +87,15 @@ func TestMeasureType(t *testing.T) {
 	assert.Equal(t, "average", string(MeasureAverage))
 	assert.Equal(t, "min", string(MeasureMin))
 	assert.Equal(t, "max", string(MeasureMax))
+	assert.Equal(t, "period_over_period", string(MeasurePeriodOverPeriod))
+}
+
+// TestPeriodOverPeriod tests period and kind enums of period_over_period measures
+func TestPeriodOverPeriod(t *testing.T) {
+	assert.Equal(t, "fiscal_quarter", string(PeriodFiscalQuarter))
+	assert.Equal(t, "year", string(PeriodYear))
+	assert.Equal(t, "previous", string(PeriodKindPrevious))
+	assert.Equal(t, "relative_change", string(PeriodKindRelativeChange))
 }
 
 // TestTimeFrame tests timeframe enums
@@ -101,6 +116,43 @@ func TestTimeFrame(t *testing.T) {
 
 	for i, tf := range timeframes {
 		assert.Equal(t, expectedValues[i], string(tf))
+		assert.True(t, tf.IsValid())
+	}
+
+	assert.True(t, TimeFrameHourOfDay.IsValid())
+	assert.True(t, TimeFrameFiscalYear.IsValid())
+	assert.False(t, LookerTimeFrame("fortnight").IsValid())
+
+	for _, tf := range []LookerTimeFrame{TimeFrameTime, TimeFrameTimeOfDay, TimeFrameHour, TimeFrameHour6, TimeFrameHourOfDay, TimeFrameMinute15, TimeFrameSecond, TimeFrameMillisecond, TimeFrameMicrosecond} {
+		assert.True(t, tf.IsTimeOfDay(), string(tf))
+	}
+	for _, tf := range []LookerTimeFrame{TimeFrameRaw, TimeFrameDate, TimeFrameDayOfWeek, TimeFrameWeekOfYear, TimeFrameMonthName, TimeFrameFiscalQuarter, TimeFrameYesNo} {
+		assert.False(t, tf.IsTimeOfDay(), string(tf))
+	}
+
+	assert.True(t, TimeFrameFiscalQuarterOfYear.IsFiscal())
+	assert.False(t, TimeFrameQuarterOfYear.IsFiscal())
+}
+
+// TestDurationInterval tests duration interval enums
+func TestDurationInterval(t *testing.T) {
+	intervals := []LookerDurationInterval{
+		IntervalSecond,
+		IntervalMinute,
+		IntervalHour,
+		IntervalDay,
+		IntervalWeek,
+		IntervalMonth,
+		IntervalQuarter,
+		IntervalYear,
+	}
+
+	expectedValues := []string{
+		"second", "minute", "hour", "day", "week", "month", "quarter", "year",
+	}
+
+	for i, interval := range intervals {
+		assert.Equal(t, expectedValues[i], string(interval))
 	}
 }
 
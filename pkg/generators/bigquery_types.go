@@ -0,0 +1,299 @@
+package generators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+)
+
+// BigQuery data types with dedicated handling
+const (
+	dataTypeGeography = "GEOGRAPHY"
+	dataTypeJSON      = "JSON"
+	dataTypeBytes     = "BYTES"
+	dataTypeTime      = "TIME"
+	dataTypeInterval  = "INTERVAL"
+	dataTypeRange     = "RANGE"
+)
+
+// Suffixes of latitude and longitude columns that are paired into location dimensions
+var (
+	latitudeSuffixes  = []string{"latitude", "lat"}
+	longitudeSuffixes = []string{"longitude", "long", "lng", "lon"}
+)
+
+// columnDataType returns the upper case data type of a column, or "" when unknown
+func columnDataType(column *models.DbtModelColumn) string {
+	if column.DataType == nil {
+		return ""
+	}
+	return strings.ToUpper(strings.TrimSpace(*column.DataType))
+}
+
+// rangeElementType returns the element type of a RANGE<T> column, or "" for other columns
+func rangeElementType(column *models.DbtModelColumn) string {
+	dataType := columnDataType(column)
+	if !strings.HasPrefix(dataType, dataTypeRange+"<") || !strings.HasSuffix(dataType, ">") {
+		return ""
+	}
+	return strings.TrimSpace(dataType[len(dataTypeRange)+1 : len(dataType)-1])
+}
+
+// arrayElementType returns the element type of a single-value ARRAY<T> column, such as INT64
+// for ARRAY<INT64>, or "" for ARRAY<STRUCT<...>> and other columns
+func arrayElementType(column *models.DbtModelColumn) string {
+	dataType := columnDataType(column)
+	var elementType string
+	switch {
+	case strings.HasPrefix(dataType, "ARRAY<") && strings.HasSuffix(dataType, ">"):
+		elementType = dataType[len("ARRAY<") : len(dataType)-1]
+	case dataType == "ARRAY" && len(column.InnerTypes) == 1:
+		// Catalog columns keep the element type apart
+		elementType = strings.ToUpper(column.InnerTypes[0])
+	default:
+		return ""
+	}
+
+	// Parameterized types such as STRING(10) or NUMERIC(10, 2) take their base type
+	if idx := strings.Index(elementType, "("); idx != -1 {
+		elementType = elementType[:idx]
+	}
+	elementType = strings.TrimSpace(elementType)
+	if elementType == "" || strings.HasPrefix(elementType, "STRUCT") || strings.HasPrefix(elementType, "ARRAY") {
+		return ""
+	}
+	return elementType
+}
+
+// applyDataTypeSettings adjusts a dimension to the BigQuery type of its column.
+// GEOGRAPHY points become location dimensions, TIME and INTERVAL values are formatted as strings, and
+// BYTES, JSON and RANGE columns are hidden unless column meta says otherwise.
+func (g *DimensionGenerator) applyDataTypeSettings(dimension *models.LookMLDimension, column *models.DbtModelColumn) {
+	dataType := columnDataType(column)
+
+	switch {
+	case dataType == dataTypeGeography:
+		dimension.SQLLatitude = fmt.Sprintf("ST_Y(%s)", dimension.SQL)
+		dimension.SQLLongitude = fmt.Sprintf("ST_X(%s)", dimension.SQL)
+		dimension.SQL = ""
+	case dataType == dataTypeTime:
+		dimension.SQL = fmt.Sprintf("FORMAT_TIME('%%H:%%M:%%S', %s)", dimension.SQL)
+	case dataType == dataTypeInterval:
+		// Looker has no interval dimension type; the canonical format (e.g. 0-0 1 2:30:00) is kept
+		dimension.SQL = fmt.Sprintf("CAST(%s AS STRING)", dimension.SQL)
+	case dataType == dataTypeBytes || dataType == dataTypeJSON || rangeElementType(column) != "":
+		if g.getDimensionHidden(column) == nil {
+			dimension.Hidden = utils.BoolPtr(true)
+		}
+	}
+
+	if column.Meta != nil && column.Meta.Looker != nil && column.Meta.Looker.Dimension != nil {
+		dimension.MapLayerName = column.Meta.Looker.Dimension.MapLayerName
+	}
+}
+
+// GenerateJSONPathDimensions generates a string dimension for every path in the
+// json_paths column meta of a JSON column, extracted with JSON_VALUE
+func (g *DimensionGenerator) GenerateJSONPathDimensions(model *models.DbtModel, column *models.DbtModelColumn) ([]models.LookMLDimension, error) {
+	if column.Meta == nil || column.Meta.Looker == nil || column.Meta.Looker.Dimension == nil ||
+		len(column.Meta.Looker.Dimension.JSONPaths) == 0 {
+		return nil, nil
+	}
+	if columnDataType(column) != dataTypeJSON {
+		return nil, fmt.Errorf("json_paths requires a JSON column, %s is %s", column.Name, columnDataType(column))
+	}
+
+	paths := column.Meta.Looker.Dimension.JSONPaths
+	names := make([]string, 0, len(paths))
+	for name := range paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	baseName := g.getDimensionNameForMainView(model, column)
+	groupLabel := g.labels.Label(baseName)
+	if label := g.getDimensionLabel(column); label != nil {
+		groupLabel = *label
+	}
+
+	dimensions := make([]models.LookMLDimension, 0, len(names))
+	for _, name := range names {
+		path := paths[name]
+		if !strings.HasPrefix(path, "$") {
+			return nil, fmt.Errorf("json_paths.%s of column %s must be a JSONPath starting with $, got %q", name, column.Name, path)
+		}
+
+		itemLabel := g.labels.Label(name)
+		dimensions = append(dimensions, models.LookMLDimension{
+			Name:           g.naming.Join(baseName, g.naming.FieldName(name)),
+			Type:           string(enums.DataTypeString),
+			SQL:            fmt.Sprintf("JSON_VALUE(%s, '%s')", g.getDimensionSQL(model, column), strings.ReplaceAll(path, "'", "\\'")),
+			GroupLabel:     &groupLabel,
+			GroupItemLabel: &itemLabel,
+		})
+	}
+	return dimensions, nil
+}
+
+// GenerateRangeDimensionGroups generates the <name>_start and <name>_end dimension
+// groups of a RANGE<DATE>, RANGE<DATETIME> or RANGE<TIMESTAMP> column
+func (g *DimensionGenerator) GenerateRangeDimensionGroups(model *models.DbtModel, column *models.DbtModelColumn) ([]models.LookMLDimensionGroup, error) {
+	elementType := rangeElementType(column)
+	if elementType == "" {
+		return nil, nil
+	}
+	if elementType != dataTypeDate && elementType != dataTypeDateTime && elementType != dataTypeTimestamp {
+		return nil, fmt.Errorf("unsupported range element type %s for column %s", elementType, column.Name)
+	}
+
+	// The bounds are dimension groups of the element type
+	boundColumn := *column
+	boundColumn.DataType = &elementType
+
+	name := g.GetDimensionName(column)
+	sql := g.getDimensionSQL(model, column)
+	bounds := []struct {
+		suffix   string
+		function string
+	}{
+		{suffix: "start", function: "RANGE_START"},
+		{suffix: "end", function: "RANGE_END"},
+	}
+
+	dimensionGroups := make([]models.LookMLDimensionGroup, 0, len(bounds))
+	for _, bound := range bounds {
+		dimensionGroup := models.LookMLDimensionGroup{
+			Name:        fmt.Sprintf("%s_%s", name, bound.suffix),
+			Type:        dimGroupTypeTime,
+			SQL:         fmt.Sprintf("%s(%s)", bound.function, sql),
+			Description: g.getDimensionDescription(column),
+			GroupLabel:  g.GetDimensionGroupLabel(column),
+			Timeframes:  g.getDimensionGroupTimeframes(&boundColumn),
+			ConvertTZ:   g.getDimensionGroupConvertTZ(&boundColumn),
+			Datatype:    g.getDimensionGroupDatatype(&boundColumn),
+		}
+		if label := g.getDimensionLabel(column); label != nil {
+			boundLabel := fmt.Sprintf("%s %s", *label, g.labels.Label(bound.suffix))
+			dimensionGroup.Label = &boundLabel
+		}
+		dimensionGroups = append(dimensionGroups, dimensionGroup)
+	}
+	return dimensionGroups, nil
+}
+
+// generateLocationDimensions generates location dimensions from the locations in model
+// meta and from number dimensions named <prefix>_latitude and <prefix>_longitude (or
+// _lat and _lng/_lon/_long). Detected pairs whose name is already taken are skipped.
+func generateLocationDimensions(model *models.DbtModel, dimensions []models.LookMLDimension) ([]models.LookMLDimension, error) {
+	byName := make(map[string]*models.LookMLDimension, len(dimensions))
+	for i := range dimensions {
+		byName[dimensions[i].Name] = &dimensions[i]
+	}
+
+	var locations []models.LookMLDimension
+	paired := make(map[string]bool)
+
+	if model.Meta != nil && model.Meta.Looker != nil {
+		for _, location := range model.Meta.Looker.Locations {
+			if location.Name == "" {
+				return nil, fmt.Errorf("location name is required")
+			}
+			if byName[location.Name] != nil {
+				return nil, fmt.Errorf("location %s: a dimension with that name already exists", location.Name)
+			}
+			for _, field := range []string{location.Latitude, location.Longitude} {
+				if byName[field] == nil {
+					return nil, fmt.Errorf("location %s references %s which does not exist in view", location.Name, field)
+				}
+			}
+
+			locations = append(locations, models.LookMLDimension{
+				Name:         location.Name,
+				Type:         string(enums.DataTypeLocation),
+				SQLLatitude:  fmt.Sprintf("${%s}", location.Latitude),
+				SQLLongitude: fmt.Sprintf("${%s}", location.Longitude),
+				Label:        location.Label,
+			})
+			paired[location.Latitude] = true
+			paired[location.Longitude] = true
+		}
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	taken := make(map[string]bool, len(locations))
+	for _, location := range locations {
+		taken[location.Name] = true
+	}
+
+	for _, latitudeName := range names {
+		latitude := byName[latitudeName]
+		prefix, ok := coordinatePrefix(latitudeName, latitudeSuffixes)
+		if !ok || latitude.Type != string(enums.DataTypeNumber) || paired[latitudeName] {
+			continue
+		}
+
+		longitudeName := findLongitude(byName, prefix)
+		if longitudeName == "" || paired[longitudeName] {
+			continue
+		}
+
+		name := "location"
+		if prefix != "" {
+			name = prefix + "_location"
+		}
+		if byName[name] != nil || taken[name] {
+			continue
+		}
+
+		location := models.LookMLDimension{
+			Name:         name,
+			Type:         string(enums.DataTypeLocation),
+			SQLLatitude:  fmt.Sprintf("${%s}", latitudeName),
+			SQLLongitude: fmt.Sprintf("${%s}", longitudeName),
+			GroupLabel:   latitude.GroupLabel,
+		}
+		locations = append(locations, location)
+		taken[name] = true
+		paired[latitudeName] = true
+		paired[longitudeName] = true
+	}
+
+	return locations, nil
+}
+
+// coordinatePrefix returns the part of a dimension name before one of the coordinate
+// suffixes: "store_lat" gives "store", "latitude" gives ""
+func coordinatePrefix(name string, suffixes []string) (string, bool) {
+	for _, suffix := range suffixes {
+		if name == suffix {
+			return "", true
+		}
+		if strings.HasSuffix(name, "_"+suffix) {
+			return strings.TrimSuffix(name, "_"+suffix), true
+		}
+	}
+	return "", false
+}
+
+// findLongitude returns the number dimension holding the longitude paired with prefix
+func findLongitude(dimensions map[string]*models.LookMLDimension, prefix string) string {
+	for _, suffix := range longitudeSuffixes {
+		name := suffix
+		if prefix != "" {
+			name = prefix + "_" + suffix
+		}
+		if dimension := dimensions[name]; dimension != nil && dimension.Type == string(enums.DataTypeNumber) {
+			return name
+		}
+	}
+	return ""
+}
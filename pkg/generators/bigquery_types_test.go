@@ -0,0 +1,333 @@
+package generators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTypesModel returns a stores model with a store_id primary key and the given columns
+func createTypesModel(columns ...models.DbtModelColumn) *models.DbtModel {
+	storeID := testColumn("store_id", "INT64")
+	storeID.IsPrimaryKey = true
+	return createTestModel("stores", "core/stores.sql", append([]models.DbtModelColumn{storeID}, columns...)...)
+}
+
+// findDimension returns the dimension of view with the given name, or nil
+func findDimension(view *models.LookMLView, name string) *models.LookMLDimension {
+	for i := range view.Dimensions {
+		if view.Dimensions[i].Name == name {
+			return &view.Dimensions[i]
+		}
+	}
+	return nil
+}
+
+func TestDimensionGenerator_BigQueryTypes(t *testing.T) {
+	generator := NewDimensionGenerator(&config.Config{})
+	model := createTypesModel()
+
+	tests := []struct {
+		name     string
+		column   models.DbtModelColumn
+		expected models.LookMLDimension
+	}{
+		{
+			name:   "geography point",
+			column: models.DbtModelColumn{Name: "position", DataType: utils.StringPtr("GEOGRAPHY")},
+			expected: models.LookMLDimension{
+				Name: "position", Type: "location", Label: utils.StringPtr("Position"),
+				SQLLatitude: "ST_Y(${TABLE}.position)", SQLLongitude: "ST_X(${TABLE}.position)",
+			},
+		},
+		{
+			name:     "interval",
+			column:   models.DbtModelColumn{Name: "opening_duration", DataType: utils.StringPtr("INTERVAL")},
+			expected: models.LookMLDimension{Name: "opening_duration", Type: "string", Label: utils.StringPtr("Opening Duration"), SQL: "CAST(${TABLE}.opening_duration AS STRING)"},
+		},
+		{
+			name:     "time of day",
+			column:   models.DbtModelColumn{Name: "opens_at", DataType: utils.StringPtr("TIME")},
+			expected: models.LookMLDimension{Name: "opens_at", Type: "string", Label: utils.StringPtr("Opens At"), SQL: "FORMAT_TIME('%H:%M:%S', ${TABLE}.opens_at)"},
+		},
+		{
+			name:     "bytes are hidden",
+			column:   models.DbtModelColumn{Name: "checksum", DataType: utils.StringPtr("BYTES")},
+			expected: models.LookMLDimension{Name: "checksum", Type: "string", Label: utils.StringPtr("Checksum"), SQL: "${TABLE}.checksum", Hidden: utils.BoolPtr(true)},
+		},
+		{
+			name: "bytes shown by column meta",
+			column: models.DbtModelColumn{
+				Name: "checksum", DataType: utils.StringPtr("BYTES"),
+				Meta: drillDimensionMeta(models.DbtMetaLookerDimension{DbtMetaLookerBase: models.DbtMetaLookerBase{Hidden: utils.BoolPtr(false)}}),
+			},
+			expected: models.LookMLDimension{Name: "checksum", Type: "string", Label: utils.StringPtr("Checksum"), SQL: "${TABLE}.checksum", Hidden: utils.BoolPtr(false)},
+		},
+		{
+			name:     "json is hidden",
+			column:   models.DbtModelColumn{Name: "attributes", DataType: utils.StringPtr("JSON")},
+			expected: models.LookMLDimension{Name: "attributes", Type: "string", Label: utils.StringPtr("Attributes"), SQL: "${TABLE}.attributes", Hidden: utils.BoolPtr(true)},
+		},
+		{
+			name:     "range is hidden",
+			column:   models.DbtModelColumn{Name: "validity", DataType: utils.StringPtr("RANGE<DATE>")},
+			expected: models.LookMLDimension{Name: "validity", Type: "string", Label: utils.StringPtr("Validity"), SQL: "${TABLE}.validity", Hidden: utils.BoolPtr(true)},
+		},
+		{
+			name: "map layer",
+			column: models.DbtModelColumn{
+				Name: "country_code", DataType: utils.StringPtr("STRING"),
+				Meta: drillDimensionMeta(models.DbtMetaLookerDimension{MapLayerName: utils.StringPtr("countries")}),
+			},
+			expected: models.LookMLDimension{Name: "country_code", Type: "string", Label: utils.StringPtr("Country Code"), SQL: "${TABLE}.country_code", MapLayerName: utils.StringPtr("countries")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dimension, err := generator.GenerateDimension(model, &tt.column)
+			require.NoError(t, err)
+			require.NotNil(t, dimension)
+			assert.Equal(t, tt.expected, *dimension)
+			assert.NoError(t, dimension.Validate())
+		})
+	}
+}
+
+func TestDimensionGenerator_GenerateJSONPathDimensions(t *testing.T) {
+	generator := NewDimensionGenerator(&config.Config{})
+	model := createTypesModel()
+
+	t.Run("paths become dimensions", func(t *testing.T) {
+		column := models.DbtModelColumn{
+			Name: "attributes", DataType: utils.StringPtr("JSON"),
+			Meta: drillDimensionMeta(models.DbtMetaLookerDimension{JSONPaths: map[string]string{
+				"opening_hours": "$.opening.hours",
+				"brand":         "$.brand",
+			}}),
+		}
+
+		dimensions, err := generator.GenerateJSONPathDimensions(model, &column)
+		require.NoError(t, err)
+		require.Len(t, dimensions, 2)
+
+		assert.Equal(t, "attributes__brand", dimensions[0].Name)
+		assert.Equal(t, "JSON_VALUE(${TABLE}.attributes, '$.brand')", dimensions[0].SQL)
+		assert.Equal(t, "attributes__opening_hours", dimensions[1].Name)
+		assert.Equal(t, "JSON_VALUE(${TABLE}.attributes, '$.opening.hours')", dimensions[1].SQL)
+		assert.Equal(t, "Attributes", *dimensions[1].GroupLabel)
+		assert.Equal(t, "Opening Hours", *dimensions[1].GroupItemLabel)
+	})
+
+	t.Run("path must be a JSONPath", func(t *testing.T) {
+		column := models.DbtModelColumn{
+			Name: "attributes", DataType: utils.StringPtr("JSON"),
+			Meta: drillDimensionMeta(models.DbtMetaLookerDimension{JSONPaths: map[string]string{"brand": "brand"}}),
+		}
+
+		_, err := generator.GenerateJSONPathDimensions(model, &column)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "json_paths.brand of column attributes must be a JSONPath starting with $")
+	})
+
+	t.Run("paths require a JSON column", func(t *testing.T) {
+		column := models.DbtModelColumn{
+			Name: "name", DataType: utils.StringPtr("STRING"),
+			Meta: drillDimensionMeta(models.DbtMetaLookerDimension{JSONPaths: map[string]string{"brand": "$.brand"}}),
+		}
+
+		_, err := generator.GenerateJSONPathDimensions(model, &column)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "json_paths requires a JSON column")
+	})
+}
+
+func TestDimensionGenerator_GenerateRangeDimensionGroups(t *testing.T) {
+	generator := NewDimensionGenerator(&config.Config{})
+	model := createTypesModel()
+
+	t.Run("date range", func(t *testing.T) {
+		column := models.DbtModelColumn{
+			Name: "validity", DataType: utils.StringPtr("RANGE<DATE>"),
+			Meta: drillDimensionMeta(models.DbtMetaLookerDimension{DbtMetaLookerBase: models.DbtMetaLookerBase{Label: utils.StringPtr("Valid")}}),
+		}
+
+		groups, err := generator.GenerateRangeDimensionGroups(model, &column)
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+
+		assert.Equal(t, "validity_start", groups[0].Name)
+		assert.Equal(t, "RANGE_START(${TABLE}.validity)", groups[0].SQL)
+		assert.Equal(t, "Valid Start", *groups[0].Label)
+		assert.Equal(t, "validity_end", groups[1].Name)
+		assert.Equal(t, "RANGE_END(${TABLE}.validity)", groups[1].SQL)
+		assert.Equal(t, "time", groups[1].Type)
+		require.NotNil(t, groups[1].Datatype)
+		assert.Equal(t, enums.TimeDatatypeDate, *groups[1].Datatype)
+		assert.NotContains(t, groups[1].Timeframes, enums.TimeFrameTime)
+	})
+
+	t.Run("timestamp range", func(t *testing.T) {
+		column := models.DbtModelColumn{Name: "session", DataType: utils.StringPtr("RANGE<TIMESTAMP>")}
+
+		groups, err := generator.GenerateRangeDimensionGroups(model, &column)
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+		assert.Equal(t, "time", groups[0].Type)
+		assert.Contains(t, groups[0].Timeframes, enums.TimeFrameTime)
+	})
+
+	t.Run("other columns", func(t *testing.T) {
+		column := models.DbtModelColumn{Name: "name", DataType: utils.StringPtr("STRING")}
+
+		groups, err := generator.GenerateRangeDimensionGroups(model, &column)
+		require.NoError(t, err)
+		assert.Empty(t, groups)
+	})
+}
+
+func TestViewGenerator_LocationDimensions(t *testing.T) {
+	tests := []struct {
+		name        string
+		columns     []models.DbtModelColumn
+		locations   []models.DbtMetaLookerLocation
+		expected    map[string][2]string
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name: "detected from column names",
+			columns: []models.DbtModelColumn{
+				{Name: "store_latitude", DataType: utils.StringPtr("FLOAT64")},
+				{Name: "store_longitude", DataType: utils.StringPtr("FLOAT64")},
+				{Name: "lat", DataType: utils.StringPtr("FLOAT64")},
+				{Name: "lng", DataType: utils.StringPtr("FLOAT64")},
+			},
+			expected: map[string][2]string{
+				"store_location": {"${store_latitude}", "${store_longitude}"},
+				"location":       {"${lat}", "${lng}"},
+			},
+		},
+		{
+			name: "string columns are not paired",
+			columns: []models.DbtModelColumn{
+				{Name: "store_lat", DataType: utils.StringPtr("STRING")},
+				{Name: "store_lon", DataType: utils.StringPtr("FLOAT64")},
+			},
+			expected: map[string][2]string{},
+		},
+		{
+			name: "declared in model meta",
+			columns: []models.DbtModelColumn{
+				{Name: "y_coordinate", DataType: utils.StringPtr("FLOAT64")},
+				{Name: "x_coordinate", DataType: utils.StringPtr("FLOAT64")},
+			},
+			locations: []models.DbtMetaLookerLocation{{Name: "position", Latitude: "y_coordinate", Longitude: "x_coordinate"}},
+			expected: map[string][2]string{
+				"position": {"${y_coordinate}", "${x_coordinate}"},
+			},
+		},
+		{
+			name:        "unknown dimension in model meta",
+			locations:   []models.DbtMetaLookerLocation{{Name: "position", Latitude: "y_coordinate", Longitude: "x_coordinate"}},
+			expectError: true,
+			errorMsg:    "location position references y_coordinate which does not exist in view",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := createTypesModel(tt.columns...)
+			if tt.locations != nil {
+				model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{Locations: tt.locations}}
+			}
+
+			view, err := NewViewGenerator(&config.Config{}).GenerateView(model)
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			locations := make(map[string][2]string)
+			for _, dimension := range view.Dimensions {
+				if dimension.Type == "location" {
+					locations[dimension.Name] = [2]string{dimension.SQLLatitude, dimension.SQLLongitude}
+					assert.NoError(t, dimension.Validate())
+				}
+			}
+			assert.Equal(t, tt.expected, locations)
+		})
+	}
+}
+
+func TestBigQueryTypes_Rendered(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir, DetailSet: config.DetailSetConfig{Disabled: true}}
+
+	model := createTypesModel(
+		models.DbtModelColumn{Name: "position", DataType: utils.StringPtr("GEOGRAPHY")},
+		models.DbtModelColumn{
+			Name: "attributes", DataType: utils.StringPtr("JSON"),
+			Meta: drillDimensionMeta(models.DbtMetaLookerDimension{JSONPaths: map[string]string{"brand": "$.brand"}}),
+		},
+		models.DbtModelColumn{Name: "validity", DataType: utils.StringPtr("RANGE<DATE>")},
+	)
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "core/stores.view.lkml")
+	assert.Contains(t, content, "  dimension: position {\n"+
+		"    type: location\n"+
+		"    sql_latitude: ST_Y(${TABLE}.position) ;;\n"+
+		"    sql_longitude: ST_X(${TABLE}.position) ;;\n"+
+		"    label: \"Position\"\n"+
+		"  }\n")
+	assert.Contains(t, content, "  dimension: attributes {\n"+
+		"    type: string\n"+
+		"    sql: ${TABLE}.attributes ;;\n"+
+		"    label: \"Attributes\"\n"+
+		"    hidden: yes\n"+
+		"  }\n")
+	assert.Contains(t, content, "  dimension: attributes__brand {\n"+
+		"    type: string\n"+
+		"    sql: JSON_VALUE(${TABLE}.attributes, '$.brand') ;;\n")
+	assert.Contains(t, content, "  dimension_group: validity_start {\n"+
+		"    type: time\n"+
+		"    sql: RANGE_START(${TABLE}.validity) ;;\n"+
+		"    timeframes: [raw, date, week, month, quarter, year]\n"+
+		"    convert_tz: no\n"+
+		"    datatype: date\n")
+	assert.Contains(t, content, "  dimension_group: validity_end {\n")
+}
+
+func TestArrayElementType(t *testing.T) {
+	tests := []struct {
+		name       string
+		dataType   string
+		innerTypes []string
+		expected   string
+	}{
+		{name: "integer array", dataType: "ARRAY<INT64>", expected: "INT64"},
+		{name: "lower case", dataType: "array<date>", expected: "DATE"},
+		{name: "parameterized element", dataType: "ARRAY<NUMERIC(10, 2)>", expected: "NUMERIC"},
+		{name: "catalog inner type", dataType: "ARRAY", innerTypes: []string{"string"}, expected: "STRING"},
+		{name: "struct array", dataType: "ARRAY<STRUCT<sku STRING>>", expected: ""},
+		{name: "not an array", dataType: "STRING", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			column := &models.DbtModelColumn{Name: "values", DataType: utils.StringPtr(tt.dataType), InnerTypes: tt.innerTypes}
+			assert.Equal(t, tt.expected, arrayElementType(column))
+		})
+	}
+}
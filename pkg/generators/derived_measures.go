@@ -0,0 +1,375 @@
+package generators
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+)
+
+// expressionKeywords are SQL words allowed in measure expressions besides measure names
+var expressionKeywords = map[string]bool{
+	"AND": true, "OR": true, "NOT": true, "IS": true, "NULL": true, "TRUE": true, "FALSE": true,
+	"CASE": true, "WHEN": true, "THEN": true, "ELSE": true, "END": true,
+	"AS": true, "IN": true, "LIKE": true, "BETWEEN": true,
+}
+
+// expressionToken is a lexical token of a measure expression
+type expressionToken struct {
+	text string
+	kind expressionTokenKind
+}
+
+type expressionTokenKind int
+
+const (
+	tokenOther      expressionTokenKind = iota // operators, commas and whitespace
+	tokenIdentifier                            // measure names, functions and keywords
+	tokenReference                             // ${field} references
+	tokenNumber
+	tokenString
+	tokenOpenParen
+	tokenCloseParen
+)
+
+// resolveDerivedMeasures sets the SQL of number measures declared with an expression, given
+// by measure name. Measure names become ${measure} references and divisors are wrapped in
+// NULLIF(divisor, 0). Unknown and circular references are errors.
+func resolveDerivedMeasures(measures []models.LookMLMeasure, expressions map[string]string) error {
+	if len(expressions) == 0 {
+		return nil
+	}
+
+	byName := make(map[string]*models.LookMLMeasure, len(measures))
+	for i := range measures {
+		byName[measures[i].Name] = &measures[i]
+	}
+
+	for i := range measures {
+		measure := &measures[i]
+		expression, ok := expressions[measure.Name]
+		if !ok {
+			continue
+		}
+
+		sql, err := resolveMeasureExpression(expression, byName)
+		if err != nil {
+			return fmt.Errorf("measure %s: %w", measure.Name, err)
+		}
+		measure.SQL = &sql
+	}
+
+	if err := checkMeasureCycles(measures, byName); err != nil {
+		return err
+	}
+
+	// Formats are inherited once all SQL is resolved, since derived measures can build on each other
+	for i := range measures {
+		measure := &measures[i]
+		if _, ok := expressions[measure.Name]; ok && measure.ValueFormatName == nil {
+			measure.ValueFormatName = inheritedValueFormat(measure, byName)
+		}
+	}
+
+	return nil
+}
+
+// resolveMeasureExpression converts an expression over measure names into LookML SQL
+func resolveMeasureExpression(expression string, measures map[string]*models.LookMLMeasure) (string, error) {
+	tokens, err := tokenizeExpression(expression)
+	if err != nil {
+		return "", err
+	}
+
+	for i, token := range tokens {
+		var name string
+		switch token.kind {
+		case tokenIdentifier:
+			if nextSignificantToken(tokens, i+1).kind == tokenOpenParen || expressionKeywords[strings.ToUpper(token.text)] {
+				// Function calls and keywords are kept as written
+				continue
+			}
+			if strings.EqualFold(previousSignificantToken(tokens, i-1).text, "AS") {
+				// Types of CAST(... AS type) are not measures
+				continue
+			}
+			name = token.text
+		case tokenReference:
+			name = strings.TrimSuffix(strings.TrimPrefix(token.text, "${"), "}")
+			if strings.Contains(name, ".") {
+				// Measures of other views are not checked
+				continue
+			}
+		default:
+			continue
+		}
+
+		if _, ok := measures[name]; !ok {
+			return "", fmt.Errorf("expression references unknown measure %s", name)
+		}
+		tokens[i] = expressionToken{text: fmt.Sprintf("${%s}", name), kind: tokenReference}
+	}
+
+	return renderSafeDivision(tokens), nil
+}
+
+// renderSafeDivision renders tokens, wrapping the operand after each division in NULLIF(operand, 0).
+// Numeric literals and operands that already guard against zero (NULLIF, SAFE_DIVIDE) are left alone.
+func renderSafeDivision(tokens []expressionToken) string {
+	var builder strings.Builder
+
+	for i := 0; i < len(tokens); i++ {
+		builder.WriteString(tokens[i].text)
+		if tokens[i].text != "/" {
+			continue
+		}
+
+		start := i + 1
+		for start < len(tokens) && tokens[start].kind == tokenOther && strings.TrimSpace(tokens[start].text) == "" {
+			builder.WriteString(tokens[start].text)
+			start++
+		}
+		if start >= len(tokens) {
+			break
+		}
+
+		end := operandEnd(tokens, start)
+		operand := renderSafeDivision(tokens[start:end])
+		if tokens[start].kind == tokenNumber || isZeroGuard(tokens[start]) {
+			builder.WriteString(operand)
+		} else {
+			builder.WriteString(fmt.Sprintf("NULLIF(%s, 0)", operand))
+		}
+		i = end - 1
+	}
+
+	return builder.String()
+}
+
+// operandEnd returns the index after the operand starting at start: a parenthesized
+// group, a function call, or a single token
+func operandEnd(tokens []expressionToken, start int) int {
+	end := start + 1
+	if tokens[start].kind == tokenIdentifier {
+		next := end
+		for next < len(tokens) && tokens[next].kind == tokenOther && strings.TrimSpace(tokens[next].text) == "" {
+			next++
+		}
+		if next < len(tokens) && tokens[next].kind == tokenOpenParen {
+			end = next + 1
+			start = next
+		}
+	}
+	if tokens[start].kind != tokenOpenParen {
+		return end
+	}
+
+	depth := 0
+	for index := start; index < len(tokens); index++ {
+		switch tokens[index].kind {
+		case tokenOpenParen:
+			depth++
+		case tokenCloseParen:
+			depth--
+			if depth == 0 {
+				return index + 1
+			}
+		}
+	}
+	return len(tokens)
+}
+
+// isZeroGuard reports whether a token starts an operand that already handles zero divisors
+func isZeroGuard(token expressionToken) bool {
+	if token.kind != tokenIdentifier {
+		return false
+	}
+	name := strings.ToUpper(token.text)
+	return name == "NULLIF" || name == "SAFE_DIVIDE"
+}
+
+// nextSignificantToken returns the next token that is not whitespace
+func nextSignificantToken(tokens []expressionToken, start int) expressionToken {
+	for i := start; i < len(tokens); i++ {
+		if tokens[i].kind != tokenOther || strings.TrimSpace(tokens[i].text) != "" {
+			return tokens[i]
+		}
+	}
+	return expressionToken{}
+}
+
+// previousSignificantToken returns the last token at or before end that is not whitespace
+func previousSignificantToken(tokens []expressionToken, end int) expressionToken {
+	for i := end; i >= 0; i-- {
+		if tokens[i].kind != tokenOther || strings.TrimSpace(tokens[i].text) != "" {
+			return tokens[i]
+		}
+	}
+	return expressionToken{}
+}
+
+// tokenizeExpression splits a measure expression into tokens
+func tokenizeExpression(expression string) ([]expressionToken, error) {
+	var tokens []expressionToken
+	runes := []rune(expression)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+
+		switch {
+		case r == '$' && i+1 < len(runes) && runes[i+1] == '{':
+			for i < len(runes) && runes[i] != '}' {
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated reference in expression %q", expression)
+			}
+			i++
+			tokens = append(tokens, expressionToken{text: string(runes[start:i]), kind: tokenReference})
+		case r == '_' || unicode.IsLetter(r):
+			for i < len(runes) && (runes[i] == '_' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, expressionToken{text: string(runes[start:i]), kind: tokenIdentifier})
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, expressionToken{text: string(runes[start:i]), kind: tokenNumber})
+		case r == '\'' || r == '"':
+			i++
+			for i < len(runes) && runes[i] != r {
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string in expression %q", expression)
+			}
+			i++
+			tokens = append(tokens, expressionToken{text: string(runes[start:i]), kind: tokenString})
+		case r == '(':
+			i++
+			tokens = append(tokens, expressionToken{text: "(", kind: tokenOpenParen})
+		case r == ')':
+			i++
+			tokens = append(tokens, expressionToken{text: ")", kind: tokenCloseParen})
+		default:
+			i++
+			tokens = append(tokens, expressionToken{text: string(r), kind: tokenOther})
+		}
+	}
+
+	return tokens, nil
+}
+
+// checkMeasureCycles reports measures whose SQL references each other in a cycle
+func checkMeasureCycles(measures []models.LookMLMeasure, byName map[string]*models.LookMLMeasure) error {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(measures))
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("circular measure reference: %s", strings.Join(append(path, name), " -> "))
+		case done:
+			return nil
+		}
+
+		state[name] = visiting
+		for _, reference := range measureReferences(byName[name], byName) {
+			if err := visit(reference, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = done
+		return nil
+	}
+
+	for _, measure := range measures {
+		if err := visit(measure.Name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// measureReferences returns the measures of the same view a measure's SQL references
+func measureReferences(measure *models.LookMLMeasure, byName map[string]*models.LookMLMeasure) []string {
+	if measure == nil || measure.SQL == nil {
+		return nil
+	}
+
+	var references []string
+	for _, match := range fieldReferencePattern.FindAllStringSubmatch(*measure.SQL, -1) {
+		name := match[1]
+		if match[2] != "" || name == "TABLE" {
+			continue
+		}
+		if _, ok := byName[name]; ok {
+			references = append(references, name)
+		}
+	}
+	return references
+}
+
+// inheritedValueFormat returns the value format a derived measure inherits: the format its
+// referenced measures share. A ratio of measures sharing a format (e.g. gross_profit / revenue)
+// is a percentage, and a ratio over unformatted measures (e.g. revenue / orders) keeps the
+// format of its numerator.
+func inheritedValueFormat(measure *models.LookMLMeasure, byName map[string]*models.LookMLMeasure) *enums.LookerValueFormatName {
+	if measure.SQL == nil {
+		return nil
+	}
+
+	index := strings.Index(*measure.SQL, "/")
+	if index < 0 {
+		format, _ := sharedValueFormat(measureReferences(measure, byName), byName)
+		return format
+	}
+
+	numerator, denominator := *measure.SQL, (*measure.SQL)[index+1:]
+	numerator = numerator[:index]
+	numeratorFormat, ok := sharedValueFormat(measureReferences(&models.LookMLMeasure{SQL: &numerator}, byName), byName)
+	if !ok || numeratorFormat == nil {
+		return nil
+	}
+	denominatorFormat, ok := sharedValueFormat(measureReferences(&models.LookMLMeasure{SQL: &denominator}, byName), byName)
+	switch {
+	case !ok:
+		return nil
+	case denominatorFormat == nil:
+		return numeratorFormat
+	case *denominatorFormat == *numeratorFormat:
+		percent := enums.FormatPercent2
+		return &percent
+	}
+	return nil
+}
+
+// sharedValueFormat returns the value format all the named measures share, or nil when
+// none of them has a format. It reports false when their formats differ.
+func sharedValueFormat(names []string, byName map[string]*models.LookMLMeasure) (*enums.LookerValueFormatName, bool) {
+	var format *enums.LookerValueFormatName
+	for i, name := range names {
+		referenced := byName[name]
+		referencedFormat := referenced.ValueFormatName
+		if referencedFormat == nil && referenced.Type == enums.MeasureNumber {
+			referencedFormat = inheritedValueFormat(referenced, byName)
+		}
+		if i > 0 && (format == nil) != (referencedFormat == nil) {
+			return nil, false
+		}
+		if format != nil && *format != *referencedFormat {
+			return nil, false
+		}
+		format = referencedFormat
+	}
+	return format, true
+}
@@ -0,0 +1,182 @@
+package generators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createDerivedMeasures returns formatted and unformatted measures for derived measures to reference
+func createDerivedMeasures() []models.LookMLMeasure {
+	return []models.LookMLMeasure{
+		{Name: "revenue", Type: enums.MeasureSum, SQL: utils.StringPtr("${amount}"), ValueFormatName: valueFormatPtr(enums.FormatUSD)},
+		{Name: "cost", Type: enums.MeasureSum, SQL: utils.StringPtr("${cost_amount}"), ValueFormatName: valueFormatPtr(enums.FormatUSD)},
+		{Name: "orders", Type: enums.MeasureCountDistinct, SQL: utils.StringPtr("${order_id}")},
+		{Name: "count", Type: enums.MeasureCount},
+	}
+}
+
+func TestResolveDerivedMeasures(t *testing.T) {
+	tests := []struct {
+		name           string
+		expression     string
+		expectedSQL    string
+		expectedFormat *enums.LookerValueFormatName
+		expectError    bool
+		errorMsg       string
+	}{
+		{
+			name:           "arithmetic over measures",
+			expression:     "revenue - cost",
+			expectedSQL:    "${revenue} - ${cost}",
+			expectedFormat: valueFormatPtr(enums.FormatUSD),
+		},
+		{
+			name:           "division is made safe",
+			expression:     "(revenue - cost) / revenue",
+			expectedSQL:    "(${revenue} - ${cost}) / NULLIF(${revenue}, 0)",
+			expectedFormat: valueFormatPtr(enums.FormatPercent2),
+		},
+		{
+			name:           "ratio over an unformatted measure keeps the numerator format",
+			expression:     "revenue / orders",
+			expectedSQL:    "${revenue} / NULLIF(${orders}, 0)",
+			expectedFormat: valueFormatPtr(enums.FormatUSD),
+		},
+		{
+			name:        "parenthesized and function divisors",
+			expression:  "revenue / (orders + count) + cost / COALESCE(orders, 1)",
+			expectedSQL: "${revenue} / NULLIF((${orders} + ${count}), 0) + ${cost} / NULLIF(COALESCE(${orders}, 1), 0)",
+		},
+		{
+			name:        "literal and guarded divisors are kept",
+			expression:  "revenue / 100 + cost / NULLIF(orders, 0)",
+			expectedSQL: "${revenue} / 100 + ${cost} / NULLIF(${orders}, 0)",
+		},
+		{
+			name:        "references and keywords",
+			expression:  "CASE WHEN ${orders} IS NULL THEN 0 ELSE revenue END",
+			expectedSQL: "CASE WHEN ${orders} IS NULL THEN 0 ELSE ${revenue} END",
+		},
+		{
+			name:        "IN, LIKE, BETWEEN and CAST keywords",
+			expression:  "CASE WHEN orders IN (1, 2) OR orders BETWEEN 5 AND 10 OR 'x' LIKE 'y' THEN CAST(revenue AS FLOAT64) END",
+			expectedSQL: "CASE WHEN ${orders} IN (1, 2) OR ${orders} BETWEEN 5 AND 10 OR 'x' LIKE 'y' THEN CAST(${revenue} AS FLOAT64) END",
+		},
+		{
+			name:           "mixed formats are not inherited",
+			expression:     "revenue + orders",
+			expectedSQL:    "${revenue} + ${orders}",
+			expectedFormat: nil,
+		},
+		{
+			name:        "unknown measure",
+			expression:  "revenue / visits",
+			expectError: true,
+			errorMsg:    "measure margin: expression references unknown measure visits",
+		},
+		{
+			name:        "unterminated reference",
+			expression:  "${revenue / cost",
+			expectError: true,
+			errorMsg:    "unterminated reference",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			measures := append(createDerivedMeasures(), models.LookMLMeasure{Name: "margin", Type: enums.MeasureNumber})
+
+			err := resolveDerivedMeasures(measures, map[string]string{"margin": tt.expression})
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			margin := measures[len(measures)-1]
+			require.NotNil(t, margin.SQL)
+			assert.Equal(t, tt.expectedSQL, *margin.SQL)
+			assert.Equal(t, tt.expectedFormat, margin.ValueFormatName)
+		})
+	}
+}
+
+func TestResolveDerivedMeasures_Chained(t *testing.T) {
+	measures := append(createDerivedMeasures(),
+		models.LookMLMeasure{Name: "profit_per_order", Type: enums.MeasureNumber},
+		models.LookMLMeasure{Name: "profit", Type: enums.MeasureNumber},
+	)
+
+	err := resolveDerivedMeasures(measures, map[string]string{
+		"profit_per_order": "profit / orders",
+		"profit":           "revenue - cost",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "${profit} / NULLIF(${orders}, 0)", *measures[4].SQL)
+	assert.Equal(t, valueFormatPtr(enums.FormatUSD), measures[4].ValueFormatName)
+	assert.Equal(t, "${revenue} - ${cost}", *measures[5].SQL)
+	assert.Equal(t, valueFormatPtr(enums.FormatUSD), measures[5].ValueFormatName)
+}
+
+func TestResolveDerivedMeasures_Circular(t *testing.T) {
+	measures := append(createDerivedMeasures(),
+		models.LookMLMeasure{Name: "a", Type: enums.MeasureNumber},
+		models.LookMLMeasure{Name: "b", Type: enums.MeasureNumber},
+	)
+
+	err := resolveDerivedMeasures(measures, map[string]string{
+		"a": "b + revenue",
+		"b": "a * 2",
+	})
+	require.Error(t, err)
+	assert.Equal(t, "circular measure reference: a -> b -> a", err.Error())
+}
+
+func TestDerivedMeasures_Rendered(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir}
+
+	model := createMeasureSQLModel()
+	model.Path = "marts/orders.sql"
+	model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{Measures: []models.DbtMetaLookerMeasure{
+		{
+			Name:            utils.StringPtr("average_order_value"),
+			Type:            enums.MeasureNumber,
+			Expression:      utils.StringPtr("revenue / count"),
+			ValueFormatName: valueFormatPtr(enums.FormatUSD),
+		},
+		{Name: utils.StringPtr("revenue"), Type: enums.MeasureSum, Column: utils.StringPtr("amount")},
+	}}}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.Contains(t, content, "  measure: average_order_value {\n"+
+		"    type: number\n"+
+		"    sql: ${revenue} / NULLIF(${count}, 0) ;;\n"+
+		"    label: \"Average Order Value\"\n"+
+		"    value_format_name: usd\n"+
+		"    drill_fields: [detail*]\n"+
+		"  }\n")
+}
+
+func TestDerivedMeasures_MissingReference(t *testing.T) {
+	model := createMeasureSQLModel()
+	model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{Measures: []models.DbtMetaLookerMeasure{
+		{Name: utils.StringPtr("margin"), Type: enums.MeasureNumber, Expression: utils.StringPtr("gross_profit / revenue")},
+	}}}
+
+	_, err := NewViewGenerator(&config.Config{}).GenerateView(model)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "measure margin: expression references unknown measure gross_profit")
+}
@@ -0,0 +1,122 @@
+package generators
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// Warning categories reported in the generation result and run report
+const (
+	// WarningStaleRefinement marks refinement code that references fields the
+	// regenerated base view no longer provides.
+	WarningStaleRefinement = "stale_refinement"
+
+	// WarningMergeConflict marks fields that merge mode kept unchanged because
+	// they were edited by hand or not generated by dbt2lookml.
+	WarningMergeConflict = "merge_conflict"
+
+	// WarningMergeAdopted marks existing fields that merge mode adopted by name and
+	// regenerated because no merge state recorded who wrote them.
+	WarningMergeAdopted = "merge_adopted"
+
+	// WarningModelFile marks problems assembling generated model files, such as
+	// explore roots that match no model.
+	WarningModelFile = "model_file"
+
+	// WarningJoin marks explore joins that were skipped or reference fields
+	// their views do not provide.
+	WarningJoin = "join"
+
+	// WarningNestedArray marks columns of arrays nested beyond the depth limit,
+	// which were dropped or flattened to a JSON string.
+	WarningNestedArray = "nested_array"
+)
+
+// ModelWarning is a non-fatal finding reported while generating a specific model.
+type ModelWarning struct {
+	ModelName string `json:"model" yaml:"model"`
+	Category  string `json:"category" yaml:"category"`
+	Message   string `json:"message" yaml:"message"`
+}
+
+// String returns a formatted warning message.
+func (w ModelWarning) String() string {
+	return fmt.Sprintf("model %s [%s]: %s", w.ModelName, w.Category, w.Message)
+}
+
+// Diagnostics collects warnings from all generators during a run.
+// A nil *Diagnostics is valid and discards warnings, so generators
+// created outside a LookMLGenerator keep working unchanged.
+type Diagnostics struct {
+	mu       sync.Mutex
+	logger   *zerolog.Logger
+	warnings []ModelWarning
+	governed []GovernedField
+}
+
+// NewDiagnostics creates a collector that also logs each warning.
+func NewDiagnostics(logger *zerolog.Logger) *Diagnostics {
+	return &Diagnostics{logger: logger}
+}
+
+// Warn records a warning for a model.
+func (d *Diagnostics) Warn(modelName, category, message string) {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.warnings = append(d.warnings, ModelWarning{
+		ModelName: modelName,
+		Category:  category,
+		Message:   message,
+	})
+
+	if d.logger != nil {
+		d.logger.Warn().Str("model", modelName).Str("category", category).Msg(message)
+	}
+}
+
+// Warnings returns a copy of all recorded warnings.
+func (d *Diagnostics) Warnings() []ModelWarning {
+	if d == nil {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	warnings := make([]ModelWarning, len(d.warnings))
+	copy(warnings, d.warnings)
+	return warnings
+}
+
+// RecordGovernedField records a field governed as personal data.
+func (d *Diagnostics) RecordGovernedField(field GovernedField) {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.governed = append(d.governed, field)
+}
+
+// GovernedFields returns a copy of all recorded governed fields.
+func (d *Diagnostics) GovernedFields() []GovernedField {
+	if d == nil {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	governed := make([]GovernedField, len(d.governed))
+	copy(governed, d.governed)
+	return governed
+}
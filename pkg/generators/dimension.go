@@ -24,15 +24,24 @@ var _ DimensionGeneratorInterface = (*DimensionGenerator)(nil)
 // DimensionGenerator handles generation of LookML dimensions and dimension groups
 type DimensionGenerator struct {
 	config *config.Config
+	naming NamingStrategy
+	labels *LabelGenerator
 }
 
 // NewDimensionGenerator creates a new DimensionGenerator instance
 func NewDimensionGenerator(cfg *config.Config) *DimensionGenerator {
 	return &DimensionGenerator{
 		config: cfg,
+		naming: NewNamingStrategy(cfg),
+		labels: NewLabelGenerator(cfg),
 	}
 }
 
+// SetNamingStrategy replaces the naming strategy of the generator
+func (g *DimensionGenerator) SetNamingStrategy(naming NamingStrategy) {
+	g.naming = naming
+}
+
 // GenerateDimension generates a LookML dimension from a model column
 func (g *DimensionGenerator) GenerateDimension(model *models.DbtModel, column *models.DbtModelColumn) (*models.LookMLDimension, error) {
 	// Skip date/time columns - they will be dimension_groups
@@ -61,12 +70,20 @@ func (g *DimensionGenerator) GenerateDimension(model *models.DbtModel, column *m
 		GroupItemLabel: g.getDimensionGroupItemLabel(column),
 	}
 
+	if column.IsPrimaryKey {
+		primaryKey := true
+		dimension.PrimaryKey = &primaryKey
+	}
+
 	// Override hidden property for ARRAY columns in main view
 	if isArrayColumn {
 		hidden := true
 		dimension.Hidden = &hidden
 	}
 
+	g.applyDataTypeSettings(dimension, column)
+	g.applyDimensionParameters(dimension, column)
+
 	// Set additional properties based on type
 	switch dimension.Type {
 	case "yesno":
@@ -87,16 +104,19 @@ func (g *DimensionGenerator) GenerateDimensionGroup(model *models.DbtModel, colu
 
 	dimensionGroup := &models.LookMLDimensionGroup{
 		Name:        g.getDimensionGroupName(column),
-		Type:        g.getDimensionGroupType(column),
+		Type:        dimGroupTypeTime,
 		SQL:         g.getDimensionSQL(model, column),
-		Label:       g.getDimensionLabel(column),
+		Label:       g.getDimensionGroupFieldLabel(column),
 		Description: g.getDimensionDescription(column),
 		Hidden:      g.getDimensionHidden(column),
 		GroupLabel:  g.GetDimensionGroupLabel(column),
 		Timeframes:  g.getDimensionGroupTimeframes(column),
 		ConvertTZ:   g.getDimensionGroupConvertTZ(column),
+		Datatype:    g.getDimensionGroupDatatype(column),
 	}
 
+	dimensionGroup.LookMLFieldParameters = g.getFieldParameters(column)
+
 	return dimensionGroup, nil
 }
 
@@ -112,8 +132,7 @@ func (g *DimensionGenerator) GetDimensionName(column *models.DbtModelColumn) str
 		}
 
 		// Generate hierarchical name like "classification__itemsubgroup__code"
-		lookmlName := utils.ToLookMLName(nameToConvert)
-		return lookmlName
+		return g.naming.FieldName(nameToConvert)
 	}
 
 	// For non-hierarchical columns, use existing LookMLName if available
@@ -129,26 +148,23 @@ func (g *DimensionGenerator) GetDimensionName(column *models.DbtModelColumn) str
 	}
 
 	// This converts "SupplierInformation" -> "supplier_information"
-	lookmlName := utils.ToLookMLName(nameToConvert)
-
-	return lookmlName
+	return g.naming.FieldName(nameToConvert)
 }
 
 // getDimensionGroupName gets the dimension group name from the column
 func (g *DimensionGenerator) getDimensionGroupName(column *models.DbtModelColumn) string {
-	name := g.GetDimensionName(column)
+	return trimDateSuffix(g.GetDimensionName(column))
+}
 
-	// Remove common date suffixes for dimension groups
-	// Note: _date_time (from PascalCase DateTime) should NOT be stripped
-	// Only strip: _datetime (snake_case), _timestamp, _date
-	suffixes := []string{"_datetime", "_timestamp", "_date"}
-	for _, suffix := range suffixes {
+// trimDateSuffix removes the date suffix of a dimension group name.
+// Note: _date_time (from PascalCase DateTime) should NOT be stripped
+// Only strip: _datetime (snake_case), _timestamp, _date
+func trimDateSuffix(name string) string {
+	for _, suffix := range []string{"_datetime", "_timestamp", "_date"} {
 		if strings.HasSuffix(name, suffix) {
-			name = strings.TrimSuffix(name, suffix)
-			break
+			return strings.TrimSuffix(name, suffix)
 		}
 	}
-
 	return name
 }
 
@@ -163,21 +179,9 @@ func (g *DimensionGenerator) getDimensionNameForMainView(model *models.DbtModel,
 
 	// For ARRAY columns in main view, use the nested view naming pattern
 	if isArrayColumn {
-		// Get the view name (using the same logic as view generation)
-		var viewName string
-		if g.config.UseTableName {
-			// Extract table name from RelationName
-			parts := strings.Split(model.RelationName, ".")
-			tableName := parts[len(parts)-1]
-			tableName = strings.Trim(tableName, "`")
-			viewName = strings.ToLower(tableName)
-		} else {
-			viewName = model.Name
-		}
-
 		// Generate dimension name: {view_name}__{array_name}
 		arrayName := strings.ToLower(column.Name)
-		return fmt.Sprintf("%s__%s", viewName, arrayName)
+		return g.naming.Join(g.naming.ViewName(model), arrayName)
 	}
 
 	// For non-ARRAY columns, use the standard naming
@@ -194,21 +198,24 @@ func (g *DimensionGenerator) getDimensionType(column *models.DbtModelColumn) str
 	return string(lookerType)
 }
 
-// getDimensionGroupType gets the dimension group type based on the column data type
-func (g *DimensionGenerator) getDimensionGroupType(column *models.DbtModelColumn) string {
+// getDimensionGroupDatatype maps the BigQuery type of a column to the datatype of its dimension group
+func (g *DimensionGenerator) getDimensionGroupDatatype(column *models.DbtModelColumn) *enums.LookerTimeDatatype {
 	if column.DataType == nil {
-		return dimGroupTypeTime
+		return nil
 	}
 
-	dataType := strings.ToUpper(*column.DataType)
-	switch dataType {
+	var datatype enums.LookerTimeDatatype
+	switch strings.ToUpper(*column.DataType) {
 	case dataTypeDate:
-		return "date" // DATE fields use type: date
-	case dataTypeDateTime, dataTypeTimestamp:
-		return dimGroupTypeTime // DATETIME and TIMESTAMP use type: time
+		datatype = enums.TimeDatatypeDate
+	case dataTypeDateTime:
+		datatype = enums.TimeDatatypeDatetime
+	case dataTypeTimestamp:
+		datatype = enums.TimeDatatypeTimestamp
 	default:
-		return dimGroupTypeTime
+		return nil
 	}
+	return &datatype
 }
 
 // getDimensionSQL gets the SQL expression for the dimension
@@ -246,8 +253,53 @@ func (g *DimensionGenerator) getDimensionLabel(column *models.DbtModelColumn) *s
 		return column.Meta.Looker.Dimension.Label
 	}
 
-	// Return nil to omit label when not explicitly defined (matches fixture behavior)
-	return nil
+	// Nested columns are labelled by group_item_label
+	if strings.Contains(column.Name, ".") {
+		return nil
+	}
+
+	// Boolean columns matching a yes/no prefix rule are rephrased
+	label, _ := g.labels.FieldLabel(labelPath(column), g.getDimensionType(column) == string(enums.DataTypeYesNo))
+	return &label
+}
+
+// getNestedViewDimensionLabel gets the label of a dimension in a nested view, derived from the
+// last part of the column path as the nested view has no group labels
+func (g *DimensionGenerator) getNestedViewDimensionLabel(column *models.DbtModelColumn) *string {
+	if column.Meta != nil &&
+		column.Meta.Looker != nil &&
+		column.Meta.Looker.Dimension != nil &&
+		column.Meta.Looker.Dimension.Label != nil {
+		return column.Meta.Looker.Dimension.Label
+	}
+
+	parts := strings.Split(labelPath(column), ".")
+	label, _ := g.labels.FieldLabel(parts[len(parts)-1], g.getDimensionType(column) == string(enums.DataTypeYesNo))
+	return &label
+}
+
+// labelPath returns the column path labels are derived from. The original name keeps the case
+// of catalog names, so OrderId is labelled "Order ID" rather than "Orderid".
+func labelPath(column *models.DbtModelColumn) string {
+	if column.OriginalName != nil && *column.OriginalName != "" {
+		return *column.OriginalName
+	}
+	return column.Name
+}
+
+// getDimensionGroupFieldLabel gets the label of a dimension group, derived from the last part of
+// the column path without its date suffix. Looker adds the timeframe to it.
+func (g *DimensionGenerator) getDimensionGroupFieldLabel(column *models.DbtModelColumn) *string {
+	if column.Meta != nil &&
+		column.Meta.Looker != nil &&
+		column.Meta.Looker.Dimension != nil &&
+		column.Meta.Looker.Dimension.Label != nil {
+		return column.Meta.Looker.Dimension.Label
+	}
+
+	parts := strings.Split(labelPath(column), ".")
+	label := g.labels.Label(trimDateSuffix(strings.ToLower(utils.CamelToSnake(parts[len(parts)-1]))))
+	return &label
 }
 
 // getDimensionDescription gets the dimension description
@@ -292,22 +344,7 @@ func (g *DimensionGenerator) GetDimensionGroupLabel(column *models.DbtModelColum
 
 	// For nested columns like "classification.assortment.code",
 	// create group label from parent path: "Classification Assortment"
-	if strings.Contains(column.Name, ".") {
-		parts := strings.Split(column.Name, ".")
-		if len(parts) > 1 {
-			// Take all parts except the last one
-			parentParts := parts[:len(parts)-1]
-			// Convert each part to title case and join with space
-			var titleParts []string
-			for _, part := range parentParts {
-				titleParts = append(titleParts, utils.ToTitleCase(part))
-			}
-			label := strings.Join(titleParts, " ")
-			return &label
-		}
-	}
-
-	return nil
+	return g.labels.GroupLabel(labelPath(column))
 }
 
 // getDimensionValueFormat gets the value format for number dimensions
@@ -331,45 +368,66 @@ func (g *DimensionGenerator) getDimensionGroupTimeframes(column *models.DbtModel
 		return column.Meta.Looker.Dimension.Timeframes
 	}
 
-	// Use config timeframes if specified
-	if len(g.config.Timeframes) > 0 {
+	dataType := ""
+	if column.DataType != nil {
+		dataType = strings.ToUpper(*column.DataType)
+	}
+
+	// Use the timeframes configured for the type, then the global timeframes
+	var configured []string
+	switch dataType {
+	case dataTypeDate:
+		configured = g.config.DimensionGroups.DateTimeframes
+	case dataTypeDateTime:
+		configured = g.config.DimensionGroups.DatetimeTimeframes
+	case dataTypeTimestamp:
+		configured = g.config.DimensionGroups.TimestampTimeframes
+	}
+	if len(configured) == 0 {
+		configured = g.config.Timeframes
+	}
+	if len(configured) > 0 {
 		var timeframes []enums.LookerTimeFrame
-		for _, tf := range g.config.Timeframes {
-			timeframes = append(timeframes, enums.LookerTimeFrame(tf))
+		for _, tf := range configured {
+			timeframe := enums.LookerTimeFrame(strings.ToLower(tf))
+			// DATE columns have no time of day, so the global list is narrowed for them
+			if dataType == dataTypeDate && timeframe.IsTimeOfDay() {
+				continue
+			}
+			timeframes = append(timeframes, timeframe)
 		}
 		return timeframes
 	}
 
 	// Default timeframes based on data type
-	if column.DataType != nil {
-		dataType := strings.ToUpper(*column.DataType)
-		switch dataType {
-		case dataTypeDate:
-			return []enums.LookerTimeFrame{
-				enums.TimeFrameRaw,
-				enums.TimeFrameDate,
-				enums.TimeFrameWeek,
-				enums.TimeFrameMonth,
-				enums.TimeFrameQuarter,
-				enums.TimeFrameYear,
-			}
-		case dataTypeDateTime, dataTypeTimestamp:
-			return []enums.LookerTimeFrame{
-				enums.TimeFrameRaw,
-				enums.TimeFrameTime,
-				enums.TimeFrameDate,
-				enums.TimeFrameWeek,
-				enums.TimeFrameMonth,
-				enums.TimeFrameQuarter,
-				enums.TimeFrameYear,
-			}
+	switch dataType {
+	case dataTypeDate:
+		return []enums.LookerTimeFrame{
+			enums.TimeFrameRaw,
+			enums.TimeFrameDate,
+			enums.TimeFrameWeek,
+			enums.TimeFrameMonth,
+			enums.TimeFrameQuarter,
+			enums.TimeFrameYear,
+		}
+	case dataTypeDateTime, dataTypeTimestamp:
+		return []enums.LookerTimeFrame{
+			enums.TimeFrameRaw,
+			enums.TimeFrameTime,
+			enums.TimeFrameDate,
+			enums.TimeFrameWeek,
+			enums.TimeFrameMonth,
+			enums.TimeFrameQuarter,
+			enums.TimeFrameYear,
 		}
 	}
 
 	return nil
 }
 
-// getDimensionGroupConvertTZ gets the convert_tz setting for dimension groups
+// getDimensionGroupConvertTZ gets the convert_tz setting for dimension groups. DATE and
+// DATETIME values have no time zone, so they are never converted unless meta says otherwise;
+// TIMESTAMP columns follow dimension_groups.timestamp_convert_tz.
 func (g *DimensionGenerator) getDimensionGroupConvertTZ(column *models.DbtModelColumn) *bool {
 	if column.Meta != nil &&
 		column.Meta.Looker != nil &&
@@ -377,20 +435,23 @@ func (g *DimensionGenerator) getDimensionGroupConvertTZ(column *models.DbtModelC
 		column.Meta.Looker.Dimension.ConvertTZ != nil {
 		return column.Meta.Looker.Dimension.ConvertTZ
 	}
+
+	if column.DataType == nil {
+		return nil
+	}
+	switch strings.ToUpper(*column.DataType) {
+	case dataTypeDate, dataTypeDateTime:
+		return utils.BoolPtr(false)
+	case dataTypeTimestamp:
+		return g.config.DimensionGroups.TimestampConvertTZ
+	}
 	return nil
 }
 
 // getDimensionGroupItemLabel gets the group item label for nested columns
 func (g *DimensionGenerator) getDimensionGroupItemLabel(column *models.DbtModelColumn) *string {
-	// For nested columns like "classification.assortment.code", extract the last part
-	if strings.Contains(column.Name, ".") {
-		parts := strings.Split(column.Name, ".")
-		lastPart := parts[len(parts)-1]
-		// Convert to title case
-		label := utils.ToTitleCase(lastPart)
-		return &label
-	}
-	return nil
+	// For nested columns like "classification.assortment.code", label the last part
+	return g.labels.GroupItemLabel(labelPath(column), g.getDimensionType(column) == string(enums.DataTypeYesNo))
 }
 
 // shouldBeDimensionGroup determines if a column should be a dimension group
@@ -1,10 +1,12 @@
 package generators
 
 import (
+	"context"
 	"strings"
 	"testing"
 
 	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
 	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -539,7 +541,7 @@ func TestDimensionGenerator_DimensionGroups(t *testing.T) {
 				DataType: stringPtr("DATE"),
 			},
 			expectedGroupName: "order", // _date suffix removed
-			expectedType:      "date",  // DATE uses type: date
+			expectedType:      "time",  // DATE uses type: time with datatype: date
 			hasTimeframes:     true,
 		},
 		{
@@ -593,6 +595,188 @@ func TestDimensionGenerator_DimensionGroups(t *testing.T) {
 	}
 }
 
+// TestDimensionGenerator_DimensionGroupTypePolicy tests datatype, convert_tz and timeframes per BigQuery type
+func TestDimensionGenerator_DimensionGroupTypePolicy(t *testing.T) {
+	tests := []struct {
+		name               string
+		cfg                *config.Config
+		column             *models.DbtModelColumn
+		expectedDatatype   enums.LookerTimeDatatype
+		expectedConvertTZ  *bool
+		expectedTimeframes []enums.LookerTimeFrame
+	}{
+		{
+			name:              "DATE is never converted and drops time from global timeframes",
+			cfg:               &config.Config{Timeframes: []string{"raw", "time", "date"}},
+			column:            &models.DbtModelColumn{Name: "order_date", DataType: stringPtr("DATE")},
+			expectedDatatype:  enums.TimeDatatypeDate,
+			expectedConvertTZ: boolPtr(false),
+			expectedTimeframes: []enums.LookerTimeFrame{
+				enums.TimeFrameRaw, enums.TimeFrameDate,
+			},
+		},
+		{
+			name:              "DATETIME is never converted",
+			cfg:               &config.Config{Timeframes: []string{"raw", "time", "date"}},
+			column:            &models.DbtModelColumn{Name: "updated_datetime", DataType: stringPtr("DATETIME")},
+			expectedDatatype:  enums.TimeDatatypeDatetime,
+			expectedConvertTZ: boolPtr(false),
+			expectedTimeframes: []enums.LookerTimeFrame{
+				enums.TimeFrameRaw, enums.TimeFrameTime, enums.TimeFrameDate,
+			},
+		},
+		{
+			name:             "TIMESTAMP keeps the Looker default",
+			cfg:              &config.Config{},
+			column:           &models.DbtModelColumn{Name: "created_at", DataType: stringPtr("TIMESTAMP")},
+			expectedDatatype: enums.TimeDatatypeTimestamp,
+			expectedTimeframes: []enums.LookerTimeFrame{
+				enums.TimeFrameRaw, enums.TimeFrameTime, enums.TimeFrameDate, enums.TimeFrameWeek,
+				enums.TimeFrameMonth, enums.TimeFrameQuarter, enums.TimeFrameYear,
+			},
+		},
+		{
+			name: "TIMESTAMP follows the configured policy and timeframes",
+			cfg: &config.Config{
+				Timeframes: []string{"raw", "date"},
+				DimensionGroups: config.DimensionGroupConfig{
+					TimestampTimeframes: []string{"time", "date"},
+					TimestampConvertTZ:  boolPtr(true),
+				},
+			},
+			column:             &models.DbtModelColumn{Name: "created_at", DataType: stringPtr("TIMESTAMP")},
+			expectedDatatype:   enums.TimeDatatypeTimestamp,
+			expectedConvertTZ:  boolPtr(true),
+			expectedTimeframes: []enums.LookerTimeFrame{enums.TimeFrameTime, enums.TimeFrameDate},
+		},
+		{
+			name: "column meta wins",
+			cfg:  &config.Config{DimensionGroups: config.DimensionGroupConfig{DateTimeframes: []string{"date"}}},
+			column: &models.DbtModelColumn{
+				Name:     "order_date",
+				DataType: stringPtr("DATE"),
+				Meta: &models.DbtModelColumnMeta{Looker: &models.DbtMetaLooker{Dimension: &models.DbtMetaLookerDimension{
+					ConvertTZ:  boolPtr(true),
+					Timeframes: []enums.LookerTimeFrame{enums.TimeFrameMonth},
+				}}},
+			},
+			expectedDatatype:   enums.TimeDatatypeDate,
+			expectedConvertTZ:  boolPtr(true),
+			expectedTimeframes: []enums.LookerTimeFrame{enums.TimeFrameMonth},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := &models.DbtModel{DbtNode: models.DbtNode{Name: "test_model"}}
+
+			dimensionGroup, err := NewDimensionGenerator(tt.cfg).GenerateDimensionGroup(model, tt.column)
+			require.NoError(t, err)
+			require.NotNil(t, dimensionGroup)
+
+			assert.Equal(t, "time", dimensionGroup.Type)
+			require.NotNil(t, dimensionGroup.Datatype)
+			assert.Equal(t, tt.expectedDatatype, *dimensionGroup.Datatype)
+			assert.Equal(t, tt.expectedConvertTZ, dimensionGroup.ConvertTZ)
+			assert.Equal(t, tt.expectedTimeframes, dimensionGroup.Timeframes)
+		})
+	}
+}
+
+// TestDimensionGroup_Rendered tests that dimension groups render their labels and type policy
+func TestDimensionGroup_Rendered(t *testing.T) {
+	outputDir := t.TempDir()
+	model := &models.DbtModel{
+		DbtNode:      models.DbtNode{Name: "orders"},
+		RelationName: "`project.dataset.orders`",
+		Path:         "orders.sql",
+		Columns: map[string]models.DbtModelColumn{
+			"order_date": {
+				Name:        "order_date",
+				DataType:    stringPtr("DATE"),
+				Description: stringPtr("Date of the order"),
+				Meta: &models.DbtModelColumnMeta{Looker: &models.DbtMetaLooker{Dimension: &models.DbtMetaLookerDimension{
+					DbtMetaLookerBase: models.DbtMetaLookerBase{Label: stringPtr("Ordered"), Hidden: boolPtr(true)},
+				}}},
+			},
+		},
+	}
+
+	_, err := NewLookMLGenerator(&config.Config{OutputDir: outputDir}).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+
+	assert.Contains(t, readOutput(t, outputDir, "orders.view.lkml"), "  dimension_group: order {\n"+
+		"    type: time\n"+
+		"    sql: ${TABLE}.order_date ;;\n"+
+		"    timeframes: [raw, date, week, month, quarter, year]\n"+
+		"    convert_tz: no\n"+
+		"    datatype: date\n"+
+		"    label: \"Ordered\"\n"+
+		"    description: \"Date of the order\"\n"+
+		"    hidden: yes\n"+
+		"  }\n")
+}
+
+// TestViewGenerator_TimeframeOverrides tests that column meta timeframes are checked against the column type
+func TestViewGenerator_TimeframeOverrides(t *testing.T) {
+	tests := []struct {
+		name        string
+		timeframes  []enums.LookerTimeFrame
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name: "date parts and fiscal timeframes",
+			timeframes: []enums.LookerTimeFrame{
+				enums.TimeFrameDate, enums.TimeFrameDayOfWeek, enums.TimeFrameWeekOfYear,
+				enums.TimeFrameMonthName, enums.TimeFrameFiscalQuarter, enums.TimeFrameFiscalYear,
+			},
+			expectError: false,
+		},
+		{
+			name:        "hour on a DATE column",
+			timeframes:  []enums.LookerTimeFrame{enums.TimeFrameDate, enums.TimeFrameHour},
+			expectError: true,
+			errorMsg:    "timeframe hour needs a time of day, which DATE columns lack, in dimension group: order",
+		},
+		{
+			name:        "unknown timeframe",
+			timeframes:  []enums.LookerTimeFrame{"fortnight"},
+			expectError: true,
+			errorMsg:    "invalid timeframe 'fortnight' for dimension group: order",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := &models.DbtModel{
+				DbtNode:      models.DbtNode{Name: "orders"},
+				RelationName: "`project.dataset.orders`",
+				Columns: map[string]models.DbtModelColumn{
+					"order_date": {
+						Name:     "order_date",
+						DataType: stringPtr("DATE"),
+						Meta: &models.DbtModelColumnMeta{Looker: &models.DbtMetaLooker{Dimension: &models.DbtMetaLookerDimension{
+							Timeframes: tt.timeframes,
+						}}},
+					},
+				},
+			}
+
+			view, err := NewViewGenerator(&config.Config{}).GenerateView(model)
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			dimensionGroup := findDimensionGroup(view, "order")
+			require.NotNil(t, dimensionGroup)
+			assert.Equal(t, tt.timeframes, dimensionGroup.Timeframes)
+		})
+	}
+}
+
 // TestDimensionGenerator_GroupLabels tests group label generation for nested columns
 func TestDimensionGenerator_GroupLabels(t *testing.T) {
 	cfg := &config.Config{}
@@ -0,0 +1,158 @@
+package generators
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+)
+
+// detailSetName is the name of the set generated views drill into
+const detailSetName = "detail"
+
+// defaultDetailSetFields is the number of dimensions a detail set falls back to when no
+// dimension besides the primary keys was selected
+const defaultDetailSetFields = 5
+
+// buildDetailSet selects the dimensions of a view's detail set: primary keys,
+// dimensions labeled in column meta and dimensions matching detail_set.columns, unless hidden. Column meta
+// dimension.drill includes or leaves out a dimension explicitly. When nothing but primary
+// keys is selected, the first visible dimensions of the view are used instead. columns maps
+// dimension names to the columns they were generated from. Returns nil for an empty set.
+func buildDetailSet(cfg *config.Config, dimensions []models.LookMLDimension, columns map[string]*models.DbtModelColumn) *models.LookMLSet {
+	if cfg.DetailSet.Disabled {
+		return nil
+	}
+
+	var keys, fields []string
+	for _, dimension := range dimensions {
+		column := columns[dimension.Name]
+		if column == nil || !inDetailSet(cfg, &dimension, column) {
+			continue
+		}
+		if column.IsPrimaryKey {
+			keys = append(keys, dimension.Name)
+		} else {
+			fields = append(fields, dimension.Name)
+		}
+	}
+	if len(fields) == 0 {
+		fields = defaultDetailFields(dimensions, columns)
+	}
+
+	// Primary keys come first so they survive max_fields
+	sort.Strings(keys)
+	sort.Strings(fields)
+	fields = append(keys, fields...)
+	if cfg.DetailSet.MaxFields > 0 && len(fields) > cfg.DetailSet.MaxFields {
+		fields = fields[:cfg.DetailSet.MaxFields]
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+	return &models.LookMLSet{Name: detailSetName, Fields: fields}
+}
+
+// inDetailSet decides whether a dimension belongs to the detail set
+func inDetailSet(cfg *config.Config, dimension *models.LookMLDimension, column *models.DbtModelColumn) bool {
+	var meta *models.DbtMetaLookerDimension
+	if column.Meta != nil && column.Meta.Looker != nil {
+		meta = column.Meta.Looker.Dimension
+	}
+	if meta != nil && meta.Drill != nil {
+		return *meta.Drill
+	}
+
+	if dimension.Hidden != nil && *dimension.Hidden {
+		return false
+	}
+	// Generated labels do not count, only labels set in column meta
+	if column.IsPrimaryKey || (meta != nil && meta.Label != nil) {
+		return true
+	}
+
+	name := strings.ToLower(column.Name)
+	for _, pattern := range cfg.DetailSet.Columns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, dimension.Name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultDetailFields returns the first visible dimensions of a view that are not primary
+// keys or left out by column meta dimension.drill
+func defaultDetailFields(dimensions []models.LookMLDimension, columns map[string]*models.DbtModelColumn) []string {
+	var fields []string
+	for _, dimension := range dimensions {
+		column := columns[dimension.Name]
+		if column == nil || column.IsPrimaryKey || (dimension.Hidden != nil && *dimension.Hidden) {
+			continue
+		}
+		if column.Meta != nil && column.Meta.Looker != nil && column.Meta.Looker.Dimension != nil &&
+			column.Meta.Looker.Dimension.Drill != nil && !*column.Meta.Looker.Dimension.Drill {
+			continue
+		}
+		fields = append(fields, dimension.Name)
+		if len(fields) == defaultDetailSetFields {
+			break
+		}
+	}
+	return fields
+}
+
+// applyDetailSet adds a detail set to a view and points the measures that do not declare
+// their own drill_fields at it. Period-over-period measures do not drill.
+func applyDetailSet(view *models.LookMLView, set *models.LookMLSet) {
+	if set == nil {
+		return
+	}
+	view.Sets = append(view.Sets, *set)
+
+	for i := range view.Measures {
+		measure := &view.Measures[i]
+		if measure.DrillFields == nil && measure.Type != enums.MeasurePeriodOverPeriod {
+			measure.DrillFields = []string{set.Name + "*"}
+		}
+	}
+}
+
+// generateDetailSet returns the detail set of a model's main view. A model's
+// meta.looker.drill_fields replaces the generated set; an empty list leaves it out.
+func (g *ViewGenerator) generateDetailSet(model *models.DbtModel, view *models.LookMLView, columnCollections *models.ColumnCollections) (*models.LookMLSet, error) {
+	if model.Meta != nil && model.Meta.Looker != nil && model.Meta.Looker.DrillFields != nil {
+		return detailSetOverride(model, view)
+	}
+
+	columns := make(map[string]*models.DbtModelColumn, len(columnCollections.MainViewColumns))
+	for columnName, column := range columnCollections.MainViewColumns {
+		column.Name = columnName
+		columns[g.dimensionGenerator.getDimensionNameForMainView(model, &column)] = &column
+	}
+
+	return buildDetailSet(g.config, view.Dimensions, columns), nil
+}
+
+// detailSetOverride returns the detail set declared in meta.looker.drill_fields,
+// after checking that its fields exist in the view
+func detailSetOverride(model *models.DbtModel, view *models.LookMLView) (*models.LookMLSet, error) {
+	available := view.FieldNames()
+	for _, field := range model.Meta.Looker.DrillFields {
+		if !fieldAvailable(available, strings.TrimPrefix(field, view.Name+".")) {
+			return nil, fmt.Errorf("drill_fields references %s which does not exist in view %s", field, view.Name)
+		}
+	}
+
+	if len(model.Meta.Looker.DrillFields) == 0 {
+		return nil, nil
+	}
+	return &models.LookMLSet{Name: detailSetName, Fields: model.Meta.Looker.DrillFields}, nil
+}
@@ -0,0 +1,225 @@
+package generators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// drillDimensionMeta returns column meta holding a looker dimension
+func drillDimensionMeta(dimension models.DbtMetaLookerDimension) *models.DbtModelColumnMeta {
+	return &models.DbtModelColumnMeta{Looker: &models.DbtMetaLooker{Dimension: &dimension}}
+}
+
+// createDrillModel returns a customers model with a primary key, a labeled email and
+// unlabeled strings, a date and a number
+func createDrillModel() *models.DbtModel {
+	customerID := testColumn("customer_id", "INT64")
+	customerID.IsPrimaryKey = true
+	email := testColumn("email", "STRING")
+	email.Meta = drillDimensionMeta(models.DbtMetaLookerDimension{DbtMetaLookerBase: models.DbtMetaLookerBase{Label: utils.StringPtr("E-mail")}})
+
+	return createTestModel("customers", "core/customers.sql",
+		customerID,
+		email,
+		testColumn("city_name", "STRING"),
+		testColumn("segment", "STRING"),
+		testColumn("signup_date", "DATE"),
+		testColumn("lifetime_sum", "NUMERIC"),
+	)
+}
+
+func TestViewGenerator_DetailSet(t *testing.T) {
+	tests := []struct {
+		name      string
+		detailSet config.DetailSetConfig
+		modify    func(model *models.DbtModel)
+		expected  []string
+	}{
+		{
+			name:     "primary keys and labeled dimensions",
+			expected: []string{"customer_id", "email"},
+		},
+		{
+			name:      "column patterns",
+			detailSet: config.DetailSetConfig{Columns: []string{"*_name"}},
+			expected:  []string{"customer_id", "city_name", "email"},
+		},
+		{
+			name:      "max fields keeps primary keys",
+			detailSet: config.DetailSetConfig{Columns: []string{"*"}, MaxFields: 2},
+			expected:  []string{"customer_id", "city_name"},
+		},
+		{
+			name: "column meta includes and leaves out dimensions",
+			modify: func(model *models.DbtModel) {
+				segment := model.Columns["segment"]
+				segment.Meta = drillDimensionMeta(models.DbtMetaLookerDimension{Drill: utils.BoolPtr(true)})
+				model.Columns["segment"] = segment
+
+				email := model.Columns["email"]
+				email.Meta.Looker.Dimension.Drill = utils.BoolPtr(false)
+				model.Columns["email"] = email
+			},
+			expected: []string{"customer_id", "segment"},
+		},
+		{
+			name: "hidden dimensions are left out",
+			modify: func(model *models.DbtModel) {
+				email := model.Columns["email"]
+				email.Meta.Looker.Dimension.Hidden = utils.BoolPtr(true)
+				model.Columns["email"] = email
+			},
+			expected: []string{"customer_id", "city_name", "lifetime_sum", "segment"},
+		},
+		{
+			name: "without primary keys or labels the first visible dimensions are used",
+			modify: func(model *models.DbtModel) {
+				customerID := model.Columns["customer_id"]
+				customerID.IsPrimaryKey = false
+				model.Columns["customer_id"] = customerID
+				delete(model.Columns, "email")
+			},
+			expected: []string{"city_name", "customer_id", "lifetime_sum", "segment"},
+		},
+		{
+			name: "model meta replaces the set",
+			modify: func(model *models.DbtModel) {
+				model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{DrillFields: []string{"segment", "signup_date", "customers.city_name"}}}
+			},
+			expected: []string{"segment", "signup_date", "customers.city_name"},
+		},
+		{
+			name:      "disabled",
+			detailSet: config.DetailSetConfig{Disabled: true},
+			expected:  nil,
+		},
+		{
+			name: "empty model meta leaves the set out",
+			modify: func(model *models.DbtModel) {
+				model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{DrillFields: []string{}}}
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := createDrillModel()
+			if tt.modify != nil {
+				tt.modify(model)
+			}
+
+			view, err := NewViewGenerator(&config.Config{DetailSet: tt.detailSet}).GenerateView(model)
+			require.NoError(t, err)
+
+			if tt.expected == nil {
+				assert.Empty(t, view.Sets)
+				for _, measure := range view.Measures {
+					assert.Nil(t, measure.DrillFields)
+				}
+				return
+			}
+
+			require.Len(t, view.Sets, 1)
+			assert.Equal(t, "detail", view.Sets[0].Name)
+			assert.Equal(t, tt.expected, view.Sets[0].Fields)
+		})
+	}
+}
+
+func TestViewGenerator_DetailSetInvalidOverride(t *testing.T) {
+	model := createDrillModel()
+	model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{DrillFields: []string{"customer_id", "phone"}}}
+
+	_, err := NewViewGenerator(&config.Config{}).GenerateView(model)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "drill_fields references phone which does not exist in view customers")
+}
+
+func TestViewGenerator_MeasureDrillFields(t *testing.T) {
+	model := createDrillModel()
+	model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{Measures: []models.DbtMetaLookerMeasure{
+		{Name: utils.StringPtr("total_lifetime"), Type: enums.MeasureSum, Column: utils.StringPtr("lifetime_sum")},
+		{Name: utils.StringPtr("segments"), Type: enums.MeasureCountDistinct, SQL: utils.StringPtr("${segment}"), DrillFields: []string{"segment", "count"}},
+		{Name: utils.StringPtr("customers_total"), Type: enums.MeasureSum, Column: utils.StringPtr("lifetime_sum"), DrillFields: []string{}},
+		{
+			Name: utils.StringPtr("lifetime_last_year"), Type: enums.MeasurePeriodOverPeriod,
+			BasedOn: utils.StringPtr("total_lifetime"), BasedOnTime: utils.StringPtr("signup_year"), Period: periodPtr(enums.PeriodYear),
+		},
+	}}}
+
+	view, err := NewViewGenerator(&config.Config{}).GenerateView(model)
+	require.NoError(t, err)
+
+	drillFields := make(map[string][]string, len(view.Measures))
+	for _, measure := range view.Measures {
+		drillFields[measure.Name] = measure.DrillFields
+	}
+
+	assert.Equal(t, []string{"detail*"}, drillFields["total_lifetime"])
+	assert.Equal(t, []string{"segment", "count"}, drillFields["segments"])
+	assert.Equal(t, []string{}, drillFields["customers_total"])
+	assert.Nil(t, drillFields["lifetime_last_year"])
+	assert.Equal(t, []string{"detail*"}, drillFields["count"])
+}
+
+func TestDetailSet_Rendered(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir, DetailSet: config.DetailSetConfig{Columns: []string{"*_name"}}}
+
+	model := createDrillModel()
+	model.Columns["orders"] = models.DbtModelColumn{Name: "orders", DataType: utils.StringPtr("ARRAY<STRUCT<order_id INT64, store_name STRING>>")}
+	model.Columns["orders.order_id"] = models.DbtModelColumn{Name: "orders.order_id", DataType: utils.StringPtr("INT64"), Nested: true}
+	model.Columns["orders.store_name"] = models.DbtModelColumn{Name: "orders.store_name", DataType: utils.StringPtr("STRING"), Nested: true}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "core/customers.view.lkml")
+	assert.Contains(t, content, "  measure: count {\n"+
+		"    type: count\n"+
+		"    drill_fields: [detail*]\n"+
+		"  }\n")
+	assert.Contains(t, content, "  set: detail {\n"+
+		"    fields: [\n"+
+		"      customer_id,\n"+
+		"      city_name,\n"+
+		"      email\n"+
+		"    ]\n"+
+		"  }\n")
+
+	// The nested view has a detail set of its own
+	assert.Contains(t, content, "  set: detail {\n"+
+		"    fields: [\n"+
+		"      store_name\n"+
+		"    ]\n"+
+		"  }\n")
+}
+
+func TestDetailSet_FromManifest(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir}
+	manifest, catalog := createTestManifest("customers", "core/customers.sql", "CustomerId", map[string]string{
+		"CustomerId":     "INT64",
+		"Email":          "STRING",
+		"Orders":         "ARRAY<STRUCT<OrderId INT64, Total NUMERIC>>",
+		"Orders.OrderId": "INT64",
+		"Orders.Total":   "NUMERIC",
+	})
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), parseTestModels(t, manifest, catalog), GenerationOptions{})
+	require.NoError(t, err)
+
+	// The primary key leads the set, followed by the first visible dimensions
+	content := readOutput(t, outputDir, "core/customers.view.lkml")
+	assert.Contains(t, nestedViewContent(t, content, "customers"), "  set: detail {\n    fields: [\n      customer_id,\n      email\n    ]\n  }\n")
+	assert.Contains(t, nestedViewContent(t, content, "customers__orders"), "  set: detail {\n    fields: [\n      order_id,\n      total\n    ]\n  }\n")
+	assert.Contains(t, nestedViewContent(t, content, "customers__orders"), "  measure: count {\n    type: count\n    drill_fields: [detail*]\n  }\n")
+}
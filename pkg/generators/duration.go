@@ -0,0 +1,180 @@
+package generators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+)
+
+const dimGroupTypeDuration = "duration"
+
+// Default intervals of duration dimension groups by the type of their columns
+var (
+	defaultDateIntervals = []enums.LookerDurationInterval{
+		enums.IntervalDay,
+		enums.IntervalWeek,
+		enums.IntervalMonth,
+		enums.IntervalQuarter,
+		enums.IntervalYear,
+	}
+	defaultTimeIntervals = []enums.LookerDurationInterval{
+		enums.IntervalMinute,
+		enums.IntervalHour,
+		enums.IntervalDay,
+		enums.IntervalWeek,
+		enums.IntervalMonth,
+	}
+)
+
+// Suffixes stripped from column names to name duration dimension groups
+var durationNameSuffixes = []string{"_timestamp", "_datetime", "_date", "_time", "_at", "_ts"}
+
+// GenerateDurationDimensionGroups generates the duration dimension groups of a model: those
+// declared in meta.looker.durations, then those of the configured duration rules. Declared
+// durations must reference DATE, DATETIME or TIMESTAMP columns of the same type; rules
+// only apply to column pairs that do.
+func (g *DimensionGenerator) GenerateDurationDimensionGroups(model *models.DbtModel, columns map[string]models.DbtModelColumn) ([]models.LookMLDimensionGroup, error) {
+	byName := make(map[string]models.DbtModelColumn, len(columns))
+	for columnName, column := range columns {
+		column.Name = columnName
+		byName[strings.ToLower(columnName)] = column
+	}
+
+	var dimensionGroups []models.LookMLDimensionGroup
+	taken := make(map[string]bool)
+
+	if model.Meta != nil && model.Meta.Looker != nil {
+		for _, duration := range model.Meta.Looker.Durations {
+			dimensionGroup, err := g.durationDimensionGroup(model, byName, duration)
+			if err != nil {
+				return nil, fmt.Errorf("duration %s to %s: %w", duration.Start, duration.End, err)
+			}
+			if taken[dimensionGroup.Name] {
+				return nil, fmt.Errorf("duplicate duration name: %s", dimensionGroup.Name)
+			}
+			taken[dimensionGroup.Name] = true
+			dimensionGroups = append(dimensionGroups, *dimensionGroup)
+		}
+	}
+
+	columnNames := make([]string, 0, len(byName))
+	for columnName := range byName {
+		columnNames = append(columnNames, columnName)
+	}
+	sort.Strings(columnNames)
+
+	for _, rule := range g.config.Durations {
+		for _, columnName := range columnNames {
+			duration, ok := matchDurationRule(rule, columnName)
+			if !ok {
+				continue
+			}
+			if _, exists := byName[duration.End]; !exists {
+				continue
+			}
+
+			// Rules skip pairs that do not make a valid duration
+			dimensionGroup, err := g.durationDimensionGroup(model, byName, duration)
+			if err != nil || taken[dimensionGroup.Name] {
+				continue
+			}
+			taken[dimensionGroup.Name] = true
+			dimensionGroups = append(dimensionGroups, *dimensionGroup)
+		}
+	}
+
+	return dimensionGroups, nil
+}
+
+// durationDimensionGroup builds the duration dimension group of a start and end column
+func (g *DimensionGenerator) durationDimensionGroup(model *models.DbtModel, columns map[string]models.DbtModelColumn, duration models.DbtMetaLookerDuration) (*models.LookMLDimensionGroup, error) {
+	start, ok := columns[strings.ToLower(duration.Start)]
+	if !ok {
+		return nil, fmt.Errorf("start references unknown column %s", duration.Start)
+	}
+	end, ok := columns[strings.ToLower(duration.End)]
+	if !ok {
+		return nil, fmt.Errorf("end references unknown column %s", duration.End)
+	}
+
+	startType, endType := columnDataType(&start), columnDataType(&end)
+	for _, column := range []struct{ name, dataType string }{{duration.Start, startType}, {duration.End, endType}} {
+		if column.dataType != dataTypeDate && column.dataType != dataTypeDateTime && column.dataType != dataTypeTimestamp {
+			return nil, fmt.Errorf("column %s is %s, durations need DATE, DATETIME or TIMESTAMP columns", column.name, column.dataType)
+		}
+	}
+	if startType != endType {
+		return nil, fmt.Errorf("start column %s is %s but end column %s is %s", duration.Start, startType, duration.End, endType)
+	}
+
+	intervals := duration.Intervals
+	if len(intervals) == 0 {
+		intervals = defaultTimeIntervals
+		if startType == dataTypeDate {
+			intervals = defaultDateIntervals
+		}
+	}
+	for _, interval := range intervals {
+		if !models.IsValidDurationInterval(interval) {
+			return nil, fmt.Errorf("invalid interval: %s", interval)
+		}
+		if startType == dataTypeDate && isSubDayInterval(interval) {
+			return nil, fmt.Errorf("interval %s needs DATETIME or TIMESTAMP columns", interval)
+		}
+	}
+
+	name := duration.Name
+	if name == "" {
+		name = fmt.Sprintf("%s_to_%s", durationNameStem(start.Name), durationNameStem(end.Name))
+	}
+
+	return &models.LookMLDimensionGroup{
+		Name:      name,
+		Type:      dimGroupTypeDuration,
+		SQLStart:  g.getDimensionSQL(model, &start),
+		SQLEnd:    g.getDimensionSQL(model, &end),
+		Intervals: intervals,
+	}, nil
+}
+
+// matchDurationRule matches a column against the start pattern of a duration rule and
+// returns the duration it declares, with the * in end and name replaced by the matched text
+func matchDurationRule(rule config.DurationRuleConfig, columnName string) (models.DbtMetaLookerDuration, bool) {
+	duration := models.DbtMetaLookerDuration{Start: columnName, End: rule.End, Name: rule.Name}
+	for _, interval := range rule.Intervals {
+		duration.Intervals = append(duration.Intervals, enums.LookerDurationInterval(interval))
+	}
+
+	prefix, suffix, wildcard := strings.Cut(rule.Start, "*")
+	if !wildcard {
+		return duration, columnName == rule.Start
+	}
+	if len(columnName) <= len(prefix)+len(suffix) || !strings.HasPrefix(columnName, prefix) || !strings.HasSuffix(columnName, suffix) {
+		return duration, false
+	}
+
+	matched := columnName[len(prefix) : len(columnName)-len(suffix)]
+	duration.End = strings.Replace(rule.End, "*", matched, 1)
+	duration.Name = strings.Replace(rule.Name, "*", matched, 1)
+	return duration, true
+}
+
+// durationNameStem strips date/time suffixes from a column name, e.g. created_at -> created
+func durationNameStem(columnName string) string {
+	name := strings.ToLower(columnName)
+	for _, suffix := range durationNameSuffixes {
+		if strings.HasSuffix(name, suffix) && len(name) > len(suffix) {
+			return strings.TrimSuffix(name, suffix)
+		}
+	}
+	return name
+}
+
+// isSubDayInterval checks whether an interval is shorter than a day
+func isSubDayInterval(interval enums.LookerDurationInterval) bool {
+	return interval == enums.IntervalSecond || interval == enums.IntervalMinute || interval == enums.IntervalHour
+}
@@ -0,0 +1,172 @@
+package generators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createDurationModel returns a tickets model with timestamp and date pairs to measure the
+// durations between, and the given durations as meta
+func createDurationModel(durations ...models.DbtMetaLookerDuration) *models.DbtModel {
+	ticketID := testColumn("ticket_id", "INT64")
+	ticketID.IsPrimaryKey = true
+	model := createTestModel("tickets", "support/tickets.sql",
+		ticketID,
+		testColumn("created_at", "TIMESTAMP"),
+		testColumn("resolved_at", "TIMESTAMP"),
+		testColumn("call_started_at", "TIMESTAMP"),
+		testColumn("call_ended_at", "TIMESTAMP"),
+		testColumn("chat_started_at", "TIMESTAMP"),
+		testColumn("chat_ended_at", "DATE"),
+		testColumn("opened_date", "DATE"),
+		testColumn("closed_date", "DATE"),
+		testColumn("subject", "STRING"),
+	)
+	if len(durations) > 0 {
+		model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{Durations: durations}}
+	}
+	return model
+}
+
+// findDimensionGroup returns the dimension group of view with the given name, or nil
+func findDimensionGroup(view *models.LookMLView, name string) *models.LookMLDimensionGroup {
+	for i := range view.DimensionGroups {
+		if view.DimensionGroups[i].Name == name {
+			return &view.DimensionGroups[i]
+		}
+	}
+	return nil
+}
+
+func TestDurationDimensionGroups_Meta(t *testing.T) {
+	tests := []struct {
+		name        string
+		duration    models.DbtMetaLookerDuration
+		expected    models.LookMLDimensionGroup
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:     "timestamp columns with intervals",
+			duration: models.DbtMetaLookerDuration{Name: "resolution", Start: "created_at", End: "resolved_at", Intervals: []enums.LookerDurationInterval{enums.IntervalHour, enums.IntervalDay}},
+			expected: models.LookMLDimensionGroup{
+				Name: "resolution", Type: "duration",
+				SQLStart: "${TABLE}.created_at", SQLEnd: "${TABLE}.resolved_at",
+				Intervals: []enums.LookerDurationInterval{enums.IntervalHour, enums.IntervalDay},
+			},
+		},
+		{
+			name:     "date columns get default intervals and a derived name",
+			duration: models.DbtMetaLookerDuration{Start: "opened_date", End: "closed_date"},
+			expected: models.LookMLDimensionGroup{
+				Name: "opened_to_closed", Type: "duration",
+				SQLStart: "${TABLE}.opened_date", SQLEnd: "${TABLE}.closed_date",
+				Intervals: defaultDateIntervals,
+			},
+		},
+		{
+			name:        "unknown column",
+			duration:    models.DbtMetaLookerDuration{Start: "created_at", End: "closed_at"},
+			expectError: true,
+			errorMsg:    "end references unknown column closed_at",
+		},
+		{
+			name:        "string column",
+			duration:    models.DbtMetaLookerDuration{Start: "subject", End: "resolved_at"},
+			expectError: true,
+			errorMsg:    "column subject is STRING, durations need DATE, DATETIME or TIMESTAMP columns",
+		},
+		{
+			name:        "mixed column types",
+			duration:    models.DbtMetaLookerDuration{Start: "chat_started_at", End: "chat_ended_at"},
+			expectError: true,
+			errorMsg:    "start column chat_started_at is TIMESTAMP but end column chat_ended_at is DATE",
+		},
+		{
+			name:        "hours between dates",
+			duration:    models.DbtMetaLookerDuration{Start: "opened_date", End: "closed_date", Intervals: []enums.LookerDurationInterval{enums.IntervalHour}},
+			expectError: true,
+			errorMsg:    "interval hour needs DATETIME or TIMESTAMP columns",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view, err := NewViewGenerator(&config.Config{}).GenerateView(createDurationModel(tt.duration))
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			dimensionGroup := findDimensionGroup(view, tt.expected.Name)
+			require.NotNil(t, dimensionGroup)
+			assert.Equal(t, tt.expected, *dimensionGroup)
+			assert.NoError(t, dimensionGroup.Validate())
+		})
+	}
+}
+
+func TestDurationDimensionGroups_Rules(t *testing.T) {
+	cfg := &config.Config{Durations: []config.DurationRuleConfig{
+		{Start: "*_started_at", End: "*_ended_at", Name: "*", Intervals: []string{"second", "minute"}},
+		{Start: "created_at", End: "resolved_at"},
+	}}
+
+	view, err := NewViewGenerator(cfg).GenerateView(createDurationModel())
+	require.NoError(t, err)
+
+	call := findDimensionGroup(view, "call")
+	require.NotNil(t, call)
+	assert.Equal(t, "${TABLE}.call_started_at", call.SQLStart)
+	assert.Equal(t, "${TABLE}.call_ended_at", call.SQLEnd)
+	assert.Equal(t, []enums.LookerDurationInterval{enums.IntervalSecond, enums.IntervalMinute}, call.Intervals)
+
+	// chat_started_at and chat_ended_at have different types and are skipped
+	assert.Nil(t, findDimensionGroup(view, "chat"))
+
+	created := findDimensionGroup(view, "created_to_resolved")
+	require.NotNil(t, created)
+	assert.Equal(t, defaultTimeIntervals, created.Intervals)
+
+	// Duration fields are available to measures and explores
+	names := view.FieldNames()
+	assert.True(t, names["seconds_call"])
+	assert.True(t, names["hours_created_to_resolved"])
+}
+
+func TestDurationDimensionGroups_Conflict(t *testing.T) {
+	model := createDurationModel(models.DbtMetaLookerDuration{Name: "opened", Start: "opened_date", End: "closed_date"})
+
+	_, err := NewViewGenerator(&config.Config{}).GenerateView(model)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duration opened conflicts with an existing dimension group")
+}
+
+func TestDurationDimensionGroups_Rendered(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir}
+
+	model := createDurationModel(models.DbtMetaLookerDuration{
+		Name: "resolution", Start: "created_at", End: "resolved_at",
+		Intervals: []enums.LookerDurationInterval{enums.IntervalHour, enums.IntervalDay},
+	})
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "support/tickets.view.lkml")
+	assert.Contains(t, content, "  dimension_group: resolution {\n"+
+		"    type: duration\n"+
+		"    sql_start: ${TABLE}.created_at ;;\n"+
+		"    sql_end: ${TABLE}.resolved_at ;;\n"+
+		"    intervals: [hour, day]\n"+
+		"  }\n")
+}
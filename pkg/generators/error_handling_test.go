@@ -3,6 +3,7 @@ package generators
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
@@ -154,6 +155,26 @@ func createInvalidModel(name string) *models.DbtModel {
 	}
 }
 
+// createTestModel returns a model of the table project.dataset.<name> at path with the given
+// columns. The fixture factories of the generator tests build on it.
+func createTestModel(name, path string, columns ...models.DbtModelColumn) *models.DbtModel {
+	model := &models.DbtModel{
+		DbtNode:      models.DbtNode{Name: name},
+		RelationName: "`project.dataset." + name + "`",
+		Path:         path,
+		Columns:      make(map[string]models.DbtModelColumn, len(columns)),
+	}
+	for _, column := range columns {
+		model.Columns[column.Name] = column
+	}
+	return model
+}
+
+// testColumn returns a column of a BigQuery type. Columns with a path are nested.
+func testColumn(name, dataType string) models.DbtModelColumn {
+	return models.DbtModelColumn{Name: name, DataType: utils.StringPtr(dataType), Nested: strings.Contains(name, ".")}
+}
+
 func TestGenerationResult_ErrorSummaryDetails(t *testing.T) {
 	result := &GenerationResult{
 		FilesGenerated: 3,
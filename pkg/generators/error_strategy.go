@@ -74,6 +74,15 @@ type GenerationResult struct {
 
 	// ModelsProcessed is the total number of models attempted
 	ModelsProcessed int
+
+	// Warnings contains non-fatal findings, e.g. stale refinement references
+	Warnings []ModelWarning
+
+	// GovernedFields lists the fields that require access grants because they hold personal data
+	GovernedFields []GovernedField
+
+	// MissingTranslations lists, per locale, the localization keys without a translation
+	MissingTranslations map[string][]string
 }
 
 // HasErrors returns true if any errors occurred during generation.
@@ -2,29 +2,44 @@ package generators
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
 	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
 	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
-	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
 )
 
 // Compile-time check to ensure ExploreGenerator implements ExploreGeneratorInterface
 var _ ExploreGeneratorInterface = (*ExploreGenerator)(nil)
 
+// joinModelRefPattern matches ref('model') and ref('package', 'model') in join_model
+var joinModelRefPattern = regexp.MustCompile(`ref\(\s*['"]([^'"]+)['"]\s*(?:,\s*['"]([^'"]+)['"]\s*)?\)`)
+
 // ExploreGenerator handles generation of LookML explores
 type ExploreGenerator struct {
-	config *config.Config
+	config      *config.Config
+	naming      NamingStrategy
+	labels      *LabelGenerator
+	joinTargets map[string]*models.DbtModel // dbt name or unique_id -> selected model
+	diagnostics *Diagnostics
 }
 
 // NewExploreGenerator creates a new ExploreGenerator instance
 func NewExploreGenerator(cfg *config.Config) *ExploreGenerator {
 	return &ExploreGenerator{
 		config: cfg,
+		naming: NewNamingStrategy(cfg),
+		labels: NewLabelGenerator(cfg),
 	}
 }
 
+// SetNamingStrategy replaces the naming strategy of the generator
+func (g *ExploreGenerator) SetNamingStrategy(naming NamingStrategy) {
+	g.naming = naming
+}
+
 // GenerateExplore generates a LookML explore from a dbt model
 func (g *ExploreGenerator) GenerateExplore(model *models.DbtModel) (*models.LookMLExplore, error) {
 	explore := &models.LookMLExplore{
@@ -33,22 +48,142 @@ func (g *ExploreGenerator) GenerateExplore(model *models.DbtModel) (*models.Look
 		Label:       g.getExploreLabel(model),
 		Description: g.getExploreDescription(model),
 		Hidden:      g.getExploreHidden(model),
-		Joins:       g.getExploreJoins(model),
+	}
+	joins, err := g.getExploreJoins(model)
+	if err != nil {
+		return nil, err
+	}
+	explore.Joins = joins
+
+	g.applyExploreMeta(explore, getExploreMeta(model))
+	explore.AlwaysJoin = g.exploreAlwaysJoin(model, explore)
+
+	if err := explore.Validate(); err != nil {
+		return nil, err
 	}
 
 	return explore, nil
 }
 
+// getExploreMeta returns the meta.looker.explore block of a model, if any
+func getExploreMeta(model *models.DbtModel) *models.DbtMetaLookerExplore {
+	if model.Meta == nil || model.Meta.Looker == nil {
+		return nil
+	}
+	return model.Meta.Looker.Explore
+}
+
+// applyExploreMeta copies the explore parameters of a meta.looker.explore block.
+// Field names without a view are qualified with the explore's base view.
+func (g *ExploreGenerator) applyExploreMeta(explore *models.LookMLExplore, meta *models.DbtMetaLookerExplore) {
+	if meta == nil {
+		return
+	}
+
+	explore.GroupLabel = meta.GroupLabel
+	explore.SQLAlwaysWhere = meta.SQLAlwaysWhere
+	for _, field := range meta.Fields {
+		explore.Fields = append(explore.Fields, qualifyExploreField(explore.ViewName, field))
+	}
+	explore.PersistWith = meta.PersistWith
+	explore.Tags = meta.Tags
+	explore.AlwaysJoin = meta.AlwaysJoin
+	explore.Extra = meta.Extra
+	explore.AlwaysFilter = g.exploreFilters(explore.ViewName, meta.AlwaysFilter)
+
+	if meta.ConditionallyFilter != nil {
+		conditionallyFilter := &models.LookMLConditionalFilter{
+			Filters: g.exploreFilters(explore.ViewName, meta.ConditionallyFilter.Filters),
+		}
+		for _, field := range meta.ConditionallyFilter.Unless {
+			conditionallyFilter.Unless = append(conditionallyFilter.Unless, qualifyFieldName(explore.ViewName, field))
+		}
+		explore.ConditionallyFilter = conditionallyFilter
+	}
+
+	userAttributes := make([]string, 0, len(meta.AccessFilter))
+	for userAttribute := range meta.AccessFilter {
+		userAttributes = append(userAttributes, userAttribute)
+	}
+	sort.Strings(userAttributes)
+	for _, userAttribute := range userAttributes {
+		explore.AccessFilters = append(explore.AccessFilters, models.LookMLAccessFilter{
+			Field:         qualifyFieldName(explore.ViewName, meta.AccessFilter[userAttribute]),
+			UserAttribute: userAttribute,
+		})
+	}
+}
+
+// exploreFilters converts a field -> expression map into filters sorted by field
+func (g *ExploreGenerator) exploreFilters(viewName string, filters map[string]string) []models.LookMLFilter {
+	if len(filters) == 0 {
+		return nil
+	}
+
+	fields := make([]string, 0, len(filters))
+	for field := range filters {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	result := make([]models.LookMLFilter, 0, len(fields))
+	for _, field := range fields {
+		result = append(result, models.LookMLFilter{
+			Field:      qualifyFieldName(viewName, field),
+			Expression: filters[field],
+		})
+	}
+	return result
+}
+
+// qualifyFieldName prefixes a field name with a view name unless it already names a view
+func qualifyFieldName(viewName, field string) string {
+	field = strings.TrimSpace(field)
+	if field == "" || strings.Contains(field, ".") {
+		return field
+	}
+	return viewName + "." + field
+}
+
+// qualifyExploreField qualifies an entry of an explore's fields list. Exclusions keep their
+// leading "-" and ALL_FIELDS* stays as is; field sets (e.g. detail*) get the view like fields.
+func qualifyExploreField(viewName, field string) string {
+	field = strings.TrimSpace(field)
+	if strings.HasPrefix(field, "-") {
+		return "-" + qualifyExploreField(viewName, field[1:])
+	}
+	if field == "ALL_FIELDS*" {
+		return field
+	}
+	return qualifyFieldName(viewName, field)
+}
+
+// exploreAlwaysJoin resolves always_join entries to join names. An array column
+// (e.g. lines) names the join of its nested view (e.g. orders__lines).
+func (g *ExploreGenerator) exploreAlwaysJoin(model *models.DbtModel, explore *models.LookMLExplore) []string {
+	if len(explore.AlwaysJoin) == 0 {
+		return nil
+	}
+
+	joinNames := make(map[string]bool, len(explore.Joins))
+	for _, join := range explore.Joins {
+		joinNames[join.Name] = true
+	}
+
+	alwaysJoin := make([]string, 0, len(explore.AlwaysJoin))
+	for _, join := range explore.AlwaysJoin {
+		join = strings.TrimSpace(join)
+		if nested := g.naming.NestedViewName(model, join); !joinNames[join] && joinNames[nested] {
+			join = nested
+		}
+		alwaysJoin = append(alwaysJoin, join)
+	}
+	return alwaysJoin
+}
+
 // getExploreName gets the explore name from the model
 func (g *ExploreGenerator) getExploreName(model *models.DbtModel) string {
-	if g.config.UseTableName {
-		// Extract just the table name from relation_name (remove project.dataset prefix and backticks)
-		parts := strings.Split(model.RelationName, ".")
-		tableName := parts[len(parts)-1]
-		tableName = strings.Trim(tableName, "`")
-		return strings.ToLower(tableName)
-	}
-	return model.Name
+	return g.naming.ViewName(model)
 }
 
 // getExploreViewName gets the view name that the explore should reference
@@ -57,8 +192,13 @@ func (g *ExploreGenerator) getExploreViewName(model *models.DbtModel) string {
 	return g.getExploreName(model)
 }
 
-// getExploreLabel gets the explore label
+// getExploreLabel gets the explore label.
+// Explores are only labelled when meta.looker.explore or meta.looker.view sets a label.
 func (g *ExploreGenerator) getExploreLabel(model *models.DbtModel) *string {
+	if exploreMeta := getExploreMeta(model); exploreMeta != nil && exploreMeta.Label != nil {
+		return exploreMeta.Label
+	}
+
 	// Check if there's a custom label in model meta
 	if model.Meta != nil &&
 		model.Meta.Looker != nil &&
@@ -67,24 +207,14 @@ func (g *ExploreGenerator) getExploreLabel(model *models.DbtModel) *string {
 		return model.Meta.Looker.View.Label
 	}
 
-	// Generate from model name
-	label := strings.ReplaceAll(model.Name, "_", " ")
-	// Capitalize first letter of each word
-	words := strings.Fields(label)
-	for i, word := range words {
-		if len(word) > 0 {
-			words[i] = strings.ToUpper(string(word[0])) + strings.ToLower(word[1:])
-		}
-	}
-	result := strings.Join(words, " ")
-	return &result
+	return nil
 }
 
-// getExploreDescription gets the explore description
+// getExploreDescription gets the explore description.
+// Like the label, it is only set from meta.looker.explore or meta.looker.view.
 func (g *ExploreGenerator) getExploreDescription(model *models.DbtModel) *string {
-	// Use model description if available
-	if model.Description != "" {
-		return &model.Description
+	if exploreMeta := getExploreMeta(model); exploreMeta != nil && exploreMeta.Description != nil {
+		return exploreMeta.Description
 	}
 
 	// Check meta description
@@ -98,19 +228,25 @@ func (g *ExploreGenerator) getExploreDescription(model *models.DbtModel) *string
 	return nil
 }
 
-// getExploreHidden gets the explore hidden setting
+// getExploreHidden gets the explore hidden setting.
+// Only meta.looker.explore can un-hide an explore; a hidden view also hides its explore.
 func (g *ExploreGenerator) getExploreHidden(model *models.DbtModel) *bool {
+	if exploreMeta := getExploreMeta(model); exploreMeta != nil && exploreMeta.Hidden != nil {
+		return exploreMeta.Hidden
+	}
+
 	if model.Meta != nil &&
 		model.Meta.Looker != nil &&
 		model.Meta.Looker.View != nil &&
-		model.Meta.Looker.View.Hidden != nil {
+		model.Meta.Looker.View.Hidden != nil &&
+		*model.Meta.Looker.View.Hidden {
 		return model.Meta.Looker.View.Hidden
 	}
 	return nil
 }
 
 // getExploreJoins gets the joins for the explore from model metadata and generates nested view joins
-func (g *ExploreGenerator) getExploreJoins(model *models.DbtModel) []models.LookMLJoin {
+func (g *ExploreGenerator) getExploreJoins(model *models.DbtModel) ([]models.LookMLJoin, error) {
 	var joins []models.LookMLJoin
 
 	// Convert metadata joins to LookML joins if available
@@ -118,43 +254,121 @@ func (g *ExploreGenerator) getExploreJoins(model *models.DbtModel) []models.Look
 		model.Meta.Looker != nil &&
 		len(model.Meta.Looker.Joins) > 0 {
 		for _, metaJoin := range model.Meta.Looker.Joins {
-			lookmlJoin := g.convertMetaJoinToLookMLJoin(metaJoin)
+			lookmlJoin, err := g.convertMetaJoinToLookMLJoin(metaJoin)
+			if err != nil {
+				// A broken join would invalidate the whole explore; leave it out instead
+				g.diagnostics.Warn(model.Name, WarningJoin, fmt.Sprintf("skipping join: %v", err))
+				continue
+			}
 			joins = append(joins, lookmlJoin)
 		}
 	}
 
 	// Generate automatic joins for nested views (ARRAY columns)
-	nestedViewJoins := g.generateNestedViewJoins(model)
+	nestedViewJoins, err := g.generateNestedViewJoins(model)
+	if err != nil {
+		return nil, err
+	}
 	joins = append(joins, nestedViewJoins...)
 
-	return joins
+	return joins, nil
+}
+
+// setJoinTargets registers the models selected for generation, which are the only valid join targets
+func (g *ExploreGenerator) setJoinTargets(selected []*models.DbtModel) {
+	g.joinTargets = make(map[string]*models.DbtModel, len(selected)*2)
+	for _, model := range selected {
+		g.joinTargets[model.Name] = model
+		if model.UniqueID != "" {
+			g.joinTargets[model.UniqueID] = model
+		}
+	}
+}
+
+// resolveJoinModel finds the model a join_model value refers to. It returns the
+// view name the join points at, and the model when join targets are known.
+func (g *ExploreGenerator) resolveJoinModel(joinModel string) (*models.DbtModel, string, error) {
+	name := strings.TrimSpace(joinModel)
+	name = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(name, "{{"), "}}"))
+	if match := joinModelRefPattern.FindStringSubmatch(name); match != nil {
+		name = match[1]
+		if match[2] != "" {
+			// ref('package', 'model')
+			name = match[2]
+		}
+	}
+	if name == "" {
+		return nil, "", fmt.Errorf("join_model is required")
+	}
+
+	if g.joinTargets == nil {
+		// Generating a single explore: trust the name as given
+		return nil, name, nil
+	}
+
+	target, ok := g.joinTargets[name]
+	if !ok {
+		return nil, "", fmt.Errorf("join_model %s is not among the selected models", joinModel)
+	}
+	return target, g.getExploreViewName(target), nil
 }
 
 // convertMetaJoinToLookMLJoin converts a metadata join to a LookML join
-func (g *ExploreGenerator) convertMetaJoinToLookMLJoin(metaJoin models.DbtMetaLookerJoin) models.LookMLJoin {
-	return models.LookMLJoin{
-		Name:         "",  // Would need to be derived from JoinModel
-		ViewLabel:    nil, // Not available in metadata join
-		SQL:          metaJoin.SQLON,
-		Type:         metaJoin.Type,
-		Relationship: metaJoin.Relationship,
+func (g *ExploreGenerator) convertMetaJoinToLookMLJoin(metaJoin models.DbtMetaLookerJoin) (models.LookMLJoin, error) {
+	if metaJoin.JoinModel == nil {
+		return models.LookMLJoin{}, fmt.Errorf("join_model is required")
 	}
+
+	_, viewName, err := g.resolveJoinModel(*metaJoin.JoinModel)
+	if err != nil {
+		return models.LookMLJoin{}, err
+	}
+
+	join := models.LookMLJoin{
+		Name:          viewName,
+		ViewLabel:     metaJoin.ViewLabel,
+		SQLOn:         metaJoin.SQLON,
+		Type:          metaJoin.Type,
+		Relationship:  metaJoin.Relationship,
+		Fields:        metaJoin.Fields,
+		RequiredJoins: metaJoin.RequiredJoins,
+	}
+
+	// An alias joins the same view under another name
+	if metaJoin.Alias != nil && *metaJoin.Alias != "" && *metaJoin.Alias != viewName {
+		join.Name = *metaJoin.Alias
+		join.From = &viewName
+	}
+
+	if err := join.Validate(); err != nil {
+		return models.LookMLJoin{}, err
+	}
+
+	return join, nil
 }
 
 // generateNestedViewJoins generates joins for nested views based on ARRAY columns
-func (g *ExploreGenerator) generateNestedViewJoins(model *models.DbtModel) []models.LookMLJoin {
-	var joins []models.LookMLJoin
-
+func (g *ExploreGenerator) generateNestedViewJoins(model *models.DbtModel) ([]models.LookMLJoin, error) {
 	// Use column collections to identify ARRAY columns that need nested view joins
-	columnCollections := models.NewColumnCollections(model, nil)
+	columnCollections, err := newColumnCollections(g.config, model)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sorted, so arrays in arrays are joined after the array they are in
+	arrayNames := make([]string, 0, len(columnCollections.NestedViewColumns))
+	for arrayName := range columnCollections.NestedViewColumns {
+		arrayNames = append(arrayNames, arrayName)
+	}
+	sort.Strings(arrayNames)
 
 	// Generate a join for each nested view
-	for arrayColumnName := range columnCollections.NestedViewColumns {
-		join := g.createNestedViewJoin(model, arrayColumnName)
-		joins = append(joins, join)
+	joins := make([]models.LookMLJoin, 0, len(arrayNames))
+	for _, arrayColumnName := range arrayNames {
+		joins = append(joins, g.createNestedViewJoin(model, arrayColumnName))
 	}
 
-	return joins
+	return joins, nil
 }
 
 // createNestedViewJoin creates a join for a specific nested view
@@ -168,58 +382,62 @@ func (g *ExploreGenerator) createNestedViewJoin(model *models.DbtModel, arrayCol
 	// Generate SQL for the join
 	sql := g.getNestedViewJoinSQL(model, arrayColumnName, nestedViewName)
 
-	// Create relationship enum value
-	relationship := enums.LookerRelationshipType("one_to_many")
+	// Invalid unnest meta was reported when planning the model
+	_, relationship, _ := arrayUnnest(g.getArrayColumn(model, arrayColumnName))
 
-	return models.LookMLJoin{
+	join := models.LookMLJoin{
 		Name:         nestedViewName,
 		ViewLabel:    &viewLabel,
 		SQL:          &sql,
 		Relationship: &relationship,
 	}
-}
 
-// getNestedViewName generates the view name for a nested view join
-func (g *ExploreGenerator) getNestedViewName(model *models.DbtModel, arrayColumnName string) string {
-	var baseName string
+	// Arrays in arrays need the joins of the arrays they are in, outermost first
+	for parentArray := parentArrayName(model, arrayColumnName); parentArray != ""; parentArray = parentArrayName(model, parentArray) {
+		join.RequiredJoins = append([]string{g.getNestedViewName(model, parentArray)}, join.RequiredJoins...)
+	}
 
-	if g.config.UseTableName {
-		// Extract just the table name from relation_name
-		parts := strings.Split(model.RelationName, ".")
-		tableName := parts[len(parts)-1]
-		tableName = strings.Trim(tableName, "`")
-		baseName = strings.ToLower(tableName)
-	} else {
-		baseName = model.Name
+	return join
+}
+
+// getArrayColumn returns the column of an array, or nil when the model has no such column
+func (g *ExploreGenerator) getArrayColumn(model *models.DbtModel, arrayColumnName string) *models.DbtModelColumn {
+	column, ok := model.Columns[arrayColumnName]
+	if !ok {
+		return nil
 	}
+	return &column
+}
 
-	// Create nested view name by converting to LookML naming convention
-	// This handles PascalCase -> snake_case conversion (SupplierInformation -> supplier_information)
-	nestedSuffix := utils.ToLookMLName(arrayColumnName)
-	return fmt.Sprintf("%s__%s", baseName, nestedSuffix)
+// getNestedViewName generates the view name for a nested view join
+func (g *ExploreGenerator) getNestedViewName(model *models.DbtModel, arrayColumnName string) string {
+	return nestedArrayViewName(g.naming, model, arrayColumnName)
 }
 
 // getNestedViewLabel generates a human-readable label for the nested view
 func (g *ExploreGenerator) getNestedViewLabel(model *models.DbtModel, arrayColumnName string) string {
 	// Use the same naming logic as explore names for consistency
-	baseName := g.getExploreName(model)
-	modelLabel := utils.ToTitleCase(strings.ReplaceAll(baseName, "_", " "))
-
-	// Convert array column name to title case
-	arrayLabel := utils.ToTitleCase(strings.ReplaceAll(strings.ReplaceAll(arrayColumnName, ".", " "), "_", " "))
-
-	return fmt.Sprintf("%s: %s", modelLabel, arrayLabel)
+	return fmt.Sprintf("%s: %s", g.labels.Label(g.getExploreName(model)), g.labels.Label(arrayColumnName))
 }
 
-// getNestedViewJoinSQL generates the SQL for joining a nested view
+// getNestedViewJoinSQL generates the SQL for joining a nested view. Arrays of the main view
+// are unnested from its reference dimension, arrays in arrays from the field of the nested
+// view of the array they are in.
 func (g *ExploreGenerator) getNestedViewJoinSQL(model *models.DbtModel, arrayColumnName string, nestedViewName string) string {
-	// Generate the main view reference using the same logic as explore/view names
-	mainViewName := g.getExploreName(model)
+	column := g.getArrayColumn(model, arrayColumnName)
 
-	// Convert array column name to LookML reference (dots to double underscores)
-	arrayFieldRef := strings.ReplaceAll(arrayColumnName, ".", "__")
+	// Convert array column name to LookML reference (dots to the nested separator)
+	arrayFieldRef := fmt.Sprintf("${%s.%s}", g.getExploreName(model), g.naming.Join(strings.Split(arrayColumnName, ".")...))
+	if parentArray := parentArrayName(model, arrayColumnName); parentArray != "" && column != nil {
+		arrayFieldRef = fmt.Sprintf("${%s.%s}", g.getNestedViewName(model, parentArray), nestedViewFieldName(g.naming, parentArray, column))
+	}
 
-	return fmt.Sprintf("LEFT JOIN UNNEST(${%s.%s}) as %s", mainViewName, arrayFieldRef, nestedViewName)
+	unnestJoin, _, _ := arrayUnnest(column)
+	sql := fmt.Sprintf("%s JOIN UNNEST(%s) as %s", strings.ToUpper(unnestJoin), arrayFieldRef, nestedViewName)
+	if column != nil && joinsWithOffset(g.config, model, column) {
+		sql += " WITH OFFSET as " + nestedOffsetAlias(nestedViewName)
+	}
+	return sql
 }
 
 // getStringPtr returns a pointer to a string
@@ -0,0 +1,117 @@
+package generators
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+)
+
+// exploreViewFields returns the fields available per view name in an explore:
+// the model's own views plus the views of its resolved meta joins
+func (g *LookMLGenerator) exploreViewFields(views []*models.LookMLView, targets []metaJoinTarget) map[string]map[string]bool {
+	available := make(map[string]map[string]bool, len(views)+len(targets))
+	for _, view := range views {
+		available[view.Name] = view.FieldNames()
+	}
+
+	for _, target := range targets {
+		fields, err := g.joinViewFields(target.model)
+		if err != nil {
+			// The joined model reports its own generation error
+			continue
+		}
+		available[target.name] = fields
+	}
+
+	return available
+}
+
+// joinViewFields returns the fields of the view generated for a joined model
+func (g *LookMLGenerator) joinViewFields(model *models.DbtModel) (map[string]bool, error) {
+	if fields, ok := g.joinFields[model.Name]; ok {
+		return fields, nil
+	}
+
+	view, err := g.viewGenerator.GenerateView(model)
+	if err != nil {
+		return nil, err
+	}
+
+	if g.joinFields == nil {
+		g.joinFields = make(map[string]map[string]bool)
+	}
+	g.joinFields[model.Name] = view.FieldNames()
+	return g.joinFields[model.Name], nil
+}
+
+// validateExploreFields checks that the fields used by meta.looker.explore parameters exist.
+// Unlike join warnings these are errors: access filters enforce row-level security.
+func (g *LookMLGenerator) validateExploreFields(model *models.DbtModel, views []*models.LookMLView, explore *models.LookMLExplore) error {
+	if getExploreMeta(model) == nil {
+		return nil
+	}
+
+	available := g.exploreViewFields(views, g.metaJoinTargets(model))
+	base := explore.ViewName
+
+	var issues []string
+	for _, filter := range explore.AlwaysFilter {
+		issues = append(issues, fieldReferenceIssues(filter.Field, base, "always_filter", available)...)
+	}
+
+	if explore.ConditionallyFilter != nil {
+		for _, filter := range explore.ConditionallyFilter.Filters {
+			issues = append(issues, fieldReferenceIssues(filter.Field, base, "conditionally_filter", available)...)
+		}
+		for _, field := range explore.ConditionallyFilter.Unless {
+			issues = append(issues, fieldReferenceIssues(field, base, "conditionally_filter unless", available)...)
+		}
+	}
+
+	for _, accessFilter := range explore.AccessFilters {
+		issues = append(issues, fieldReferenceIssues(accessFilter.Field, base, "access_filter", available)...)
+	}
+
+	for _, field := range explore.Fields {
+		issues = append(issues, fieldReferenceIssues(field, base, "fields", available)...)
+	}
+
+	joinNames := make(map[string]bool, len(explore.Joins))
+	for _, join := range explore.Joins {
+		joinNames[join.Name] = true
+	}
+	for _, join := range explore.AlwaysJoin {
+		if !joinNames[join] {
+			issues = append(issues, fmt.Sprintf("always_join references join %s which is not part of the explore", join))
+		}
+	}
+
+	if len(issues) > 0 {
+		return fmt.Errorf("explore %s: %s", explore.Name, strings.Join(uniqueSorted(issues), "; "))
+	}
+	return nil
+}
+
+// fieldReferenceIssues checks a field name such as `view.field` or `field` (resolved against
+// defaultView). Sets and exclusions of sets (e.g. ALL_FIELDS*, -detail*) are not checked.
+func fieldReferenceIssues(field, defaultView, context string, available map[string]map[string]bool) []string {
+	field = strings.TrimPrefix(strings.TrimSpace(field), "-")
+	if field == "" || strings.HasSuffix(field, "*") {
+		return nil
+	}
+
+	viewName, fieldName := defaultView, field
+	if index := strings.Index(field, "."); index >= 0 {
+		viewName, fieldName = field[:index], field[index+1:]
+	}
+
+	fields, ok := available[viewName]
+	if !ok {
+		return []string{fmt.Sprintf("%s references view %s which is not part of the explore", context, viewName)}
+	}
+	if !fieldAvailable(fields, fieldName) {
+		return []string{fmt.Sprintf("%s references %s.%s which does not exist", context, viewName, fieldName)}
+	}
+	return nil
+}
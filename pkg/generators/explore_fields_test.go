@@ -0,0 +1,129 @@
+package generators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createExploreMetaModels returns the join models with orders joining customers under the given explore meta
+func createExploreMetaModels(exploreMeta *models.DbtMetaLookerExplore) []*models.DbtModel {
+	selected := createJoinModels(models.DbtMetaLookerJoin{
+		JoinModel: utils.StringPtr("customers"),
+		SQLON:     utils.StringPtr("${orders.customer_id} = ${customers.id}"),
+	})
+	selected[0].Meta.Looker.Explore = exploreMeta
+	return selected
+}
+
+func TestExploreMeta_Rendered(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir}
+
+	selected := createExploreMetaModels(&models.DbtMetaLookerExplore{
+		DbtMetaLookerBase: models.DbtMetaLookerBase{Label: utils.StringPtr("Sales Orders"), Hidden: utils.BoolPtr(false)},
+		GroupLabel:        utils.StringPtr("Sales"),
+		AlwaysFilter:      map[string]string{"id": "NOT NULL"},
+		ConditionallyFilter: &models.DbtMetaLookerConditionalFilter{
+			Filters: map[string]string{"customer_id": "NOT NULL"},
+			Unless:  []string{"customers.id"},
+		},
+		SQLAlwaysWhere: utils.StringPtr("${orders.id} > 0"),
+		AccessFilter:   map[string]string{"customer": "customers.name"},
+		Fields:         []string{"ALL_FIELDS*", "-customers.name"},
+		PersistWith:    utils.StringPtr("daily"),
+		Tags:           []string{"sales", "pii"},
+		AlwaysJoin:     []string{"customers"},
+	})
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), selected, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.Contains(t, content, "explore: orders {\n"+
+		"  label: \"Sales Orders\"\n"+
+		"  group_label: \"Sales\"\n"+
+		"  hidden: no\n"+
+		"  tags: [\"sales\", \"pii\"]\n"+
+		"  persist_with: daily\n"+
+		"  fields: [ALL_FIELDS*, -customers.name]\n"+
+		"  always_join: [customers]\n"+
+		"  sql_always_where: ${orders.id} > 0 ;;\n"+
+		"  always_filter: {\n"+
+		"    filters: [orders.id: \"NOT NULL\"]\n"+
+		"  }\n"+
+		"  conditionally_filter: {\n"+
+		"    filters: [orders.customer_id: \"NOT NULL\"]\n"+
+		"    unless: [customers.id]\n"+
+		"  }\n"+
+		"  access_filter: {\n"+
+		"    field: customers.name\n"+
+		"    user_attribute: customer\n"+
+		"  }\n")
+}
+
+func TestExploreMeta_DefaultStaysHidden(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), createExploreMetaModels(nil), GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.Contains(t, content, "  hidden: yes\n")
+	assert.NotContains(t, content, "access_filter")
+}
+
+func TestExploreMeta_InvalidFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		meta     *models.DbtMetaLookerExplore
+		errorMsg string
+	}{
+		{
+			name:     "access filter on missing field",
+			meta:     &models.DbtMetaLookerExplore{AccessFilter: map[string]string{"region": "region"}},
+			errorMsg: "access_filter references orders.region which does not exist",
+		},
+		{
+			name:     "always filter on view outside the explore",
+			meta:     &models.DbtMetaLookerExplore{AlwaysFilter: map[string]string{"products.id": "1"}},
+			errorMsg: "always_filter references view products which is not part of the explore",
+		},
+		{
+			name: "conditionally filter unless",
+			meta: &models.DbtMetaLookerExplore{ConditionallyFilter: &models.DbtMetaLookerConditionalFilter{
+				Filters: map[string]string{"id": "1"},
+				Unless:  []string{"customers.email"},
+			}},
+			errorMsg: "conditionally_filter unless references customers.email which does not exist",
+		},
+		{
+			name:     "fields",
+			meta:     &models.DbtMetaLookerExplore{Fields: []string{"orders.total"}},
+			errorMsg: "fields references orders.total which does not exist",
+		},
+		{
+			name:     "always join",
+			meta:     &models.DbtMetaLookerExplore{AlwaysJoin: []string{"products"}},
+			errorMsg: "always_join references join products which is not part of the explore",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{OutputDir: t.TempDir()}
+
+			result, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), createExploreMetaModels(tt.meta), GenerationOptions{ErrorStrategy: ContinueOnError})
+			require.NoError(t, err)
+			require.Len(t, result.Errors, 1)
+			assert.Equal(t, "orders", result.Errors[0].ModelName)
+			assert.Contains(t, result.Errors[0].Error.Error(), tt.errorMsg)
+		})
+	}
+}
@@ -1,11 +1,13 @@
 package generators
 
 import (
+	"context"
 	"testing"
 
 	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
 	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
 	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -35,7 +37,7 @@ func TestExploreGenerator_GenerateExplore(t *testing.T) {
 			},
 			expectedName:     "test_model",
 			expectedViewName: "test_model",
-			expectedLabel:    exploreStringPtr("Test Model"),
+			expectedLabel:    nil,
 			expectError:      false,
 		},
 		{
@@ -48,7 +50,7 @@ func TestExploreGenerator_GenerateExplore(t *testing.T) {
 			},
 			expectedName:     "customer_order_summary",
 			expectedViewName: "customer_order_summary",
-			expectedLabel:    exploreStringPtr("Customer Order Summary"),
+			expectedLabel:    nil,
 			expectError:      false,
 		},
 		{
@@ -93,6 +95,8 @@ func TestExploreGenerator_GenerateExplore(t *testing.T) {
 				if tt.expectedLabel != nil {
 					require.NotNil(t, explore.Label)
 					assert.Equal(t, *tt.expectedLabel, *explore.Label)
+				} else {
+					assert.Nil(t, explore.Label)
 				}
 			}
 		})
@@ -140,8 +144,8 @@ func TestExploreGenerator_ExploreAttributes(t *testing.T) {
 				Description:  "This is a test model with description",
 			},
 			checkFunc: func(t *testing.T, explore *models.LookMLExplore) {
-				require.NotNil(t, explore.Description)
-				assert.Equal(t, "This is a test model with description", *explore.Description)
+				// The model description documents the view, not the explore
+				assert.Nil(t, explore.Description)
 			},
 		},
 		{
@@ -194,10 +198,9 @@ func TestExploreGenerator_ExploreAttributes(t *testing.T) {
 				assert.NotNil(t, explore.Joins)
 				if len(explore.Joins) > 0 {
 					join := explore.Joins[0]
-					// Note: Current implementation sets Name to empty string
-					assert.Equal(t, "", join.Name)
-					if join.SQL != nil {
-						assert.Equal(t, "${join_model.id} = ${other_model.join_id}", *join.SQL)
+					assert.Equal(t, "other_model", join.Name)
+					if join.SQLOn != nil {
+						assert.Equal(t, "${join_model.id} = ${other_model.join_id}", *join.SQLOn)
 					}
 					if join.Type != nil {
 						assert.Equal(t, enums.JoinLeftOuter, *join.Type)
@@ -226,28 +229,27 @@ func TestExploreGenerator_LabelGeneration(t *testing.T) {
 
 	tests := []struct {
 		name          string
-		modelName     string
-		expectedLabel string
+		meta          *models.DbtModelMeta
+		expectedLabel *string
 	}{
 		{
-			name:          "simple name",
-			modelName:     "users",
-			expectedLabel: "Users",
-		},
-		{
-			name:          "name with underscores",
-			modelName:     "customer_orders",
-			expectedLabel: "Customer Orders",
+			name:          "no meta",
+			expectedLabel: nil,
 		},
 		{
-			name:          "complex name with multiple underscores",
-			modelName:     "dim_customer_order_summary",
-			expectedLabel: "Dim Customer Order Summary",
+			name: "view meta label",
+			meta: &models.DbtModelMeta{Looker: &models.DbtMetaLooker{
+				View: &models.DbtMetaLookerBase{Label: exploreStringPtr("Customer Orders")},
+			}},
+			expectedLabel: exploreStringPtr("Customer Orders"),
 		},
 		{
-			name:          "single character",
-			modelName:     "a",
-			expectedLabel: "A",
+			name: "explore meta label takes priority",
+			meta: &models.DbtModelMeta{Looker: &models.DbtMetaLooker{
+				View:    &models.DbtMetaLookerBase{Label: exploreStringPtr("Customer Orders")},
+				Explore: &models.DbtMetaLookerExplore{DbtMetaLookerBase: models.DbtMetaLookerBase{Label: exploreStringPtr("Orders")}},
+			}},
+			expectedLabel: exploreStringPtr("Orders"),
 		},
 	}
 
@@ -255,16 +257,21 @@ func TestExploreGenerator_LabelGeneration(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			model := &models.DbtModel{
 				DbtNode: models.DbtNode{
-					Name: tt.modelName,
+					Name: "customer_orders",
 				},
 				RelationName: "`project.dataset.table`",
+				Meta:         tt.meta,
 			}
 
 			explore, err := generator.GenerateExplore(model)
 			require.NoError(t, err)
 			require.NotNil(t, explore)
-			require.NotNil(t, explore.Label)
-			assert.Equal(t, tt.expectedLabel, *explore.Label)
+			if tt.expectedLabel == nil {
+				assert.Nil(t, explore.Label)
+			} else {
+				require.NotNil(t, explore.Label)
+				assert.Equal(t, *tt.expectedLabel, *explore.Label)
+			}
 		})
 	}
 }
@@ -316,10 +323,9 @@ func TestExploreGenerator_JoinGeneration(t *testing.T) {
 			checkJoins: func(t *testing.T, joins []models.LookMLJoin) {
 				require.Len(t, joins, 1)
 				join := joins[0]
-				// Note: Current implementation sets Name to empty string
-				assert.Equal(t, "", join.Name)
-				require.NotNil(t, join.SQL)
-				assert.Equal(t, "${orders.user_id} = ${users.id}", *join.SQL)
+				assert.Equal(t, "users", join.Name)
+				require.NotNil(t, join.SQLOn)
+				assert.Equal(t, "${orders.user_id} = ${users.id}", *join.SQLOn)
 				require.NotNil(t, join.Type)
 				assert.Equal(t, enums.JoinLeftOuter, *join.Type)
 				require.NotNil(t, join.Relationship)
@@ -357,14 +363,12 @@ func TestExploreGenerator_JoinGeneration(t *testing.T) {
 				require.Len(t, joins, 2)
 
 				// Check first join
-				// Note: Current implementation sets Name to empty string
-				assert.Equal(t, "", joins[0].Name)
+				assert.Equal(t, "users", joins[0].Name)
 				require.NotNil(t, joins[0].Type)
 				assert.Equal(t, enums.JoinLeftOuter, *joins[0].Type)
 
 				// Check second join
-				// Note: Current implementation sets Name to empty string
-				assert.Equal(t, "", joins[1].Name)
+				assert.Equal(t, "products", joins[1].Name)
 				require.NotNil(t, joins[1].Type)
 				assert.Equal(t, enums.JoinInner, *joins[1].Type)
 			},
@@ -433,6 +437,143 @@ func TestExploreGenerator_JoinTypes(t *testing.T) {
 	}
 }
 
+// createPackagingModel returns the packaging columns of d_item_v3: a struct in the main view
+// holding an array of packaging materials, each with an array of quantities
+func createPackagingModel(t *testing.T) *models.DbtModel {
+	t.Helper()
+	const (
+		quantity     = "STRUCT<quantity_unit_of_measure STRING, quantity_value NUMERIC>"
+		materialType = "STRUCT<code_description STRING, code_name STRING, code_value STRING>"
+		composition  = "STRUCT<packaging_material_composition_quantity ARRAY<" + quantity + ">, packaging_material_type " + materialType + ">"
+	)
+	return createTestModel("d_item_v3", "marts/d_item_v3.sql",
+		testColumn("d_item_key", "INT64"),
+		testColumn("packaging_information", "STRUCT<packaging_material_composition ARRAY<"+composition+">, packaging_weight NUMERIC, packaging_weight_uom STRING>"),
+		testColumn("packaging_information.packaging_material_composition", "ARRAY<"+composition+">"),
+		testColumn("packaging_information.packaging_material_composition.packaging_material_composition_quantity", "ARRAY<"+quantity+">"),
+		testColumn("packaging_information.packaging_material_composition.packaging_material_composition_quantity.quantity_unit_of_measure", "STRING"),
+		testColumn("packaging_information.packaging_material_composition.packaging_material_composition_quantity.quantity_value", "NUMERIC"),
+		testColumn("packaging_information.packaging_material_composition.packaging_material_type", materialType),
+		testColumn("packaging_information.packaging_material_composition.packaging_material_type.code_description", "STRING"),
+		testColumn("packaging_information.packaging_material_composition.packaging_material_type.code_name", "STRING"),
+		testColumn("packaging_information.packaging_material_composition.packaging_material_type.code_value", "STRING"),
+		testColumn("packaging_information.packaging_weight", "NUMERIC"),
+		testColumn("packaging_information.packaging_weight_uom", "STRING"),
+	)
+}
+
+// TestExploreGenerator_NestedArrayJoins tests that the array in a struct of the main view and
+// the array in that array are each unnested from the view holding them
+func TestExploreGenerator_NestedArrayJoins(t *testing.T) {
+	outputDir := t.TempDir()
+	model := createPackagingModel(t)
+
+	_, err := NewLookMLGenerator(&config.Config{OutputDir: outputDir}).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+	content := readOutput(t, outputDir, "marts/d_item_v3.view.lkml")
+
+	const (
+		composition = "d_item_v3__packaging_information__packaging_material_composition"
+		quantity    = composition + "__packaging_material_composition_quantity"
+	)
+
+	// The array in the array requires the join of the array holding it
+	assert.Contains(t, content, "  join: "+composition+" {\n"+
+		"    view_label: \"D Item V3: Packaging Information Packaging Material Composition\"\n"+
+		"    sql: LEFT JOIN UNNEST(${d_item_v3.packaging_information__packaging_material_composition}) as "+composition+" ;;\n"+
+		"    relationship: one_to_many\n"+
+		"  }\n")
+	assert.Contains(t, content, "  join: "+quantity+" {\n"+
+		"    view_label: \"D Item V3: Packaging Information Packaging Material Composition Packaging Material Composition Quantity\"\n"+
+		"    required_joins: ["+composition+"]\n"+
+		"    sql: LEFT JOIN UNNEST(${"+composition+".packaging_material_composition_quantity}) as "+quantity+" ;;\n"+
+		"    relationship: one_to_many\n"+
+		"  }\n")
+
+	// The struct fields of the main view are grouped
+	assert.Contains(t, content, "  dimension: packaging_information__packaging_weight {\n"+
+		"    type: number\n"+
+		"    sql: ${TABLE}.packaging_information.packaging_weight ;;\n"+
+		"    group_label: \"Packaging Information\"\n"+
+		"    group_item_label: \"Packaging Weight\"\n"+
+		"  }\n")
+
+	// Fields of the nested views are relative to the element they unnest
+	assert.Contains(t, content, "view: "+composition+" {\n")
+	assert.Contains(t, content, "  dimension: packaging_material_type__code_value {\n"+
+		"    type: string\n"+
+		"    sql: ${TABLE}.packaging_material_type.code_value ;;\n")
+	assert.Contains(t, content, "  dimension: packaging_material_composition_quantity {\n"+
+		"    type: string\n"+
+		"    sql: ${TABLE}.packaging_material_composition_quantity ;;\n")
+	assert.Contains(t, content, "view: "+quantity+" {\n")
+	assert.Contains(t, content, "  dimension: quantity_value {\n"+
+		"    type: number\n"+
+		"    sql: ${TABLE}.quantity_value ;;\n")
+}
+
+func TestExploreGenerator_UnnestMeta(t *testing.T) {
+	model := createPackagingModel(t)
+	quantityName := "packaging_information.packaging_material_composition.packaging_material_composition_quantity"
+	quantity := model.Columns[quantityName]
+	quantity.Meta = &models.DbtModelColumnMeta{Looker: &models.DbtMetaLooker{
+		Unnest: &models.DbtMetaLookerUnnest{
+			Join:         utils.StringPtr("CROSS"),
+			Relationship: relationshipPtr(enums.RelationshipOneToOne),
+		},
+	}}
+	model.Columns[quantityName] = quantity
+
+	explore, err := NewExploreGenerator(&config.Config{}).GenerateExplore(model)
+	require.NoError(t, err)
+	require.Len(t, explore.Joins, 2)
+
+	assert.Equal(t, "LEFT JOIN UNNEST(${d_item_v3.packaging_information__packaging_material_composition}) as d_item_v3__packaging_information__packaging_material_composition", *explore.Joins[0].SQL)
+	assert.Equal(t, "CROSS JOIN UNNEST(${d_item_v3__packaging_information__packaging_material_composition.packaging_material_composition_quantity}) as d_item_v3__packaging_information__packaging_material_composition__packaging_material_composition_quantity", *explore.Joins[1].SQL)
+	assert.Equal(t, enums.RelationshipOneToOne, *explore.Joins[1].Relationship)
+}
+
+func TestExploreGenerator_InvalidUnnestMeta(t *testing.T) {
+	tests := []struct {
+		name   string
+		column string
+		unnest models.DbtMetaLookerUnnest
+		errMsg string
+	}{
+		{
+			name:   "join",
+			column: "packaging_information.packaging_material_composition",
+			unnest: models.DbtMetaLookerUnnest{Join: utils.StringPtr("inner")},
+			errMsg: "invalid unnest.join in meta of column packaging_information.packaging_material_composition: inner",
+		},
+		{
+			name:   "relationship",
+			column: "packaging_information.packaging_material_composition",
+			unnest: models.DbtMetaLookerUnnest{Relationship: relationshipPtr(enums.RelationshipManyToOne)},
+			errMsg: "invalid unnest.relationship in meta of column packaging_information.packaging_material_composition: many_to_one",
+		},
+		{
+			name:   "not an array",
+			column: "packaging_information.packaging_weight",
+			unnest: models.DbtMetaLookerUnnest{Join: utils.StringPtr("cross")},
+			errMsg: "unnest meta of column packaging_information.packaging_weight: only array columns are unnested",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := createPackagingModel(t)
+			column := model.Columns[tt.column]
+			column.Meta = &models.DbtModelColumnMeta{Looker: &models.DbtMetaLooker{Unnest: &tt.unnest}}
+			model.Columns[tt.column] = column
+
+			_, err := NewLookMLGenerator(&config.Config{OutputDir: t.TempDir()}).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{ErrorStrategy: FailFast})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
 func TestExploreGenerator_ErrorHandling(t *testing.T) {
 	cfg := &config.Config{}
 	generator := NewExploreGenerator(cfg)
@@ -494,7 +635,7 @@ func TestExploreGenerator_DescriptionPriority(t *testing.T) {
 			name:                "model description only",
 			modelDescription:    "Model description",
 			metaDescription:     nil,
-			expectedDescription: exploreStringPtr("Model description"),
+			expectedDescription: nil,
 		},
 		{
 			name:                "meta description only",
@@ -503,10 +644,10 @@ func TestExploreGenerator_DescriptionPriority(t *testing.T) {
 			expectedDescription: exploreStringPtr("Meta description"),
 		},
 		{
-			name:                "model description takes priority",
+			name:                "meta description takes priority",
 			modelDescription:    "Model description",
 			metaDescription:     exploreStringPtr("Meta description"),
-			expectedDescription: exploreStringPtr("Model description"),
+			expectedDescription: exploreStringPtr("Meta description"),
 		},
 		{
 			name:                "no description",
@@ -566,3 +707,249 @@ func joinTypePtr(jt enums.LookerJoinType) *enums.LookerJoinType {
 func relationshipPtr(rt enums.LookerRelationshipType) *enums.LookerRelationshipType {
 	return &rt
 }
+
+func TestExploreGenerator_JoinResolution(t *testing.T) {
+	customers := &models.DbtModel{
+		DbtNode:      models.DbtNode{Name: "customers", UniqueID: "model.shop.customers"},
+		RelationName: "`project.dataset.dim_customers`",
+	}
+
+	tests := []struct {
+		name         string
+		cfg          *config.Config
+		join         models.DbtMetaLookerJoin
+		expectedName string
+		expectedFrom *string
+		expectSkip   bool
+	}{
+		{
+			name:         "dbt model name",
+			cfg:          &config.Config{},
+			join:         models.DbtMetaLookerJoin{JoinModel: exploreStringPtr("customers")},
+			expectedName: "customers",
+		},
+		{
+			name:         "ref string",
+			cfg:          &config.Config{},
+			join:         models.DbtMetaLookerJoin{JoinModel: exploreStringPtr("ref('customers')")},
+			expectedName: "customers",
+		},
+		{
+			name:         "templated ref with package",
+			cfg:          &config.Config{},
+			join:         models.DbtMetaLookerJoin{JoinModel: exploreStringPtr(`{{ ref("shop", "customers") }}`)},
+			expectedName: "customers",
+		},
+		{
+			name:         "unique id",
+			cfg:          &config.Config{},
+			join:         models.DbtMetaLookerJoin{JoinModel: exploreStringPtr("model.shop.customers")},
+			expectedName: "customers",
+		},
+		{
+			name:         "use table name",
+			cfg:          &config.Config{UseTableName: true},
+			join:         models.DbtMetaLookerJoin{JoinModel: exploreStringPtr("ref('customers')")},
+			expectedName: "dim_customers",
+		},
+		{
+			name:         "alias joins with from",
+			cfg:          &config.Config{},
+			join:         models.DbtMetaLookerJoin{JoinModel: exploreStringPtr("customers"), Alias: exploreStringPtr("buyer")},
+			expectedName: "buyer",
+			expectedFrom: exploreStringPtr("customers"),
+		},
+		{
+			name:       "model outside the selection",
+			cfg:        &config.Config{},
+			join:       models.DbtMetaLookerJoin{JoinModel: exploreStringPtr("ref('suppliers')")},
+			expectSkip: true,
+		},
+		{
+			name:       "missing join model",
+			cfg:        &config.Config{},
+			join:       models.DbtMetaLookerJoin{SQLON: exploreStringPtr("1 = 1")},
+			expectSkip: true,
+		},
+		{
+			name: "invalid join type",
+			cfg:  &config.Config{},
+			join: models.DbtMetaLookerJoin{
+				JoinModel: exploreStringPtr("customers"),
+				Type:      joinTypePtr(enums.LookerJoinType("outer")),
+			},
+			expectSkip: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orders := &models.DbtModel{
+				DbtNode:      models.DbtNode{Name: "orders"},
+				RelationName: "`project.dataset.orders`",
+				Meta: &models.DbtModelMeta{
+					Looker: &models.DbtMetaLooker{Joins: []models.DbtMetaLookerJoin{tt.join}},
+				},
+			}
+
+			generator := NewExploreGenerator(tt.cfg)
+			generator.diagnostics = NewDiagnostics(tt.cfg.Logger())
+			generator.setJoinTargets([]*models.DbtModel{orders, customers})
+
+			explore, err := generator.GenerateExplore(orders)
+			require.NoError(t, err)
+
+			if tt.expectSkip {
+				assert.Empty(t, explore.Joins)
+				warnings := generator.diagnostics.Warnings()
+				require.Len(t, warnings, 1)
+				assert.Equal(t, WarningJoin, warnings[0].Category)
+				return
+			}
+
+			require.Len(t, explore.Joins, 1)
+			assert.Equal(t, tt.expectedName, explore.Joins[0].Name)
+			assert.Equal(t, tt.expectedFrom, explore.Joins[0].From)
+		})
+	}
+}
+
+func TestExploreGenerator_JoinParameters(t *testing.T) {
+	generator := NewExploreGenerator(&config.Config{})
+
+	model := &models.DbtModel{
+		DbtNode:      models.DbtNode{Name: "orders"},
+		RelationName: "`project.dataset.orders`",
+		Meta: &models.DbtModelMeta{
+			Looker: &models.DbtMetaLooker{
+				Joins: []models.DbtMetaLookerJoin{
+					{
+						JoinModel:     exploreStringPtr("customers"),
+						SQLON:         exploreStringPtr("${orders.customer_id} = ${customers.id}"),
+						Type:          joinTypePtr(enums.JoinInner),
+						Relationship:  relationshipPtr(enums.RelationshipManyToOne),
+						ViewLabel:     exploreStringPtr("Customer"),
+						Fields:        []string{"customers.name"},
+						RequiredJoins: []string{"accounts"},
+					},
+				},
+			},
+		},
+	}
+
+	explore, err := generator.GenerateExplore(model)
+	require.NoError(t, err)
+	require.Len(t, explore.Joins, 1)
+
+	join := explore.Joins[0]
+	assert.Equal(t, "customers", join.Name)
+	assert.Nil(t, join.SQL)
+	require.NotNil(t, join.SQLOn)
+	assert.Equal(t, "${orders.customer_id} = ${customers.id}", *join.SQLOn)
+	require.NotNil(t, join.ViewLabel)
+	assert.Equal(t, "Customer", *join.ViewLabel)
+	assert.Equal(t, []string{"customers.name"}, join.Fields)
+	assert.Equal(t, []string{"accounts"}, join.RequiredJoins)
+}
+
+func TestExploreGenerator_ExploreMeta(t *testing.T) {
+	generator := NewExploreGenerator(&config.Config{})
+
+	model := &models.DbtModel{
+		DbtNode:      models.DbtNode{Name: "orders"},
+		RelationName: "`project.dataset.orders`",
+		Description:  "Model description",
+		Columns: map[string]models.DbtModelColumn{
+			"lines": {Name: "lines", DataType: utils.StringPtr("ARRAY<STRUCT<sku STRING>>")},
+		},
+		Meta: &models.DbtModelMeta{
+			Looker: &models.DbtMetaLooker{
+				View: &models.DbtMetaLookerBase{Label: exploreStringPtr("View Label")},
+				Explore: &models.DbtMetaLookerExplore{
+					DbtMetaLookerBase: models.DbtMetaLookerBase{
+						Label:       exploreStringPtr("Sales Orders"),
+						Description: exploreStringPtr("Orders for sales reporting"),
+						Hidden:      exploreBoolPtr(false),
+					},
+					GroupLabel:   exploreStringPtr("Sales"),
+					AlwaysFilter: map[string]string{"status": "-cancelled", "customers.country": "SE"},
+					ConditionallyFilter: &models.DbtMetaLookerConditionalFilter{
+						Filters: map[string]string{"created_date": "7 days"},
+						Unless:  []string{"id"},
+					},
+					AccessFilter: map[string]string{"region": "region", "company": "customers.company_id"},
+					Fields:       []string{"ALL_FIELDS*", "-margin", "detail*", "customers.name"},
+					PersistWith:  exploreStringPtr("daily"),
+					Tags:         []string{"sales"},
+					AlwaysJoin:   []string{"lines", "customers"},
+				},
+			},
+		},
+	}
+
+	explore, err := generator.GenerateExplore(model)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Sales Orders", *explore.Label)
+	assert.Equal(t, "Orders for sales reporting", *explore.Description)
+	require.NotNil(t, explore.Hidden)
+	assert.False(t, *explore.Hidden)
+	assert.Equal(t, "Sales", *explore.GroupLabel)
+	assert.Equal(t, []models.LookMLFilter{
+		{Field: "customers.country", Expression: "SE"},
+		{Field: "orders.status", Expression: "-cancelled"},
+	}, explore.AlwaysFilter)
+	require.NotNil(t, explore.ConditionallyFilter)
+	assert.Equal(t, []models.LookMLFilter{{Field: "orders.created_date", Expression: "7 days"}}, explore.ConditionallyFilter.Filters)
+	assert.Equal(t, []string{"orders.id"}, explore.ConditionallyFilter.Unless)
+	assert.Equal(t, []models.LookMLAccessFilter{
+		{Field: "customers.company_id", UserAttribute: "company"},
+		{Field: "orders.region", UserAttribute: "region"},
+	}, explore.AccessFilters)
+	assert.Equal(t, []string{"ALL_FIELDS*", "-orders.margin", "orders.detail*", "customers.name"}, explore.Fields)
+	assert.Equal(t, []string{"orders__lines", "customers"}, explore.AlwaysJoin)
+	assert.Equal(t, "daily", *explore.PersistWith)
+	assert.Equal(t, []string{"sales"}, explore.Tags)
+}
+
+func TestExploreGenerator_HiddenPrecedence(t *testing.T) {
+	generator := NewExploreGenerator(&config.Config{})
+
+	tests := []struct {
+		name        string
+		viewHidden  *bool
+		metaHidden  *bool
+		expectedNil bool
+		expected    bool
+	}{
+		{name: "no meta", expectedNil: true},
+		{name: "hidden view hides explore", viewHidden: exploreBoolPtr(true), expected: true},
+		{name: "visible view does not un-hide explore", viewHidden: exploreBoolPtr(false), expectedNil: true},
+		{name: "explore meta un-hides explore", viewHidden: exploreBoolPtr(true), metaHidden: exploreBoolPtr(false), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := &models.DbtModel{
+				DbtNode:      models.DbtNode{Name: "orders"},
+				RelationName: "`project.dataset.orders`",
+				Meta: &models.DbtModelMeta{
+					Looker: &models.DbtMetaLooker{
+						View:    &models.DbtMetaLookerBase{Hidden: tt.viewHidden},
+						Explore: &models.DbtMetaLookerExplore{DbtMetaLookerBase: models.DbtMetaLookerBase{Hidden: tt.metaHidden}},
+					},
+				},
+			}
+
+			explore, err := generator.GenerateExplore(model)
+			require.NoError(t, err)
+
+			if tt.expectedNil {
+				assert.Nil(t, explore.Hidden)
+				return
+			}
+			require.NotNil(t, explore.Hidden)
+			assert.Equal(t, tt.expected, *explore.Hidden)
+		})
+	}
+}
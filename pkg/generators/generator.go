@@ -31,12 +31,15 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
+	"text/template"
 
 	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
 	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
-	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
 )
 
 const (
@@ -45,6 +48,10 @@ const (
 
 	// filePermissions defines the file permissions for generated LookML files
 	filePermissions = 0644
+
+	// viewsFolder and exploresFolder hold the files of the split layout
+	viewsFolder    = "views"
+	exploresFolder = "explores"
 )
 
 // Compile-time check to ensure LookMLGenerator implements LookMLGeneratorInterface
@@ -53,21 +60,49 @@ var _ LookMLGeneratorInterface = (*LookMLGenerator)(nil)
 // LookMLGenerator is the main generator that coordinates all LookML generation
 type LookMLGenerator struct {
 	config             *config.Config
+	naming             NamingStrategy
 	dimensionGenerator *DimensionGenerator
 	viewGenerator      *ViewGenerator
 	exploreGenerator   *ExploreGenerator
 	measureGenerator   *MeasureGenerator
+	diagnostics        *Diagnostics
+	mergeState         *mergeState
+	modelFiles         map[string]*modelFileGroup
+	joinFields         map[string]map[string]bool // dbt model name -> fields of its generated view
+	localization       *localizationStrings       // nil unless localization is enabled
+	outputPath         *template.Template         // Parsed output_path, nil until first used
 }
 
 // NewLookMLGenerator creates a new LookMLGenerator instance
 func NewLookMLGenerator(cfg *config.Config) *LookMLGenerator {
-	return &LookMLGenerator{
+	diagnostics := NewDiagnostics(cfg.Logger())
+
+	exploreGenerator := NewExploreGenerator(cfg)
+	exploreGenerator.diagnostics = diagnostics
+
+	generator := &LookMLGenerator{
 		config:             cfg,
+		naming:             NewNamingStrategy(cfg),
 		dimensionGenerator: NewDimensionGenerator(cfg),
 		viewGenerator:      NewViewGenerator(cfg),
-		exploreGenerator:   NewExploreGenerator(cfg),
+		exploreGenerator:   exploreGenerator,
 		measureGenerator:   NewMeasureGenerator(cfg),
+		diagnostics:        diagnostics,
 	}
+	if cfg.Localization.Enabled {
+		generator.localization = newLocalizationStrings(cfg)
+	}
+	return generator
+}
+
+// SetNamingStrategy replaces the naming strategy of the generator and the generators it
+// coordinates, e.g. with a custom NamingStrategy implementation
+func (g *LookMLGenerator) SetNamingStrategy(naming NamingStrategy) {
+	g.naming = naming
+	g.dimensionGenerator.SetNamingStrategy(naming)
+	g.viewGenerator.SetNamingStrategy(naming)
+	g.exploreGenerator.SetNamingStrategy(naming)
+	g.measureGenerator.SetNamingStrategy(naming)
 }
 
 // GenerateAll generates all LookML files for the given models.
@@ -79,12 +114,17 @@ func (g *LookMLGenerator) GenerateAll(models []*models.DbtModel) (int, error) {
 
 // GenerateAllWithOptions generates all LookML files with configurable error handling.
 // This is the recommended method for new code as it provides better error control.
-func (g *LookMLGenerator) GenerateAllWithOptions(ctx context.Context, models []*models.DbtModel, opts GenerationOptions) (*GenerationResult, error) {
-	result := &GenerationResult{
+func (g *LookMLGenerator) GenerateAllWithOptions(ctx context.Context, models []*models.DbtModel, opts GenerationOptions) (result *GenerationResult, err error) {
+	result = &GenerationResult{
 		FilesGenerated:  0,
 		Errors:          []ModelError{},
 		ModelsProcessed: 0,
 	}
+	defer func() {
+		result.Warnings = g.diagnostics.Warnings()
+		result.GovernedFields = g.diagnostics.GovernedFields()
+		result.MissingTranslations = g.localization.missingTranslations()
+	}()
 
 	if len(models) == 0 {
 		return result, fmt.Errorf("no models provided for generation")
@@ -95,6 +135,36 @@ func (g *LookMLGenerator) GenerateAllWithOptions(ctx context.Context, models []*
 		return result, fmt.Errorf("failed to create output directory: %w", err)
 	}
 
+	// Explores may only join models that are generated in this run
+	g.exploreGenerator.setJoinTargets(models)
+
+	// handleModelError records a failed model and applies the error strategy; it returns
+	// an error when generation has to stop
+	handleModelError := func(modelName string, err error) error {
+		result.Errors = append(result.Errors, ModelError{
+			ModelName: modelName,
+			Error:     err,
+		})
+
+		switch opts.ErrorStrategy {
+		case FailFast:
+			return fmt.Errorf("generation failed on model %s: %w", modelName, err)
+
+		case FailAtEnd:
+			g.config.Logger().Warn().Str("model", modelName).Err(err).Msg("Failed to generate model")
+			// Check if we've hit the error limit
+			if opts.MaxErrors > 0 && len(result.Errors) >= opts.MaxErrors {
+				return fmt.Errorf("too many errors (%d), stopping generation", len(result.Errors))
+			}
+
+		case ContinueOnError:
+			g.config.Logger().Warn().Str("model", modelName).Err(err).Msg("Failed to generate model (continuing)")
+		}
+		return nil
+	}
+
+	// 1. Plan the files of every model
+	var planned []plannedModel
 	for _, model := range models {
 		result.ModelsProcessed++
 
@@ -108,36 +178,37 @@ func (g *LookMLGenerator) GenerateAllWithOptions(ctx context.Context, models []*
 
 		g.config.Logger().Debug().Str("model", model.Name).Msg("Generating LookML for model")
 
-		// Generate main view file
-		if err := g.generateViewFile(model); err != nil {
-			modelErr := ModelError{
-				ModelName: model.Name,
-				Error:     err,
+		files, err := g.planModelFiles(model)
+		if err != nil {
+			if stopErr := handleModelError(model.Name, err); stopErr != nil {
+				return result, stopErr
 			}
-			result.Errors = append(result.Errors, modelErr)
-
-			// Apply error strategy
-			switch opts.ErrorStrategy {
-			case FailFast:
-				return result, fmt.Errorf("generation failed on model %s: %w", model.Name, err)
-
-			case FailAtEnd:
-				g.config.Logger().Warn().Str("model", model.Name).Err(err).Msg("Failed to generate model")
-				// Check if we've hit the error limit
-				if opts.MaxErrors > 0 && len(result.Errors) >= opts.MaxErrors {
-					return result, fmt.Errorf("too many errors (%d), stopping generation", len(result.Errors))
-				}
-				continue
+			continue
+		}
+		planned = append(planned, plannedModel{model: model, files: files})
+	}
 
-			case ContinueOnError:
-				g.config.Logger().Warn().Str("model", model.Name).Err(err).Msg("Failed to generate model (continuing)")
-				continue
+	// 2. Check that no two files share a path
+	if err := checkPathCollisions(planned); err != nil {
+		return result, err
+	}
+
+	// 3. Write the files
+	for i := range planned {
+		if err := g.writePlannedModel(&planned[i]); err != nil {
+			if stopErr := handleModelError(planned[i].model.Name, err); stopErr != nil {
+				return result, g.stopRun(stopErr)
 			}
+			continue
 		}
-
 		result.FilesGenerated++
 	}
 
+	// 4. Write the files that depend on all views
+	if err := g.finishRun(); err != nil {
+		return result, err
+	}
+
 	// Return error only if strategy requires it
 	if opts.ErrorStrategy == FailAtEnd && result.HasErrors() {
 		return result, fmt.Errorf("%d of %d models failed to generate", len(result.Errors), result.ModelsProcessed)
@@ -147,7 +218,7 @@ func (g *LookMLGenerator) GenerateAllWithOptions(ctx context.Context, models []*
 }
 
 // GenerateAllWithContext generates all LookML files for the given models with cancellation support
-func (g *LookMLGenerator) GenerateAllWithContext(ctx context.Context, models []*models.DbtModel) (int, error) {
+func (g *LookMLGenerator) GenerateAllWithContext(ctx context.Context, models []*models.DbtModel) (filesGenerated int, err error) {
 	if len(models) == 0 {
 		return 0, fmt.Errorf("no models provided for generation")
 	}
@@ -157,9 +228,21 @@ func (g *LookMLGenerator) GenerateAllWithContext(ctx context.Context, models []*
 		return 0, fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	var filesGenerated int
+	// Explores may only join models that are generated in this run
+	g.exploreGenerator.setJoinTargets(models)
+
 	var errors []string
+	handleModelError := func(modelName string, err error) error {
+		if !g.config.ContinueOnError {
+			return fmt.Errorf("failed to generate view for model %s: %w", modelName, err)
+		}
+		errorMsg := fmt.Sprintf("failed to generate view for model %s: %v", modelName, err)
+		g.config.Logger().Warn().Str("model", modelName).Err(err).Msg("Failed to generate view")
+		errors = append(errors, errorMsg)
+		return nil
+	}
 
+	var planned []plannedModel
 	for _, model := range models {
 		// Check for cancellation before processing each model
 		select {
@@ -171,21 +254,33 @@ func (g *LookMLGenerator) GenerateAllWithContext(ctx context.Context, models []*
 
 		g.config.Logger().Debug().Str("model", model.Name).Msg("Generating LookML for model")
 
-		// Generate main view file (includes explore and nested views inline)
-		if err := g.generateViewFile(model); err != nil {
-			if g.config.ContinueOnError {
-				errorMsg := fmt.Sprintf("failed to generate view for model %s: %v", model.Name, err)
-				g.config.Logger().Warn().Str("model", model.Name).Err(err).Msg("Failed to generate view")
-				errors = append(errors, errorMsg)
-				continue
-			} else {
-				return filesGenerated, fmt.Errorf("failed to generate view for model %s: %w", model.Name, err)
+		// Plan main view file (includes explore and nested views inline)
+		files, err := g.planModelFiles(model)
+		if err != nil {
+			if stopErr := handleModelError(model.Name, err); stopErr != nil {
+				return filesGenerated, stopErr
 			}
+			continue
+		}
+		planned = append(planned, plannedModel{model: model, files: files})
+	}
+
+	if err := checkPathCollisions(planned); err != nil {
+		return filesGenerated, err
+	}
+
+	for i := range planned {
+		if err := g.writePlannedModel(&planned[i]); err != nil {
+			if stopErr := handleModelError(planned[i].model.Name, err); stopErr != nil {
+				return filesGenerated, g.stopRun(stopErr)
+			}
+			continue
 		}
 		filesGenerated++
+	}
 
-		// Note: Nested views and explores are now generated inline in the main view file
-		// No separate files needed
+	if err := g.finishRun(); err != nil {
+		return filesGenerated, err
 	}
 
 	if len(errors) > 0 {
@@ -195,361 +290,295 @@ func (g *LookMLGenerator) GenerateAllWithContext(ctx context.Context, models []*
 	return filesGenerated, nil
 }
 
-// generateViewFile generates a LookML view file for a model (includes explore and nested views)
-func (g *LookMLGenerator) generateViewFile(model *models.DbtModel) error {
-	var fullContent strings.Builder
-
-	// 1. Generate main view first
-	view, err := g.viewGenerator.GenerateView(model)
-	if err != nil {
-		return fmt.Errorf("failed to generate view: %w", err)
+// writePlannedModel writes the planned files of a model and records them for the model
+// files and the report
+func (g *LookMLGenerator) writePlannedModel(planned *plannedModel) error {
+	for i := range planned.files {
+		if _, err := g.writeOutputFile(&planned.files[i]); err != nil {
+			return err
+		}
 	}
 
-	viewContent, err := g.viewToLookML(view)
-	if err != nil {
-		return fmt.Errorf("failed to convert view to LookML: %w", err)
+	if err := g.recordModelFile(planned.model, planned.files); err != nil {
+		return err
 	}
-	fullContent.WriteString(viewContent)
+	g.recordGovernedFields(planned.model, planned.files)
 
-	// 2. Generate nested views and append them to the same file
-	nestedViewsCount, err := g.generateNestedViewsInline(model, &fullContent)
-	if err != nil {
-		return fmt.Errorf("failed to generate nested views: %w", err)
-	}
+	return nil
+}
 
-	// 3. Generate explore section at the bottom
-	explore, err := g.exploreGenerator.GenerateExplore(model)
-	if err != nil {
-		return fmt.Errorf("failed to generate explore: %w", err)
+// planModelFiles generates the LookML objects for a model and plans the files they are written to
+func (g *LookMLGenerator) planModelFiles(model *models.DbtModel) ([]outputFile, error) {
+	if err := g.reportNestedArrayOverflow(model); err != nil {
+		return nil, err
 	}
-
-	exploreContent, err := g.exploreToLookML(explore)
-	if err != nil {
-		return fmt.Errorf("failed to convert explore to LookML: %w", err)
+	if err := checkArrayUnnests(model); err != nil {
+		return nil, err
 	}
-	fullContent.WriteString(exploreContent)
-
-	g.config.Logger().Debug().Int("count", nestedViewsCount).Str("model", model.Name).Msg("Generated nested views inline")
-
-	// Write to file
-	filename := g.getViewFilename(model)
-	filePath := g.config.GetOutputPath(filename)
-
-	// Create directory if it doesn't exist
-	if err := os.MkdirAll(filepath.Dir(filePath), dirPermissions); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
+	// Models whose database has no connection are left out before anything is written
+	if g.config.ModelFiles {
+		if _, err := g.config.GetConnection(model.Database); err != nil {
+			return nil, err
+		}
 	}
 
-	if err := os.WriteFile(filePath, []byte(fullContent.String()), filePermissions); err != nil {
-		return fmt.Errorf("failed to write view file: %w", err)
+	// 1. Generate main view first
+	view, err := g.viewGenerator.GenerateView(model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate view: %w", err)
 	}
 
-	g.config.Logger().Debug().Str("file", filePath).Msg("Generated view file")
-	return nil
-}
-
-// generateNestedViews generates nested views for ARRAY/STRUCT columns
-func (g *LookMLGenerator) generateNestedViews(model *models.DbtModel) (int, error) {
-	// Create column collections to identify array columns
-	columnCollections := models.NewColumnCollections(model, nil)
-
-	logger := g.config.Logger()
-	logger.Debug().Str("model", model.Name).Int("total_columns", len(model.Columns)).Int("nested_views", len(columnCollections.NestedViewColumns)).Msg("Processing model columns")
+	// 2. Generate nested views, written to the same file
+	nestedViews, err := g.generateNestedViewList(model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate nested views: %w", err)
+	}
 
-	for arrayName := range columnCollections.NestedViewColumns {
-		logger.Debug().Str("array", arrayName).Msg("Found array column for nested view")
+	// 3. Generate explore section at the bottom
+	explore, err := g.exploreGenerator.GenerateExplore(model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate explore: %w", err)
 	}
 
-	var filesGenerated int
+	g.config.Logger().Debug().Int("count", len(nestedViews)).Str("model", model.Name).Str("layout", g.config.Layout).Msg("Generated nested views")
 
-	// Generate a nested view for each array column
-	for arrayName, nestedColumns := range columnCollections.NestedViewColumns {
-		if err := g.generateNestedViewFile(model, arrayName, nestedColumns); err != nil {
-			return filesGenerated, fmt.Errorf("failed to generate nested view for %s: %w", arrayName, err)
+	exploreViews := append([]*models.LookMLView{view}, nestedViews...)
+	for _, exploreView := range exploreViews {
+		if err := validateMeasures(exploreView.Measures); err != nil {
+			return nil, fmt.Errorf("invalid view %s: %w", exploreView.Name, err)
 		}
-		filesGenerated++
 	}
 
-	return filesGenerated, nil
-}
-
-// generateNestedViewFile generates a single nested view file
-func (g *LookMLGenerator) generateNestedViewFile(model *models.DbtModel, arrayName string, nestedColumns map[string]models.DbtModelColumn) error {
-	// Create a nested view name
-	viewName := g.getNestedViewName(model, arrayName)
-
-	// Generate the nested view
-	view := &models.LookMLView{
-		Name:         viewName,
-		SQLTableName: fmt.Sprintf("${%s.SQL_TABLE_NAME}", g.viewGenerator.getViewName(model)),
-		Label:        &viewName,
+	g.checkExploreJoins(model, exploreViews, explore)
+	if err := g.validateExploreFields(model, exploreViews, explore); err != nil {
+		return nil, fmt.Errorf("invalid explore: %w", err)
 	}
 
-	// Generate dimensions for nested columns
-	var dimensions []models.LookMLDimension
-	for _, column := range nestedColumns {
-		dimension, err := g.dimensionGenerator.GenerateDimension(model, &column)
-		if err != nil {
-			return fmt.Errorf("failed to generate dimension for nested column %s: %w", column.Name, err)
-		}
-		if dimension != nil {
-			dimensions = append(dimensions, *dimension)
+	if g.localization != nil {
+		for _, exploreView := range exploreViews {
+			if err := g.localization.localizeView(g.config, exploreView); err != nil {
+				return nil, fmt.Errorf("failed to localize: %w", err)
+			}
 		}
 	}
-	view.Dimensions = dimensions
 
-	// Convert to LookML string
-	lookmlContent, err := g.viewToLookML(view)
+	files, err := g.planLayout(model, view, nestedViews, explore)
 	if err != nil {
-		return fmt.Errorf("failed to convert nested view to LookML: %w", err)
-	}
-
-	// Write to file
-	filename := g.getNestedViewFilename(model, arrayName)
-	filePath := g.config.GetOutputPath(filename)
-
-	// Create directory if it doesn't exist
-	if err := os.MkdirAll(filepath.Dir(filePath), dirPermissions); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
+		return nil, fmt.Errorf("failed to plan output files: %w", err)
 	}
 
-	if err := os.WriteFile(filePath, []byte(lookmlContent), filePermissions); err != nil {
-		return fmt.Errorf("failed to write nested view file: %w", err)
+	if !g.config.Refinements {
+		return files, nil
 	}
 
-	g.config.Logger().Debug().Str("file", filePath).Msg("Generated nested view file")
-	return nil
+	return g.planRefinementLayer(model, files)
 }
 
-// generateExploreFile generates a LookML explore file for a model
-func (g *LookMLGenerator) generateExploreFile(model *models.DbtModel) error {
-	// Generate the explore
-	explore, err := g.exploreGenerator.GenerateExplore(model)
+// planLayout distributes the views and explore of a model over files according to the layout
+func (g *LookMLGenerator) planLayout(model *models.DbtModel, view *models.LookMLView, nestedViews []*models.LookMLView, explore *models.LookMLExplore) ([]outputFile, error) {
+	viewPath, err := g.getViewFilename(model)
 	if err != nil {
-		return fmt.Errorf("failed to generate explore: %w", err)
+		return nil, err
 	}
-
-	// Convert to LookML string
-	lookmlContent, err := g.exploreToLookML(explore)
+	joinIncludes, err := g.joinIncludes(model)
 	if err != nil {
-		return fmt.Errorf("failed to convert explore to LookML: %w", err)
-	}
-
-	// Write to file
-	filename := g.getExploreFilename(model)
-	filePath := g.config.GetOutputPath(filename)
-
-	// Create directory if it doesn't exist
-	if err := os.MkdirAll(filepath.Dir(filePath), dirPermissions); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
+		return nil, err
 	}
 
-	if err := os.WriteFile(filePath, []byte(lookmlContent), filePermissions); err != nil {
-		return fmt.Errorf("failed to write explore file: %w", err)
-	}
-
-	g.config.Logger().Debug().Str("file", filePath).Msg("Generated explore file")
-	return nil
-}
+	switch g.config.Layout {
+	case config.LayoutSplit:
+		// views/ holds the view with its nested views, explores/ the explore
+		explorePath, err := g.getExploreFilename(model)
+		if err != nil {
+			return nil, err
+		}
+		viewFile := outputFile{
+			Model: model.Name,
+			Path:  viewPath,
+			Views: append([]*models.LookMLView{view}, nestedViews...),
+		}
+		exploreFile := outputFile{
+			Model:    model.Name,
+			Path:     explorePath,
+			Includes: append([]string{g.config.GetIncludePath(viewFile.Path)}, joinIncludes...),
+			Explores: []*models.LookMLExplore{explore},
+		}
+		return []outputFile{viewFile, exploreFile}, nil
+
+	case config.LayoutPerView:
+		// One file per view, plus an explore file including all of them
+		files := []outputFile{{
+			Model: model.Name,
+			Path:  viewPath,
+			Views: []*models.LookMLView{view},
+		}}
+		for _, nestedView := range nestedViews {
+			nestedPath, err := g.getNestedViewFilename(model, nestedView.Name)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, outputFile{
+				Model: model.Name,
+				Path:  nestedPath,
+				Views: []*models.LookMLView{nestedView},
+			})
+		}
 
-// shouldGenerateExplore determines if an explore should be generated for a model
-func (g *LookMLGenerator) shouldGenerateExplore(model *models.DbtModel) bool {
-	// Check if model has joins defined in meta
-	if model.Meta != nil && model.Meta.Looker != nil && len(model.Meta.Looker.Joins) > 0 {
-		return true
+		explorePath, err := g.getExploreFilename(model)
+		if err != nil {
+			return nil, err
+		}
+		exploreFile := outputFile{
+			Model:    model.Name,
+			Path:     explorePath,
+			Explores: []*models.LookMLExplore{explore},
+		}
+		for _, file := range files {
+			exploreFile.Includes = append(exploreFile.Includes, g.config.GetIncludePath(file.Path))
+		}
+		exploreFile.Includes = append(exploreFile.Includes, joinIncludes...)
+		return append(files, exploreFile), nil
+
+	default:
+		// Inline: view, nested views and explore in one file
+		return []outputFile{{
+			Model:    model.Name,
+			Path:     viewPath,
+			Views:    append([]*models.LookMLView{view}, nestedViews...),
+			Explores: []*models.LookMLExplore{explore},
+		}}, nil
 	}
-
-	// Default to generating explores for all models (can be configured)
-	return true
 }
 
-// getViewFilename generates the filename for a view file
-func (g *LookMLGenerator) getViewFilename(model *models.DbtModel) string {
-	var name string
+// getOutputLocation returns the directory of the files of a model, applying flatten and
+// remove_schema_string
+func (g *LookMLGenerator) getOutputLocation(model *models.DbtModel) string {
+	// Use directory structure from model path (unless flatten is enabled)
 	var directory string
-
-	if g.config.UseTableName && model.RelationName != "" {
-		// Extract just the table name from relation_name (remove project.dataset prefix and backticks)
-		parts := strings.Split(model.RelationName, ".")
-		tableName := parts[len(parts)-1]
-		tableName = strings.Trim(tableName, "`")
-		name = strings.ToLower(tableName)
-
-		// Fallback to model name if table name is empty (e.g., ephemeral models)
-		if name == "" {
-			name = model.Name
-		}
-
-		// Use directory structure from model path (unless flatten is enabled)
-		if model.Path != "" && !g.config.Flatten {
-			directory = filepath.Dir(model.Path)
-			directory = strings.Trim(directory, "/")
-		}
-	} else {
-		name = model.Name
-		// Use directory structure from model path (unless flatten is enabled)
-		if model.Path != "" && !g.config.Flatten {
-			directory = filepath.Dir(model.Path)
-			directory = strings.Trim(directory, "/")
-		}
+	if model.Path != "" && !g.config.Flatten {
+		directory = strings.Trim(filepath.ToSlash(filepath.Dir(model.Path)), "/.")
 	}
 
 	// Remove schema string if configured
 	if g.config.RemoveSchemaString != "" {
-		name = strings.ReplaceAll(name, g.config.RemoveSchemaString, "")
 		directory = strings.ReplaceAll(directory, g.config.RemoveSchemaString, "")
 	}
 
-	if directory != "" && !g.config.Flatten {
-		return fmt.Sprintf("%s/%s.view.lkml", directory, name)
-	}
-	return fmt.Sprintf("%s.view.lkml", name)
+	return directory
 }
 
-// getExploreFilename generates the filename for an explore file
-func (g *LookMLGenerator) getExploreFilename(model *models.DbtModel) string {
-	var name string
-	var directory string
-
-	if g.config.UseTableName && model.RelationName != "" {
-		// Extract just the table name from relation_name (remove project.dataset prefix and backticks)
-		parts := strings.Split(model.RelationName, ".")
-		tableName := parts[len(parts)-1]
-		tableName = strings.Trim(tableName, "`")
-		name = strings.ToLower(tableName)
-
-		// Fallback to model name if table name is empty (e.g., ephemeral models)
-		if name == "" {
-			name = model.Name
-		}
+// layoutFilename builds an output path inside the layout's folder for the file kind
+func (g *LookMLGenerator) layoutFilename(kindFolder, directory, name, extension string) string {
+	var parts []string
+	if g.config.Layout == config.LayoutSplit {
+		parts = append(parts, kindFolder)
+	}
+	if directory != "" {
+		parts = append(parts, directory)
+	}
+	parts = append(parts, name+extension)
+	return strings.Join(parts, "/")
+}
 
-		// Use directory structure from model path (unless flatten is enabled)
-		if model.Path != "" && !g.config.Flatten {
-			directory = filepath.Dir(model.Path)
-			directory = strings.Trim(directory, "/")
-		}
-	} else {
-		name = model.Name
-		// Use directory structure from model path (unless flatten is enabled)
-		if model.Path != "" && !g.config.Flatten {
-			directory = filepath.Dir(model.Path)
-			directory = strings.Trim(directory, "/")
+// modelFilename builds the output path of a file of a model, from the output_path template
+// when configured and from the model's location otherwise
+func (g *LookMLGenerator) modelFilename(model *models.DbtModel, kind, kindFolder, name, extension string) (string, error) {
+	if g.config.OutputPath != "" {
+		rendered, err := g.renderOutputPath(model, kind, name)
+		if err != nil {
+			return "", err
 		}
+		return g.layoutFilename(kindFolder, path.Dir(rendered), path.Base(rendered), extension), nil
 	}
 
 	// Remove schema string if configured
 	if g.config.RemoveSchemaString != "" {
 		name = strings.ReplaceAll(name, g.config.RemoveSchemaString, "")
-		directory = strings.ReplaceAll(directory, g.config.RemoveSchemaString, "")
-	}
-
-	// Return the explore filename
-	if directory != "" && !g.config.Flatten {
-		return fmt.Sprintf("%s/%s.explore.lkml", directory, name)
 	}
-	return fmt.Sprintf("%s.explore.lkml", name)
+	return g.layoutFilename(kindFolder, g.getOutputLocation(model), name, extension), nil
 }
 
-// getNestedViewName generates the view name for a nested view
-func (g *LookMLGenerator) getNestedViewName(model *models.DbtModel, arrayName string) string {
-	var baseName string
-
-	if g.config.UseTableName {
-		// Extract just the table name from relation_name
-		parts := strings.Split(model.RelationName, ".")
-		tableName := parts[len(parts)-1]
-		tableName = strings.Trim(tableName, "`")
-		baseName = strings.ToLower(tableName)
-	} else {
-		baseName = model.Name
-	}
-
-	// Create nested view name by converting to proper LookML naming
-	// This handles both PascalCase conversion and dot replacement
-	nestedSuffix := utils.ToLookMLName(arrayName)
-	return fmt.Sprintf("%s__%s", baseName, nestedSuffix)
+// getViewFilename generates the filename for a view file, named like the view
+func (g *LookMLGenerator) getViewFilename(model *models.DbtModel) (string, error) {
+	return g.modelFilename(model, outputKindView, viewsFolder, g.naming.ViewName(model), ".view.lkml")
 }
 
-// getNestedViewNameWithOriginal generates the view name using the original PascalCase name
-func (g *LookMLGenerator) getNestedViewNameWithOriginal(model *models.DbtModel, originalArrayName string) string {
-	var baseName string
-
-	if g.config.UseTableName {
-		// Extract just the table name from relation_name
-		parts := strings.Split(model.RelationName, ".")
-		tableName := parts[len(parts)-1]
-		tableName = strings.Trim(tableName, "`")
-		baseName = strings.ToLower(tableName)
-	} else {
-		baseName = model.Name
-	}
+// getExploreFilename generates the filename for an explore file
+func (g *LookMLGenerator) getExploreFilename(model *models.DbtModel) (string, error) {
+	return g.modelFilename(model, outputKindExplore, exploresFolder, g.naming.ViewName(model), ".explore.lkml")
+}
 
-	// Create nested view name by converting PascalCase to snake_case with double underscores
-	// e.g., "SupplierInformation" -> "supplier_information"
-	// e.g., "Markings.Marking" -> "markings__marking"
-	nestedSuffix := utils.ToLookMLName(originalArrayName)
-	return fmt.Sprintf("%s__%s", baseName, nestedSuffix)
+// getNestedViewFilename generates the filename for a nested view file, next to its parent view
+func (g *LookMLGenerator) getNestedViewFilename(model *models.DbtModel, viewName string) (string, error) {
+	return g.modelFilename(model, outputKindNestedView, viewsFolder, viewName, ".view.lkml")
 }
 
-// getNestedViewFilename generates the filename for a nested view file
-func (g *LookMLGenerator) getNestedViewFilename(model *models.DbtModel, arrayName string) string {
-	var directory string
+// viewToLookML converts a LookMLView to LookML string format
+func (g *LookMLGenerator) viewToLookML(view *models.LookMLView) (string, error) {
+	// This is a simplified implementation
+	// A full implementation would use a proper LookML serializer
+	var builder strings.Builder
 
-	// Use directory structure from model path
-	if model.Path != "" {
-		directory = filepath.Dir(model.Path)
-		directory = strings.Trim(directory, "/")
+	builder.WriteString(fmt.Sprintf("view: %s {\n", view.Name))
+	for _, param := range g.viewParamBlocks(view) {
+		builder.WriteString(param.Text)
 	}
 
-	// Remove schema string if configured
-	if g.config.RemoveSchemaString != "" {
-		directory = strings.ReplaceAll(directory, g.config.RemoveSchemaString, "")
+	extraToLookML(&builder, view.Extra, "  ")
+
+	// Add dimensions, dimension groups and measures
+	for _, field := range g.viewFieldBlocks(view) {
+		builder.WriteString(field.Text)
 	}
 
-	// Generate nested view name
-	viewName := g.getNestedViewName(model, arrayName)
+	builder.WriteString("}\n")
 
-	if directory != "" {
-		return fmt.Sprintf("%s/%s.view.lkml", directory, viewName)
-	}
-	return fmt.Sprintf("%s.view.lkml", viewName)
+	return builder.String(), nil
 }
 
-// viewToLookML converts a LookMLView to LookML string format
-func (g *LookMLGenerator) viewToLookML(view *models.LookMLView) (string, error) {
-	// This is a simplified implementation
-	// A full implementation would use a proper LookML serializer
-	var builder strings.Builder
+// viewParamBlocks renders the view parameters the generator derives from the model, in
+// output order. The merge mode updates them like fields.
+func (g *LookMLGenerator) viewParamBlocks(view *models.LookMLView) []renderedBlock {
+	var blocks []renderedBlock
 
-	builder.WriteString(fmt.Sprintf("view: %s {\n", view.Name))
-	builder.WriteString(fmt.Sprintf("  sql_table_name: %s ;;\n", view.SQLTableName))
+	// Nested views and refinements have no table of their own
+	if view.SQLTableName != "" {
+		blocks = append(blocks, renderedBlock{Kind: viewParamKind, Name: "sql_table_name", Text: fmt.Sprintf("  sql_table_name: %s ;;\n", view.SQLTableName)})
+	}
 
 	if view.Label != nil {
-		builder.WriteString(fmt.Sprintf("  label: \"%s\"\n", *view.Label))
+		blocks = append(blocks, renderedBlock{Kind: viewParamKind, Name: "label", Text: fmt.Sprintf("  label: %s\n", quoteString(*view.Label))})
 	}
 
 	if view.Description != nil {
-		builder.WriteString(fmt.Sprintf("  description: \"%s\"\n", *view.Description))
+		blocks = append(blocks, renderedBlock{Kind: viewParamKind, Name: "description", Text: fmt.Sprintf("  description: %s\n", quoteString(*view.Description))})
 	}
 
-	// Add dimensions
+	return blocks
+}
+
+// viewFieldBlocks renders the fields of a view in output order.
+// The merge mode uses the same blocks to update existing files field by field.
+func (g *LookMLGenerator) viewFieldBlocks(view *models.LookMLView) []renderedBlock {
+	blocks := make([]renderedBlock, 0, len(view.Dimensions)+len(view.DimensionGroups)+len(view.Measures))
+
 	for _, dimension := range view.Dimensions {
-		builder.WriteString(g.dimensionToLookML(&dimension))
+		blocks = append(blocks, renderedBlock{Kind: "dimension", Name: dimension.Name, Text: g.dimensionToLookML(&dimension)})
 	}
 
-	// Add dimension groups
 	for _, dimensionGroup := range view.DimensionGroups {
-		builder.WriteString(g.dimensionGroupToLookML(&dimensionGroup))
+		blocks = append(blocks, renderedBlock{Kind: "dimension_group", Name: dimensionGroup.Name, Text: g.dimensionGroupToLookML(&dimensionGroup)})
 	}
 
-	// Add measures
 	for _, measure := range view.Measures {
-		builder.WriteString(g.measureToLookML(&measure))
+		blocks = append(blocks, renderedBlock{Kind: "measure", Name: measure.Name, Text: g.measureToLookML(&measure)})
 	}
 
-	builder.WriteString("}\n")
+	for _, set := range view.Sets {
+		blocks = append(blocks, renderedBlock{Kind: "set", Name: set.Name, Text: g.setToLookML(&set)})
+	}
 
-	return builder.String(), nil
+	return blocks
 }
 
 // lookmlJoinToLookML converts a LookML join to LookML string
@@ -558,14 +587,34 @@ func (g *LookMLGenerator) lookmlJoinToLookML(join *models.LookMLJoin) string {
 
 	builder.WriteString(fmt.Sprintf("  join: %s {\n", join.Name))
 
+	if join.From != nil {
+		builder.WriteString(fmt.Sprintf("    from: %s\n", *join.From))
+	}
+
 	if join.ViewLabel != nil {
-		builder.WriteString(fmt.Sprintf("    view_label: \"%s\"\n", *join.ViewLabel))
+		builder.WriteString(fmt.Sprintf("    view_label: %s\n", quoteString(*join.ViewLabel)))
+	}
+
+	if join.Type != nil {
+		builder.WriteString(fmt.Sprintf("    type: %s\n", string(*join.Type)))
+	}
+
+	if len(join.Fields) > 0 {
+		builder.WriteString(fmt.Sprintf("    fields: [%s]\n", strings.Join(join.Fields, ", ")))
+	}
+
+	if len(join.RequiredJoins) > 0 {
+		builder.WriteString(fmt.Sprintf("    required_joins: [%s]\n", strings.Join(join.RequiredJoins, ", ")))
 	}
 
 	if join.SQL != nil {
 		builder.WriteString(fmt.Sprintf("    sql: %s ;;\n", *join.SQL))
 	}
 
+	if join.SQLOn != nil {
+		builder.WriteString(fmt.Sprintf("    sql_on: %s ;;\n", *join.SQLOn))
+	}
+
 	if join.Relationship != nil {
 		builder.WriteString(fmt.Sprintf("    relationship: %s\n", string(*join.Relationship)))
 	}
@@ -581,7 +630,69 @@ func (g *LookMLGenerator) exploreToLookML(explore *models.LookMLExplore) (string
 
 	builder.WriteString("\n# Un-hide and use this explore, or copy the joins into another explore, to get all the fully nested relationships from this view\n")
 	builder.WriteString(fmt.Sprintf("explore: %s {\n", explore.Name))
-	builder.WriteString("  hidden: yes\n")
+
+	if explore.Label != nil {
+		builder.WriteString(fmt.Sprintf("  label: %s\n", quoteString(*explore.Label)))
+	}
+
+	if explore.GroupLabel != nil {
+		builder.WriteString(fmt.Sprintf("  group_label: %s\n", quoteString(*explore.GroupLabel)))
+	}
+
+	if explore.Description != nil {
+		builder.WriteString(fmt.Sprintf("  description: %s\n", quoteString(*explore.Description)))
+	}
+
+	// Explores stay hidden unless meta.looker.explore un-hides them
+	if explore.Hidden != nil && !*explore.Hidden {
+		builder.WriteString("  hidden: no\n")
+	} else {
+		builder.WriteString("  hidden: yes\n")
+	}
+
+	if len(explore.Tags) > 0 {
+		builder.WriteString(fmt.Sprintf("  tags: [%s]\n", quoteList(explore.Tags)))
+	}
+
+	if explore.PersistWith != nil {
+		builder.WriteString(fmt.Sprintf("  persist_with: %s\n", *explore.PersistWith))
+	}
+
+	if len(explore.Fields) > 0 {
+		builder.WriteString(fmt.Sprintf("  fields: [%s]\n", strings.Join(explore.Fields, ", ")))
+	}
+
+	if len(explore.AlwaysJoin) > 0 {
+		builder.WriteString(fmt.Sprintf("  always_join: [%s]\n", strings.Join(explore.AlwaysJoin, ", ")))
+	}
+
+	if explore.SQLAlwaysWhere != nil {
+		builder.WriteString(fmt.Sprintf("  sql_always_where: %s ;;\n", *explore.SQLAlwaysWhere))
+	}
+
+	if len(explore.AlwaysFilter) > 0 {
+		builder.WriteString("  always_filter: {\n")
+		builder.WriteString(fmt.Sprintf("    filters: [%s]\n", filtersToLookML(explore.AlwaysFilter)))
+		builder.WriteString("  }\n")
+	}
+
+	if explore.ConditionallyFilter != nil {
+		builder.WriteString("  conditionally_filter: {\n")
+		builder.WriteString(fmt.Sprintf("    filters: [%s]\n", filtersToLookML(explore.ConditionallyFilter.Filters)))
+		if len(explore.ConditionallyFilter.Unless) > 0 {
+			builder.WriteString(fmt.Sprintf("    unless: [%s]\n", strings.Join(explore.ConditionallyFilter.Unless, ", ")))
+		}
+		builder.WriteString("  }\n")
+	}
+
+	for _, accessFilter := range explore.AccessFilters {
+		builder.WriteString("  access_filter: {\n")
+		builder.WriteString(fmt.Sprintf("    field: %s\n", accessFilter.Field))
+		builder.WriteString(fmt.Sprintf("    user_attribute: %s\n", accessFilter.UserAttribute))
+		builder.WriteString("  }\n")
+	}
+
+	extraToLookML(&builder, explore.Extra, "  ")
 
 	// Add joins
 	for _, join := range explore.Joins {
@@ -593,72 +704,124 @@ func (g *LookMLGenerator) exploreToLookML(explore *models.LookMLExplore) (string
 	return builder.String(), nil
 }
 
+// filtersToLookML renders filters as `view.field: "expression"` pairs
+func filtersToLookML(filters []models.LookMLFilter) string {
+	parts := make([]string, 0, len(filters))
+	for _, filter := range filters {
+		parts = append(parts, fmt.Sprintf("%s: \"%s\"", filter.Field, filter.Expression))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// quotedStringEscaper escapes the characters that would end a quoted LookML string
+var quotedStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// quoteString renders a string as a quoted LookML value
+func quoteString(value string) string {
+	return `"` + quotedStringEscaper.Replace(value) + `"`
+}
+
+// quoteList renders strings as a comma-separated list of quoted values
+func quoteList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, quoteString(value))
+	}
+	return strings.Join(quoted, ", ")
+}
+
 // dimensionToLookML converts a dimension to LookML string
 func (g *LookMLGenerator) dimensionToLookML(dimension *models.LookMLDimension) string {
 	var builder strings.Builder
 	builder.WriteString(fmt.Sprintf("  dimension: %s {\n", dimension.Name))
+	if dimension.PrimaryKey != nil && *dimension.PrimaryKey {
+		builder.WriteString("    primary_key: yes\n")
+	}
 	builder.WriteString(fmt.Sprintf("    type: %s\n", dimension.Type))
-	builder.WriteString(fmt.Sprintf("    sql: %s ;;\n", dimension.SQL))
+	if dimension.SQL != "" {
+		builder.WriteString(fmt.Sprintf("    sql: %s ;;\n", dimension.SQL))
+	}
+	if dimension.SQLLatitude != "" {
+		builder.WriteString(fmt.Sprintf("    sql_latitude: %s ;;\n", dimension.SQLLatitude))
+		builder.WriteString(fmt.Sprintf("    sql_longitude: %s ;;\n", dimension.SQLLongitude))
+	}
+	if dimension.MapLayerName != nil {
+		builder.WriteString(fmt.Sprintf("    map_layer_name: %s\n", *dimension.MapLayerName))
+	}
 
 	// Add group_label if present
 	if dimension.GroupLabel != nil {
-		builder.WriteString(fmt.Sprintf("    group_label: \"%s\"\n", *dimension.GroupLabel))
+		builder.WriteString(fmt.Sprintf("    group_label: %s\n", quoteString(*dimension.GroupLabel)))
 	}
 
 	// Add group_item_label if present
 	if dimension.GroupItemLabel != nil {
-		builder.WriteString(fmt.Sprintf("    group_item_label: \"%s\"\n", *dimension.GroupItemLabel))
+		builder.WriteString(fmt.Sprintf("    group_item_label: %s\n", quoteString(*dimension.GroupItemLabel)))
 	}
 
 	if dimension.Label != nil {
-		builder.WriteString(fmt.Sprintf("    label: \"%s\"\n", *dimension.Label))
+		builder.WriteString(fmt.Sprintf("    label: %s\n", quoteString(*dimension.Label)))
 	}
 
 	if dimension.Description != nil {
-		builder.WriteString(fmt.Sprintf("    description: \"%s\"\n", *dimension.Description))
+		builder.WriteString(fmt.Sprintf("    description: %s\n", quoteString(*dimension.Description)))
+	}
+
+	fieldParametersToLookML(&builder, &dimension.LookMLFieldParameters)
+
+	if dimension.OrderByField != nil {
+		builder.WriteString(fmt.Sprintf("    order_by_field: %s\n", *dimension.OrderByField))
+	}
+
+	if dimension.SuggestDimension != nil {
+		builder.WriteString(fmt.Sprintf("    suggest_dimension: %s\n", *dimension.SuggestDimension))
+	}
+
+	if dimension.CaseSensitive != nil {
+		builder.WriteString(fmt.Sprintf("    case_sensitive: %s\n", yesNo(*dimension.CaseSensitive)))
 	}
 
 	if dimension.Hidden != nil && *dimension.Hidden {
 		builder.WriteString("    hidden: yes\n")
 	}
 
+	extraToLookML(&builder, dimension.Extra, "    ")
+
 	builder.WriteString("  }\n\n")
 
 	return builder.String()
 }
 
-// generateNestedViewsInline generates nested views and appends them to the content builder
-func (g *LookMLGenerator) generateNestedViewsInline(model *models.DbtModel, contentBuilder *strings.Builder) (int, error) {
+// generateNestedViewList generates a nested view for each array column, ordered by array name
+func (g *LookMLGenerator) generateNestedViewList(model *models.DbtModel) ([]*models.LookMLView, error) {
 	// Create column collections to identify array columns
-	columnCollections := models.NewColumnCollections(model, nil)
-
-	var viewsGenerated int
+	columnCollections, err := newColumnCollections(g.config, model)
+	if err != nil {
+		return nil, err
+	}
 
-	// Generate a nested view for each array column
+	arrayNames := make([]string, 0, len(columnCollections.NestedViewColumns))
 	for arrayName := range columnCollections.NestedViewColumns {
-		nestedView, err := g.generateSingleNestedView(model, arrayName, columnCollections.NestedViewColumns[arrayName])
-		if err != nil {
-			return viewsGenerated, fmt.Errorf("failed to generate nested view for %s: %w", arrayName, err)
-		}
+		arrayNames = append(arrayNames, arrayName)
+	}
+	sort.Strings(arrayNames)
 
-		// Convert nested view to LookML and append to content
-		nestedViewContent, err := g.viewToLookML(nestedView)
+	var nestedViews []*models.LookMLView
+	for _, arrayName := range arrayNames {
+		nestedView, err := g.generateSingleNestedView(model, arrayName, columnCollections.NestedViewColumns[arrayName], columnCollections.OverflowArrays[arrayName])
 		if err != nil {
-			return viewsGenerated, fmt.Errorf("failed to convert nested view to LookML for %s: %w", arrayName, err)
+			return nil, fmt.Errorf("failed to generate nested view for %s: %w", arrayName, err)
 		}
-
-		contentBuilder.WriteString("\n")
-		contentBuilder.WriteString(nestedViewContent)
-		viewsGenerated++
+		nestedViews = append(nestedViews, nestedView)
 
 		g.config.Logger().Debug().Str("view", nestedView.Name).Msg("Generated inline nested view")
 	}
 
-	return viewsGenerated, nil
+	return nestedViews, nil
 }
 
 // generateSingleNestedView generates a single nested view for an array column
-func (g *LookMLGenerator) generateSingleNestedView(model *models.DbtModel, arrayName string, nestedColumns map[string]models.DbtModelColumn) (*models.LookMLView, error) {
+func (g *LookMLGenerator) generateSingleNestedView(model *models.DbtModel, arrayName string, nestedColumns, overflowColumns map[string]models.DbtModelColumn) (*models.LookMLView, error) {
 	// Find the array column to get its OriginalName for proper view naming
 	var arrayColumn *models.DbtModelColumn
 	for _, col := range nestedColumns {
@@ -668,13 +831,13 @@ func (g *LookMLGenerator) generateSingleNestedView(model *models.DbtModel, array
 		}
 	}
 
-	// Generate nested view name using OriginalName if available
-	var viewName string
+	// Generate nested view name using OriginalName if available, which converts PascalCase
+	// to snake_case: "Markings.Marking" -> "<view>__markings__marking"
+	arrayPath := arrayName
 	if arrayColumn != nil && arrayColumn.OriginalName != nil && *arrayColumn.OriginalName != "" {
-		viewName = g.getNestedViewNameWithOriginal(model, *arrayColumn.OriginalName)
-	} else {
-		viewName = g.getNestedViewName(model, arrayName)
+		arrayPath = *arrayColumn.OriginalName
 	}
+	viewName := g.naming.NestedViewName(model, arrayPath)
 
 	// Create the nested view
 	nestedView := &models.LookMLView{
@@ -684,7 +847,9 @@ func (g *LookMLGenerator) generateSingleNestedView(model *models.DbtModel, array
 
 	// Generate dimensions for nested columns using nested view-specific logic
 	var dimensions []models.LookMLDimension
-	for _, column := range nestedColumns {
+	dimensionColumns := make(map[string]*models.DbtModelColumn, len(nestedColumns))
+	for _, columnName := range sortedColumnNames(nestedColumns) {
+		column := nestedColumns[columnName]
 		// Check if this is the array field itself (hidden self-reference)
 		if column.Name == arrayName {
 			// Determine if we should include the hidden self-reference dimension
@@ -696,6 +861,26 @@ func (g *LookMLGenerator) generateSingleNestedView(model *models.DbtModel, array
 			if !isSingleValueArray && !isTopLevelArray {
 				continue
 			}
+
+			// The elements of single-value arrays are fields of their element type
+			if elementType := arrayElementType(&column); elementType != "" {
+				element := column
+				element.DataType = &elementType
+				if g.dimensionGenerator.shouldBeDimensionGroup(&element) {
+					nestedView.DimensionGroups = append(nestedView.DimensionGroups, g.generateNestedElementDimensionGroup(model, viewName, arrayName, &element))
+					continue
+				}
+
+				dimension, err := g.generateNestedViewDimension(model, viewName, arrayName, &element)
+				if err != nil {
+					return nil, fmt.Errorf("failed to generate dimension for %s: %w", column.Name, err)
+				}
+				// Element values are what the view is about, so they stay visible
+				dimension.Hidden = g.dimensionGenerator.getDimensionHidden(&element)
+				dimensions = append(dimensions, *dimension)
+				dimensionColumns[dimension.Name] = &element
+				continue
+			}
 		}
 
 		dimension, err := g.generateNestedViewDimension(model, viewName, arrayName, &column)
@@ -703,16 +888,122 @@ func (g *LookMLGenerator) generateSingleNestedView(model *models.DbtModel, array
 			return nil, fmt.Errorf("failed to generate dimension for %s: %w", column.Name, err)
 		}
 		if dimension != nil {
+			// Arrays inside the array are only there to be unnested by their own join
+			if column.IsArrayColumn() {
+				hidden := true
+				dimension.Hidden = &hidden
+			}
 			dimensions = append(dimensions, *dimension)
+			dimensionColumns[dimension.Name] = &column
 		}
 	}
 
+	// Arrays nested beyond the depth limit are kept as JSON strings unless dropped
+	flatten, err := flattensOverflowArrays(g.config, model)
+	if err != nil {
+		return nil, err
+	}
+	if flatten {
+		overflowNames := make([]string, 0, len(overflowColumns))
+		for name := range overflowColumns {
+			overflowNames = append(overflowNames, name)
+		}
+		sort.Strings(overflowNames)
+		for _, name := range overflowNames {
+			column := overflowColumns[name]
+			dimensions = append(dimensions, overflowDimension(
+				g.generateNestedViewDimensionName(model, arrayName, &column),
+				g.generateNestedViewSQL(viewName, arrayName, &column),
+				&column,
+			))
+		}
+	}
+
+	// Element positions of single-value arrays unnested WITH OFFSET
+	if unnestsWithOffset(g.config, model, arrayColumn) {
+		description := "Position of the element in the array, starting at 0"
+		dimensions = append(dimensions, models.LookMLDimension{
+			Name:        "offset",
+			Type:        string(enums.DataTypeNumber),
+			SQL:         nestedOffsetAlias(viewName),
+			Description: &description,
+		})
+	}
+
+	// A compound primary key keeps measures on the nested view correct across the fanout
+	if primaryKey := g.generateNestedPrimaryKey(model, viewName, arrayName); primaryKey != nil {
+		dimensions = append([]models.LookMLDimension{*primaryKey}, dimensions...)
+	}
+	nestedView.Measures = append(nestedView.Measures, models.LookMLMeasure{
+		Name: "count",
+		Type: enums.MeasureCount,
+	})
+
 	// Assign dimensions to the nested view
 	nestedView.Dimensions = dimensions
 
+	// Nested views get a detail set of their own
+	if err := applyPIIMasking(g.config, nestedView); err != nil {
+		return nil, err
+	}
+
+	applyDetailSet(nestedView, buildDetailSet(g.config, nestedView.Dimensions, dimensionColumns))
+
+	if err := validateViewParameters(nestedView); err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+
 	return nestedView, nil
 }
 
+// generateNestedPrimaryKey generates the hidden compound primary key of a nested view: the
+// primary key of the view the array is in, followed by the offset of the element. Returns
+// nil when the model has no primary key to build it from.
+func (g *LookMLGenerator) generateNestedPrimaryKey(model *models.DbtModel, viewName, arrayName string) *models.LookMLDimension {
+	keyColumns := primaryKeyColumns(model)
+	if len(keyColumns) == 0 {
+		return nil
+	}
+
+	var parts []string
+	if parentArray := parentArrayName(model, arrayName); parentArray != "" {
+		// Arrays in arrays build on the compound key of the enclosing nested view
+		parentView := nestedArrayViewName(g.naming, model, parentArray)
+		parts = append(parts, fmt.Sprintf("${%s.%s}", parentView, nestedPrimaryKeyName(parentView)))
+	} else {
+		mainViewName := g.naming.ViewName(model)
+		for _, column := range keyColumns {
+			parts = append(parts, fmt.Sprintf("CAST(${%s.%s} AS STRING)", mainViewName, g.dimensionGenerator.getDimensionNameForMainView(model, &column)))
+		}
+	}
+	parts = append(parts, fmt.Sprintf("CAST(%s AS STRING)", nestedOffsetAlias(viewName)))
+
+	primaryKey := true
+	hidden := true
+	return &models.LookMLDimension{
+		Name:       nestedPrimaryKeyName(viewName),
+		Type:       string(enums.DataTypeString),
+		SQL:        fmt.Sprintf("CONCAT(%s)", strings.Join(parts, ", '-', ")),
+		PrimaryKey: &primaryKey,
+		Hidden:     &hidden,
+	}
+}
+
+// generateNestedElementDimensionGroup generates the dimension group of the DATE, DATETIME or
+// TIMESTAMP elements of a single-value array
+func (g *LookMLGenerator) generateNestedElementDimensionGroup(model *models.DbtModel, viewName, arrayName string, element *models.DbtModelColumn) models.LookMLDimensionGroup {
+	return models.LookMLDimensionGroup{
+		Name:        g.generateNestedViewDimensionName(model, arrayName, element),
+		Type:        dimGroupTypeTime,
+		SQL:         g.generateNestedViewSQL(viewName, arrayName, element),
+		Description: g.dimensionGenerator.getDimensionDescription(element),
+		Hidden:      g.dimensionGenerator.getDimensionHidden(element),
+		Timeframes:  g.dimensionGenerator.getDimensionGroupTimeframes(element),
+		ConvertTZ:   g.dimensionGenerator.getDimensionGroupConvertTZ(element),
+		Datatype:    g.dimensionGenerator.getDimensionGroupDatatype(element),
+	}
+}
+
 // isSingleValueArray checks if a column is a single-value array (ARRAY<primitive>, not ARRAY<STRUCT>)
 func (g *LookMLGenerator) isSingleValueArray(column *models.DbtModelColumn) bool {
 	if column.DataType == nil {
@@ -738,10 +1029,15 @@ func (g *LookMLGenerator) generateNestedViewDimension(model *models.DbtModel, vi
 		dimension.Description = description
 	}
 
+	g.dimensionGenerator.applyDataTypeSettings(dimension, column)
+	g.dimensionGenerator.applyDimensionParameters(dimension, column)
+
 	// Override hidden property for array fields
 	if column.Name == arrayName {
 		hidden := true
 		dimension.Hidden = &hidden
+	} else {
+		dimension.Label = g.dimensionGenerator.getNestedViewDimensionLabel(column)
 	}
 
 	return dimension, nil
@@ -761,43 +1057,11 @@ func (g *LookMLGenerator) generateNestedViewDimensionName(model *models.DbtModel
 			arrayOriginalName = arrayName
 		}
 
-		// Generate the full nested view name (same as the view name)
-		var baseName string
-		if g.config.UseTableName {
-			parts := strings.Split(model.RelationName, ".")
-			tableName := parts[len(parts)-1]
-			tableName = strings.Trim(tableName, "`")
-			baseName = strings.ToLower(tableName)
-		} else {
-			baseName = model.Name
-		}
-
-		nestedSuffix := utils.ToLookMLName(arrayOriginalName)
-		return fmt.Sprintf("%s__%s", baseName, nestedSuffix)
-	}
-
-	// Use OriginalName to preserve PascalCase for proper conversion
-	originalName := columnName
-	if column.OriginalName != nil && *column.OriginalName != "" {
-		originalName = *column.OriginalName
+		// Use the full nested view name (same as the view name)
+		return g.naming.NestedViewName(model, arrayOriginalName)
 	}
 
-	// For nested fields, extract the path relative to the array
-	// e.g., "SupplierInformation.PalletType" with arrayName "supplierinformation" -> "PalletType"
-	// Need to find the array prefix in OriginalName (case-insensitive)
-	if strings.HasPrefix(strings.ToLower(originalName), strings.ToLower(arrayName)+".") {
-		// Find the position after the array name and dot
-		prefixLen := len(arrayName) + 1 // +1 for the dot
-		nestedPath := originalName[prefixLen:]
-
-		// Convert nested path to LookML dimension name
-		// e.g., "GTIN.GTINId" -> "gtin__gtin_id"
-		// e.g., "PalletType" -> "pallet_type"
-		return utils.ToLookMLName(nestedPath)
-	}
-
-	// Fallback: use the original name converted to LookML format
-	return utils.ToLookMLName(originalName)
+	return nestedViewFieldName(g.naming, arrayName, column)
 }
 
 // generateNestedViewSQL generates the SQL reference for a nested view dimension
@@ -839,7 +1103,21 @@ func (g *LookMLGenerator) dimensionGroupToLookML(dimensionGroup *models.LookMLDi
 
 	builder.WriteString(fmt.Sprintf("  dimension_group: %s {\n", dimensionGroup.Name))
 	builder.WriteString(fmt.Sprintf("    type: %s\n", dimensionGroup.Type))
-	builder.WriteString(fmt.Sprintf("    sql: %s ;;\n", dimensionGroup.SQL))
+	if dimensionGroup.SQL != "" {
+		builder.WriteString(fmt.Sprintf("    sql: %s ;;\n", dimensionGroup.SQL))
+	}
+	if dimensionGroup.SQLStart != "" {
+		builder.WriteString(fmt.Sprintf("    sql_start: %s ;;\n", dimensionGroup.SQLStart))
+		builder.WriteString(fmt.Sprintf("    sql_end: %s ;;\n", dimensionGroup.SQLEnd))
+	}
+
+	if len(dimensionGroup.Intervals) > 0 {
+		intervals := make([]string, len(dimensionGroup.Intervals))
+		for i, interval := range dimensionGroup.Intervals {
+			intervals[i] = string(interval)
+		}
+		builder.WriteString(fmt.Sprintf("    intervals: [%s]\n", strings.Join(intervals, ", ")))
+	}
 
 	if len(dimensionGroup.Timeframes) > 0 {
 		timeframes := make([]string, len(dimensionGroup.Timeframes))
@@ -849,6 +1127,31 @@ func (g *LookMLGenerator) dimensionGroupToLookML(dimensionGroup *models.LookMLDi
 		builder.WriteString(fmt.Sprintf("    timeframes: [%s]\n", strings.Join(timeframes, ", ")))
 	}
 
+	if dimensionGroup.ConvertTZ != nil {
+		builder.WriteString(fmt.Sprintf("    convert_tz: %s\n", yesNo(*dimensionGroup.ConvertTZ)))
+	}
+	if dimensionGroup.Datatype != nil {
+		builder.WriteString(fmt.Sprintf("    datatype: %s\n", *dimensionGroup.Datatype))
+	}
+
+	if dimensionGroup.GroupLabel != nil {
+		builder.WriteString(fmt.Sprintf("    group_label: %s\n", quoteString(*dimensionGroup.GroupLabel)))
+	}
+	if dimensionGroup.Label != nil {
+		builder.WriteString(fmt.Sprintf("    label: %s\n", quoteString(*dimensionGroup.Label)))
+	}
+	if dimensionGroup.Description != nil {
+		builder.WriteString(fmt.Sprintf("    description: %s\n", quoteString(*dimensionGroup.Description)))
+	}
+
+	fieldParametersToLookML(&builder, &dimensionGroup.LookMLFieldParameters)
+
+	if dimensionGroup.Hidden != nil && *dimensionGroup.Hidden {
+		builder.WriteString("    hidden: yes\n")
+	}
+
+	extraToLookML(&builder, dimensionGroup.Extra, "    ")
+
 	builder.WriteString("  }\n\n")
 
 	return builder.String()
@@ -861,19 +1164,107 @@ func (g *LookMLGenerator) measureToLookML(measure *models.LookMLMeasure) string
 	builder.WriteString(fmt.Sprintf("  measure: %s {\n", measure.Name))
 	builder.WriteString(fmt.Sprintf("    type: %s\n", string(measure.Type)))
 
+	if measure.BasedOn != nil {
+		builder.WriteString(fmt.Sprintf("    based_on: %s\n", *measure.BasedOn))
+	}
+
+	if measure.BasedOnTime != nil {
+		builder.WriteString(fmt.Sprintf("    based_on_time: %s\n", *measure.BasedOnTime))
+	}
+
+	if measure.Period != nil {
+		builder.WriteString(fmt.Sprintf("    period: %s\n", string(*measure.Period)))
+	}
+
+	if measure.Kind != nil {
+		builder.WriteString(fmt.Sprintf("    kind: %s\n", string(*measure.Kind)))
+	}
+
 	if measure.SQL != nil {
 		builder.WriteString(fmt.Sprintf("    sql: %s ;;\n", *measure.SQL))
 	}
 
 	if measure.Label != nil {
-		builder.WriteString(fmt.Sprintf("    label: \"%s\"\n", *measure.Label))
+		builder.WriteString(fmt.Sprintf("    label: %s\n", quoteString(*measure.Label)))
+	}
+
+	if measure.GroupLabel != nil {
+		builder.WriteString(fmt.Sprintf("    group_label: %s\n", quoteString(*measure.GroupLabel)))
+	}
+
+	if measure.Description != nil {
+		builder.WriteString(fmt.Sprintf("    description: %s\n", quoteString(*measure.Description)))
+	}
+
+	if measure.ValueFormatName != nil {
+		builder.WriteString(fmt.Sprintf("    value_format_name: %s\n", string(*measure.ValueFormatName)))
+	}
+
+	if measure.Precision != nil {
+		builder.WriteString(fmt.Sprintf("    precision: %d\n", *measure.Precision))
+	}
+
+	if measure.Percentile != nil {
+		builder.WriteString(fmt.Sprintf("    percentile: %d\n", *measure.Percentile))
+	}
+
+	if measure.Approximate != nil && *measure.Approximate {
+		builder.WriteString("    approximate: yes\n")
+	}
+
+	if measure.ApproximateThreshold != nil {
+		builder.WriteString(fmt.Sprintf("    approximate_threshold: %d\n", *measure.ApproximateThreshold))
 	}
 
+	if len(measure.Filters) > 0 {
+		filters := make([]string, 0, len(measure.Filters))
+		for _, filter := range measure.Filters {
+			filters = append(filters, fmt.Sprintf("%s: \"%s\"", filter.FilterDimension, filter.FilterExpression))
+		}
+		builder.WriteString(fmt.Sprintf("    filters: [%s]\n", strings.Join(filters, ", ")))
+	}
+
+	if len(measure.DrillFields) > 0 {
+		builder.WriteString(fmt.Sprintf("    drill_fields: [%s]\n", strings.Join(measure.DrillFields, ", ")))
+	}
+
+	// count_distinct measures use sql_distinct_key as their sql instead
+	if measure.SQLDistinctKey != nil && measure.Type != enums.MeasureCountDistinct {
+		builder.WriteString(fmt.Sprintf("    sql_distinct_key: %s ;;\n", *measure.SQLDistinctKey))
+	}
+
+	fieldParametersToLookML(&builder, &measure.LookMLFieldParameters)
+
+	if measure.Hidden != nil && *measure.Hidden {
+		builder.WriteString("    hidden: yes\n")
+	}
+
+	extraToLookML(&builder, measure.Extra, "    ")
+
 	builder.WriteString("  }\n\n")
 
 	return builder.String()
 }
 
+// setToLookML converts a set to LookML string, one field per line
+func (g *LookMLGenerator) setToLookML(set *models.LookMLSet) string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("  set: %s {\n", set.Name))
+	builder.WriteString("    fields: [\n")
+	for i, field := range set.Fields {
+		separator := ","
+		if i == len(set.Fields)-1 {
+			separator = ""
+		}
+		builder.WriteString(fmt.Sprintf("      %s%s\n", field, separator))
+	}
+	builder.WriteString("    ]\n")
+	builder.WriteString("  }\n")
+
+	return builder.String()
+}
+
 // joinToLookML converts a join to LookML string
 func (g *LookMLGenerator) joinToLookML(join *models.DbtMetaLookerJoin) string {
 	var builder strings.Builder
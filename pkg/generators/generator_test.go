@@ -0,0 +1,147 @@
+package generators
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createLayoutModel returns an orders model at marts/orders.sql with an array of structs
+func createLayoutModel() *models.DbtModel {
+	return createTestModel("orders", "marts/orders.sql",
+		testColumn("id", "INT64"),
+		testColumn("lines", "ARRAY<STRUCT<sku STRING, quantity INT64>>"),
+		testColumn("lines.sku", "STRING"),
+		testColumn("lines.quantity", "INT64"),
+	)
+}
+
+// readOutput returns the content of a generated file below outputDir
+func readOutput(t *testing.T, outputDir, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(outputDir, path))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestLayout_Inline(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir, Layout: config.LayoutInline}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createLayoutModel()}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.Contains(t, content, "view: orders {")
+	assert.Contains(t, content, "view: orders__lines {")
+	assert.Contains(t, content, "explore: orders {")
+	assert.NotContains(t, content, "include:")
+}
+
+func TestLayout_Split(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir, Layout: config.LayoutSplit}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createLayoutModel()}, GenerationOptions{})
+	require.NoError(t, err)
+
+	views := readOutput(t, outputDir, "views/marts/orders.view.lkml")
+	assert.Contains(t, views, "view: orders {")
+	assert.Contains(t, views, "view: orders__lines {")
+	assert.NotContains(t, views, "explore:")
+
+	explores := readOutput(t, outputDir, "explores/marts/orders.explore.lkml")
+	assert.Contains(t, explores, `include: "/views/marts/orders.view.lkml"`)
+	assert.Contains(t, explores, "explore: orders {")
+	assert.NotContains(t, explores, "view:")
+}
+
+func TestLayout_PerView(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir, Layout: config.LayoutPerView, IncludeRoot: "lookml"}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createLayoutModel()}, GenerationOptions{})
+	require.NoError(t, err)
+
+	main := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.Contains(t, main, "view: orders {")
+	assert.NotContains(t, main, "view: orders__lines {")
+
+	nested := readOutput(t, outputDir, "marts/orders__lines.view.lkml")
+	assert.Contains(t, nested, "view: orders__lines {")
+
+	explores := readOutput(t, outputDir, "marts/orders.explore.lkml")
+	assert.Contains(t, explores, `include: "/lookml/marts/orders.view.lkml"`)
+	assert.Contains(t, explores, `include: "/lookml/marts/orders__lines.view.lkml"`)
+	assert.Contains(t, explores, "explore: orders {")
+}
+
+func TestLayout_SplitWithRefinements(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir, Layout: config.LayoutSplit, Refinements: true}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createLayoutModel()}, GenerationOptions{})
+	require.NoError(t, err)
+
+	explores := readOutput(t, outputDir, "generated/explores/marts/orders.explore.lkml")
+	assert.Contains(t, explores, `include: "/generated/views/marts/orders.view.lkml"`)
+
+	refinement := readOutput(t, outputDir, "refinements/views/marts/orders.view.lkml")
+	assert.Contains(t, refinement, `include: "/generated/views/marts/orders.view.lkml"`)
+	assert.Contains(t, refinement, `include: "/generated/explores/marts/orders.explore.lkml"`)
+	assert.Contains(t, refinement, "explore: +orders {")
+}
+
+func TestLayout_Filenames(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *config.Config
+		expected []string // view, explore, nested view
+	}{
+		{
+			name:     "inline",
+			cfg:      &config.Config{},
+			expected: []string{"marts/orders.view.lkml", "marts/orders.explore.lkml", "marts/orders__lines.view.lkml"},
+		},
+		{
+			name:     "split",
+			cfg:      &config.Config{Layout: config.LayoutSplit},
+			expected: []string{"views/marts/orders.view.lkml", "explores/marts/orders.explore.lkml", "views/marts/orders__lines.view.lkml"},
+		},
+		{
+			name:     "flatten applies to nested views",
+			cfg:      &config.Config{Flatten: true},
+			expected: []string{"orders.view.lkml", "orders.explore.lkml", "orders__lines.view.lkml"},
+		},
+		{
+			name:     "remove schema string applies to all files",
+			cfg:      &config.Config{RemoveSchemaString: "ord"},
+			expected: []string{"marts/ers.view.lkml", "marts/ers.explore.lkml", "marts/ers__lines.view.lkml"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := NewLookMLGenerator(tt.cfg)
+			model := createLayoutModel()
+
+			viewPath, err := gen.getViewFilename(model)
+			require.NoError(t, err)
+			explorePath, err := gen.getExploreFilename(model)
+			require.NoError(t, err)
+			nestedPath, err := gen.getNestedViewFilename(model, "orders__lines")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected[0], viewPath)
+			assert.Equal(t, tt.expected[1], explorePath)
+			assert.Equal(t, tt.expected[2], nestedPath)
+		})
+	}
+}
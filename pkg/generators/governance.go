@@ -0,0 +1,258 @@
+package generators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+)
+
+// maskedFieldSuffix is appended to the name of a governed dimension to name its masked variant
+const maskedFieldSuffix = "_masked"
+
+// GovernedField is a field that requires access grants because it holds personal data
+type GovernedField struct {
+	ModelName    string   `json:"model" yaml:"model"`
+	View         string   `json:"view" yaml:"view"`
+	Field        string   `json:"field" yaml:"field"`
+	Reason       string   `json:"reason" yaml:"reason"`
+	AccessGrants []string `json:"access_grants" yaml:"access_grants"`
+	Masking      string   `json:"masking" yaml:"masking"`
+	MaskedField  string   `json:"masked_field,omitempty" yaml:"masked_field,omitempty"`
+}
+
+// piiGovernanceEnabled reports whether personal data is governed: it requires access
+// grants or a masking mode
+func piiGovernanceEnabled(cfg *config.Config) bool {
+	return len(cfg.GetPIIAccessGrants()) > 0 || piiMasking(cfg) != config.PIIMaskingNone
+}
+
+// piiMasking returns the configured masking mode
+func piiMasking(cfg *config.Config) string {
+	if cfg.PII.Masking == "" {
+		return config.PIIMaskingNone
+	}
+	return cfg.PII.Masking
+}
+
+// piiReason returns why a column is governed as personal data, or "" when it is not.
+// meta.contains_pii takes precedence over the column's tags.
+func piiReason(cfg *config.Config, column *models.DbtModelColumn, tags []string) string {
+	if column.Meta != nil && column.Meta.ContainsPII != nil {
+		if *column.Meta.ContainsPII {
+			return "meta.contains_pii"
+		}
+		return ""
+	}
+
+	for _, piiTag := range cfg.GetPIITags() {
+		for _, tag := range tags {
+			if strings.EqualFold(tag, piiTag) {
+				return "tag:" + tag
+			}
+		}
+	}
+	return ""
+}
+
+// applyPIIGovernance marks the parameters of a column holding personal data as governed and
+// adds the access grants it requires
+func (g *DimensionGenerator) applyPIIGovernance(column *models.DbtModelColumn, parameters *models.LookMLFieldParameters) {
+	if !piiGovernanceEnabled(g.config) {
+		return
+	}
+
+	reason := piiReason(g.config, column, parameters.Tags)
+	if reason == "" {
+		return
+	}
+	parameters.PIIReason = reason
+	parameters.RequiredAccessGrants = mergeTags(parameters.RequiredAccessGrants, g.config.GetPIIAccessGrants())
+}
+
+// applyPIIMasking completes the governance of a view. Fields referencing a governed field
+// are governed too, and governed dimensions are hidden or get a masked variant depending
+// on pii.masking.
+func applyPIIMasking(cfg *config.Config, view *models.LookMLView) error {
+	if !piiGovernanceEnabled(cfg) {
+		return nil
+	}
+
+	propagatePIIGovernance(view)
+
+	masking := piiMasking(cfg)
+	if masking == config.PIIMaskingHidden {
+		for i := range view.Dimensions {
+			if view.Dimensions[i].PIIReason != "" {
+				view.Dimensions[i].Hidden = utils.BoolPtr(true)
+			}
+		}
+		for i := range view.DimensionGroups {
+			if view.DimensionGroups[i].PIIReason != "" {
+				view.DimensionGroups[i].Hidden = utils.BoolPtr(true)
+			}
+		}
+		return nil
+	}
+	if masking != config.PIIMaskingSHA256 && masking != config.PIIMaskingRedacted {
+		return nil
+	}
+
+	available := view.FieldNames()
+	var masked []models.LookMLDimension
+	for _, dimension := range view.Dimensions {
+		if dimension.PIIReason == "" || dimension.SQL == "" {
+			continue
+		}
+		name := dimension.Name + maskedFieldSuffix
+		if fieldAvailable(available, name) {
+			return fmt.Errorf("masked dimension %s conflicts with an existing field in view %s", name, view.Name)
+		}
+		masked = append(masked, maskedDimension(&dimension, name, masking))
+	}
+	view.Dimensions = append(view.Dimensions, masked...)
+	return nil
+}
+
+// maskedDimension returns the variant of a governed dimension shown to users without its
+// access grants
+func maskedDimension(dimension *models.LookMLDimension, name, masking string) models.LookMLDimension {
+	sql := "'REDACTED'"
+	if masking == config.PIIMaskingSHA256 {
+		sql = fmt.Sprintf("TO_HEX(SHA256(CAST(%s AS STRING)))", dimension.SQL)
+	}
+
+	masked := models.LookMLDimension{
+		Name:           name,
+		Type:           "string",
+		SQL:            sql,
+		GroupLabel:     dimension.GroupLabel,
+		GroupItemLabel: dimension.GroupItemLabel,
+		Description:    dimension.Description,
+	}
+	if dimension.Label != nil {
+		masked.Label = utils.StringPtr(*dimension.Label + " (Masked)")
+	}
+	if dimension.GroupItemLabel != nil {
+		masked.GroupItemLabel = utils.StringPtr(*dimension.GroupItemLabel + " (Masked)")
+	}
+	return masked
+}
+
+// governedReference is a ${field} reference to a governed field and the grants it requires
+type governedReference struct {
+	field  string
+	grants []string
+}
+
+// propagatePIIGovernance governs every field whose SQL references a governed field of the
+// same view, until no more fields are added
+func propagatePIIGovernance(view *models.LookMLView) {
+	references := make(map[string]governedReference)
+	for _, dimension := range view.Dimensions {
+		if dimension.PIIReason != "" {
+			references["${"+dimension.Name+"}"] = governedReference{field: dimension.Name, grants: dimension.RequiredAccessGrants}
+		}
+	}
+	for i := range view.DimensionGroups {
+		dimensionGroup := &view.DimensionGroups[i]
+		if dimensionGroup.PIIReason == "" {
+			continue
+		}
+		// Fields of a dimension group are referenced by timeframe, as ${name_timeframe}
+		for _, name := range dimensionGroup.FieldNames() {
+			references["${"+name+"}"] = governedReference{field: dimensionGroup.Name, grants: dimensionGroup.RequiredAccessGrants}
+		}
+	}
+	if len(references) == 0 {
+		return
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for i := range view.Dimensions {
+			dimension := &view.Dimensions[i]
+			sql := dimension.SQL + dimension.SQLLatitude + dimension.SQLLongitude
+			if governByReference(&dimension.LookMLFieldParameters, sql, references) {
+				references["${"+dimension.Name+"}"] = governedReference{field: dimension.Name, grants: dimension.RequiredAccessGrants}
+				changed = true
+			}
+		}
+	}
+
+	for i := range view.Measures {
+		measure := &view.Measures[i]
+		sql := ""
+		if measure.SQL != nil {
+			sql = *measure.SQL
+		}
+		governByReference(&measure.LookMLFieldParameters, sql, references)
+	}
+}
+
+// governByReference governs an ungoverned field whose SQL contains a reference to a governed
+// field, and reports whether it did
+func governByReference(parameters *models.LookMLFieldParameters, sql string, references map[string]governedReference) bool {
+	if parameters.PIIReason != "" || sql == "" {
+		return false
+	}
+
+	var referenced []string
+	for reference := range references {
+		if strings.Contains(sql, reference) {
+			referenced = append(referenced, reference)
+		}
+	}
+	if len(referenced) == 0 {
+		return false
+	}
+
+	sort.Strings(referenced)
+	var names []string
+	for _, reference := range referenced {
+		names = mergeTags(names, []string{references[reference].field})
+		parameters.RequiredAccessGrants = mergeTags(parameters.RequiredAccessGrants, references[reference].grants)
+	}
+	parameters.PIIReason = "references " + strings.Join(names, ", ")
+	return true
+}
+
+// recordGovernedFields reports the governed fields of the views written for a model
+func (g *LookMLGenerator) recordGovernedFields(model *models.DbtModel, files []outputFile) {
+	masking := piiMasking(g.config)
+	for _, file := range files {
+		for _, view := range file.Views {
+			available := view.FieldNames()
+			record := func(name string, parameters *models.LookMLFieldParameters) {
+				if parameters.PIIReason == "" {
+					return
+				}
+				field := GovernedField{
+					ModelName:    model.Name,
+					View:         view.Name,
+					Field:        name,
+					Reason:       parameters.PIIReason,
+					AccessGrants: parameters.RequiredAccessGrants,
+					Masking:      masking,
+				}
+				if fieldAvailable(available, name+maskedFieldSuffix) {
+					field.MaskedField = name + maskedFieldSuffix
+				}
+				g.diagnostics.RecordGovernedField(field)
+			}
+
+			for i := range view.Dimensions {
+				record(view.Dimensions[i].Name, &view.Dimensions[i].LookMLFieldParameters)
+			}
+			for i := range view.DimensionGroups {
+				record(view.DimensionGroups[i].Name, &view.DimensionGroups[i].LookMLFieldParameters)
+			}
+			for i := range view.Measures {
+				record(view.Measures[i].Name, &view.Measures[i].LookMLFieldParameters)
+			}
+		}
+	}
+}
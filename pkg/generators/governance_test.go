@@ -0,0 +1,187 @@
+package generators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createGovernanceModel returns a customers model with columns tagged or flagged as PII, one
+// of them opted out, and a measure over a PII column
+func createGovernanceModel() *models.DbtModel {
+	email := testColumn("email", "STRING")
+	email.Description = utils.StringPtr("Contact email")
+	email.Tags = []string{"PII"}
+	phone := testColumn("phone", "STRING")
+	phone.Tags = []string{"pii"}
+	phone.Meta = &models.DbtModelColumnMeta{ContainsPII: utils.BoolPtr(false)}
+	birthDate := testColumn("birth_date", "DATE")
+	birthDate.Meta = &models.DbtModelColumnMeta{ContainsPII: utils.BoolPtr(true)}
+
+	model := createTestModel("customers", "crm/customers.sql", testColumn("customer_id", "INT64"), email, phone, birthDate)
+	model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{
+		Measures: []models.DbtMetaLookerMeasure{{
+			Type: enums.MeasureCountDistinct,
+			Name: utils.StringPtr("unique_emails"),
+			SQL:  utils.StringPtr("${email}"),
+		}},
+	}}
+	return model
+}
+
+// governanceConfig returns a config with a PII access grant and the given masking
+func governanceConfig(masking string) *config.Config {
+	return &config.Config{
+		AccessGrants: []config.AccessGrantConfig{
+			{Name: "can_see_pii", UserAttribute: "pii_access", AllowedValues: []string{"yes"}},
+		},
+		PII: config.PIIConfig{Masking: masking},
+	}
+}
+
+func TestViewGenerator_PIIGovernance(t *testing.T) {
+	tests := []struct {
+		name      string
+		masking   string
+		hidden    bool
+		maskedSQL string
+	}{
+		{name: "grants only", masking: config.PIIMaskingNone},
+		{name: "hidden", masking: config.PIIMaskingHidden, hidden: true},
+		{name: "sha256", masking: config.PIIMaskingSHA256, maskedSQL: "TO_HEX(SHA256(CAST(${TABLE}.email AS STRING)))"},
+		{name: "redacted", masking: config.PIIMaskingRedacted, maskedSQL: "'REDACTED'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view, err := NewViewGenerator(governanceConfig(tt.masking)).GenerateView(createGovernanceModel())
+			require.NoError(t, err)
+
+			email := findDimension(view, "email")
+			require.NotNil(t, email)
+			assert.Equal(t, []string{"can_see_pii"}, email.RequiredAccessGrants)
+			assert.Equal(t, "tag:PII", email.PIIReason)
+			assert.Equal(t, tt.hidden, email.Hidden != nil && *email.Hidden)
+
+			birth := findDimensionGroup(view, "birth")
+			require.NotNil(t, birth)
+			assert.Equal(t, []string{"can_see_pii"}, birth.RequiredAccessGrants)
+			assert.Equal(t, "meta.contains_pii", birth.PIIReason)
+
+			phone := findDimension(view, "phone")
+			require.NotNil(t, phone)
+			assert.Empty(t, phone.RequiredAccessGrants, "contains_pii: false exempts a tagged column")
+
+			var uniqueEmails *models.LookMLMeasure
+			for i := range view.Measures {
+				if view.Measures[i].Name == "unique_emails" {
+					uniqueEmails = &view.Measures[i]
+				}
+			}
+			require.NotNil(t, uniqueEmails)
+			assert.Equal(t, []string{"can_see_pii"}, uniqueEmails.RequiredAccessGrants)
+			assert.Equal(t, "references email", uniqueEmails.PIIReason)
+
+			masked := findDimension(view, "email_masked")
+			if tt.maskedSQL == "" {
+				assert.Nil(t, masked)
+				return
+			}
+			require.NotNil(t, masked)
+			assert.Equal(t, tt.maskedSQL, masked.SQL)
+			assert.Empty(t, masked.RequiredAccessGrants)
+			assert.Equal(t, email.Description, masked.Description)
+		})
+	}
+}
+
+func TestViewGenerator_PIIGovernanceDimensionGroupReferences(t *testing.T) {
+	model := createGovernanceModel()
+	model.Columns["birth_place"] = models.DbtModelColumn{Name: "birth_place", DataType: utils.StringPtr("STRING")}
+	model.Meta.Looker.Measures = append(model.Meta.Looker.Measures,
+		models.DbtMetaLookerMeasure{Type: enums.MeasureMin, Name: utils.StringPtr("first_birth_year"), SQL: utils.StringPtr("${birth_year}")},
+		models.DbtMetaLookerMeasure{Type: enums.MeasureCountDistinct, Name: utils.StringPtr("birth_places"), SQL: utils.StringPtr("${birth_place}")},
+	)
+
+	view, err := NewViewGenerator(governanceConfig(config.PIIMaskingNone)).GenerateView(model)
+	require.NoError(t, err)
+
+	measures := make(map[string]models.LookMLMeasure)
+	for _, measure := range view.Measures {
+		measures[measure.Name] = measure
+	}
+	assert.Equal(t, "references birth", measures["first_birth_year"].PIIReason)
+	assert.Equal(t, []string{"can_see_pii"}, measures["first_birth_year"].RequiredAccessGrants)
+	assert.Empty(t, measures["birth_places"].PIIReason, "birth_place is not a timeframe of birth")
+	assert.Empty(t, measures["birth_places"].RequiredAccessGrants)
+}
+
+func TestViewGenerator_PIIGovernanceDisabled(t *testing.T) {
+	view, err := NewViewGenerator(&config.Config{}).GenerateView(createGovernanceModel())
+	require.NoError(t, err)
+
+	email := findDimension(view, "email")
+	require.NotNil(t, email)
+	assert.Empty(t, email.RequiredAccessGrants)
+	assert.Empty(t, email.PIIReason)
+}
+
+func TestViewGenerator_PIIGovernanceMaskedConflict(t *testing.T) {
+	model := createGovernanceModel()
+	model.Columns["email_masked"] = models.DbtModelColumn{Name: "email_masked", DataType: utils.StringPtr("STRING")}
+
+	_, err := NewViewGenerator(governanceConfig(config.PIIMaskingRedacted)).GenerateView(model)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "masked dimension email_masked conflicts with an existing field in view customers")
+}
+
+func TestPIIGovernance_HiddenRendered(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := governanceConfig(config.PIIMaskingHidden)
+	cfg.OutputDir = outputDir
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createGovernanceModel()}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "crm/customers.view.lkml")
+	assert.Contains(t, content, "    datatype: date\n"+
+		"    label: \"Birth\"\n"+
+		"    required_access_grants: [can_see_pii]\n"+
+		"    hidden: yes\n"+
+		"  }\n")
+}
+
+func TestPIIGovernance_ModelFileAndReport(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := governanceConfig(config.PIIMaskingSHA256)
+	cfg.OutputDir = outputDir
+	cfg.ModelFiles = true
+	cfg.ModelName = "analytics"
+	cfg.ModelGrouping = config.ModelGroupingSingle
+	cfg.Connection = "bigquery_default"
+
+	result, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createGovernanceModel()}, GenerationOptions{})
+	require.NoError(t, err)
+
+	modelFile := readOutput(t, outputDir, "analytics.model.lkml")
+	assert.Contains(t, modelFile, "access_grant: can_see_pii {\n"+
+		"  user_attribute: pii_access\n"+
+		"  allowed_values: [\"yes\"]\n"+
+		"}\n")
+
+	content := readOutput(t, outputDir, "crm/customers.view.lkml")
+	assert.Contains(t, content, "    tags: [\"PII\"]\n    required_access_grants: [can_see_pii]\n")
+	assert.Contains(t, content, "  dimension: email_masked {\n")
+
+	assert.Equal(t, []GovernedField{
+		{ModelName: "customers", View: "customers", Field: "email", Reason: "tag:PII", AccessGrants: []string{"can_see_pii"}, Masking: "sha256", MaskedField: "email_masked"},
+		{ModelName: "customers", View: "customers", Field: "birth", Reason: "meta.contains_pii", AccessGrants: []string{"can_see_pii"}, Masking: "sha256"},
+		{ModelName: "customers", View: "customers", Field: "unique_emails", Reason: "references email", AccessGrants: []string{"can_see_pii"}, Masking: "sha256"},
+	}, result.GovernedFields)
+}
@@ -23,6 +23,15 @@ type DimensionGeneratorInterface interface {
 	// GenerateDimensionGroup generates a LookML dimension group from a model column
 	GenerateDimensionGroup(model *models.DbtModel, column *models.DbtModelColumn) (*models.LookMLDimensionGroup, error)
 
+	// GenerateRangeDimensionGroups generates the start and end dimension groups of a RANGE column
+	GenerateRangeDimensionGroups(model *models.DbtModel, column *models.DbtModelColumn) ([]models.LookMLDimensionGroup, error)
+
+	// GenerateDurationDimensionGroups generates the duration dimension groups of a model
+	GenerateDurationDimensionGroups(model *models.DbtModel, columns map[string]models.DbtModelColumn) ([]models.LookMLDimensionGroup, error)
+
+	// GenerateJSONPathDimensions generates the dimensions extracted from a JSON column
+	GenerateJSONPathDimensions(model *models.DbtModel, column *models.DbtModelColumn) ([]models.LookMLDimension, error)
+
 	// GetDimensionName gets the dimension name from the column
 	GetDimensionName(column *models.DbtModelColumn) string
 
@@ -35,14 +44,14 @@ type MeasureGeneratorInterface interface {
 	// GenerateMeasure generates a LookML measure from measure metadata
 	GenerateMeasure(model *models.DbtModel, measureMeta *models.DbtMetaLookerMeasure) (*models.LookMLMeasure, error)
 
-	// GenerateDefaultCountMeasure generates a default count measure for a model
-	GenerateDefaultCountMeasure(model *models.DbtModel) *models.LookMLMeasure
+	// GenerateColumnMeasure generates a measure declared in a column's meta
+	GenerateColumnMeasure(model *models.DbtModel, column *models.DbtModelColumn, measureMeta *models.DbtMetaLookerMeasure) (*models.LookMLMeasure, error)
 
-	// GeneratePrimaryKeyMeasure generates a count distinct measure for primary key columns
-	GeneratePrimaryKeyMeasure(model *models.DbtModel, pkColumn *models.DbtModelColumn) *models.LookMLMeasure
+	// GenerateTemplateMeasures generates the measures of the configured measure templates
+	GenerateTemplateMeasures(model *models.DbtModel) ([]*models.LookMLMeasure, error)
 
-	// GenerateNumericMeasures generates sum/average measures for numeric columns
-	GenerateNumericMeasures(model *models.DbtModel, column *models.DbtModelColumn) []*models.LookMLMeasure
+	// GenerateDefaultCountMeasure generates a default count measure for a model
+	GenerateDefaultCountMeasure(model *models.DbtModel) *models.LookMLMeasure
 }
 
 // ExploreGeneratorInterface defines the interface for generating LookML explores
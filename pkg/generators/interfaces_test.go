@@ -42,6 +42,18 @@ type MockDimensionGenerator struct {
 	GetDimensionGroupLabelFunc func(column *models.DbtModelColumn) *string
 }
 
+func (m *MockDimensionGenerator) GenerateRangeDimensionGroups(model *models.DbtModel, column *models.DbtModelColumn) ([]models.LookMLDimensionGroup, error) {
+	return nil, nil
+}
+
+func (m *MockDimensionGenerator) GenerateDurationDimensionGroups(model *models.DbtModel, columns map[string]models.DbtModelColumn) ([]models.LookMLDimensionGroup, error) {
+	return nil, nil
+}
+
+func (m *MockDimensionGenerator) GenerateJSONPathDimensions(model *models.DbtModel, column *models.DbtModelColumn) ([]models.LookMLDimension, error) {
+	return nil, nil
+}
+
 func (m *MockDimensionGenerator) GenerateDimension(model *models.DbtModel, column *models.DbtModelColumn) (*models.LookMLDimension, error) {
 	if m.GenerateDimensionFunc != nil {
 		return m.GenerateDimensionFunc(model, column)
@@ -0,0 +1,130 @@
+package generators
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+)
+
+// metaJoinTarget is a meta.looker.joins entry resolved to a selected model
+type metaJoinTarget struct {
+	join  models.DbtMetaLookerJoin
+	name  string // join name in the explore (alias or view name)
+	model *models.DbtModel
+}
+
+// metaJoinTargets resolves the meta joins of a model, dropping the ones that cannot be resolved.
+// The explore generator already reported those.
+func (g *LookMLGenerator) metaJoinTargets(model *models.DbtModel) []metaJoinTarget {
+	if model.Meta == nil || model.Meta.Looker == nil {
+		return nil
+	}
+
+	var targets []metaJoinTarget
+	for _, metaJoin := range model.Meta.Looker.Joins {
+		if metaJoin.JoinModel == nil {
+			continue
+		}
+		target, viewName, err := g.exploreGenerator.resolveJoinModel(*metaJoin.JoinModel)
+		if err != nil || target == nil {
+			continue
+		}
+
+		name := viewName
+		if metaJoin.Alias != nil && *metaJoin.Alias != "" {
+			name = *metaJoin.Alias
+		}
+		targets = append(targets, metaJoinTarget{join: metaJoin, name: name, model: target})
+	}
+	return targets
+}
+
+// joinIncludes returns include statements for the view files of the models a model's explore joins
+func (g *LookMLGenerator) joinIncludes(model *models.DbtModel) ([]string, error) {
+	seen := make(map[string]bool)
+	var includes []string
+
+	for _, target := range g.metaJoinTargets(model) {
+		if target.model.Name == model.Name {
+			continue
+		}
+
+		viewFile, err := g.getViewFilename(target.model)
+		if err != nil {
+			return nil, err
+		}
+		if g.config.Refinements {
+			viewFile = path.Join(generatedDirName, viewFile)
+		}
+
+		include := g.config.GetIncludePath(viewFile)
+		if !seen[include] {
+			seen[include] = true
+			includes = append(includes, include)
+		}
+	}
+
+	return includes, nil
+}
+
+// checkExploreJoins reports meta join parameters that reference views or fields the explore does not provide
+func (g *LookMLGenerator) checkExploreJoins(model *models.DbtModel, views []*models.LookMLView, explore *models.LookMLExplore) {
+	targets := g.metaJoinTargets(model)
+	if len(targets) == 0 {
+		return
+	}
+
+	available := g.exploreViewFields(views, targets)
+
+	joinNames := make(map[string]bool, len(explore.Joins))
+	for _, join := range explore.Joins {
+		joinNames[join.Name] = true
+	}
+
+	var issues []string
+	for _, target := range targets {
+		context := fmt.Sprintf("join %s", target.name)
+
+		if target.join.SQLON != nil {
+			issues = append(issues, joinReferenceIssues(*target.join.SQLON, context, available)...)
+		}
+
+		for _, field := range target.join.Fields {
+			issues = append(issues, fieldReferenceIssues(field, target.name, context+" fields", available)...)
+		}
+
+		for _, required := range target.join.RequiredJoins {
+			if !joinNames[required] {
+				issues = append(issues, fmt.Sprintf("%s requires join %s which is not part of explore %s", context, required, explore.Name))
+			}
+		}
+	}
+
+	for _, issue := range uniqueSorted(issues) {
+		g.diagnostics.Warn(model.Name, WarningJoin, issue)
+	}
+}
+
+// joinReferenceIssues checks the ${view.field} references in a join's sql_on
+func joinReferenceIssues(sqlOn, context string, available map[string]map[string]bool) []string {
+	var issues []string
+
+	for _, match := range fieldReferencePattern.FindAllStringSubmatch(sqlOn, -1) {
+		viewName, fieldName := match[1], match[2]
+		if fieldName == "" || viewName == "TABLE" || fieldName == "SQL_TABLE_NAME" {
+			continue
+		}
+
+		fields, ok := available[viewName]
+		if !ok {
+			issues = append(issues, fmt.Sprintf("%s sql_on references view %s which is not part of the explore", context, viewName))
+			continue
+		}
+		if !fieldAvailable(fields, fieldName) {
+			issues = append(issues, fmt.Sprintf("%s sql_on references ${%s.%s} which does not exist", context, viewName, fieldName))
+		}
+	}
+
+	return issues
+}
@@ -0,0 +1,168 @@
+package generators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createJoinModels returns an orders model with the given joins and the customers model it joins
+func createJoinModels(joins ...models.DbtMetaLookerJoin) []*models.DbtModel {
+	orders := createTestModel("orders", "marts/orders.sql",
+		testColumn("id", "INT64"),
+		testColumn("customer_id", "INT64"),
+	)
+	orders.Meta = &models.DbtModelMeta{
+		Looker: &models.DbtMetaLooker{Joins: joins},
+	}
+
+	customers := createTestModel("customers", "core/customers.sql",
+		testColumn("id", "INT64"),
+		testColumn("name", "STRING"),
+	)
+	customers.RelationName = "`project.dataset.dim_customers`"
+
+	return []*models.DbtModel{orders, customers}
+}
+
+// joinWarnings returns the messages of the join warnings of result
+func joinWarnings(result *GenerationResult) []string {
+	var messages []string
+	for _, warning := range result.Warnings {
+		if warning.Category == WarningJoin {
+			messages = append(messages, warning.Message)
+		}
+	}
+	return messages
+}
+
+func TestJoins_Rendered(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir}
+
+	relationship := enums.RelationshipManyToOne
+	joinType := enums.JoinLeftOuter
+	selected := createJoinModels(models.DbtMetaLookerJoin{
+		JoinModel:     utils.StringPtr("ref('customers')"),
+		Alias:         utils.StringPtr("buyer"),
+		SQLON:         utils.StringPtr("${orders.customer_id} = ${buyer.id}"),
+		Type:          &joinType,
+		Relationship:  &relationship,
+		ViewLabel:     utils.StringPtr("Buyer"),
+		Fields:        []string{"buyer.name"},
+		RequiredJoins: []string{"orders"},
+	})
+
+	result, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), selected, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.Contains(t, content, "  join: buyer {\n"+
+		"    from: customers\n"+
+		"    view_label: \"Buyer\"\n"+
+		"    type: left_outer\n"+
+		"    fields: [buyer.name]\n"+
+		"    required_joins: [orders]\n"+
+		"    sql_on: ${orders.customer_id} = ${buyer.id} ;;\n"+
+		"    relationship: many_to_one\n"+
+		"  }\n")
+
+	// orders is the explore's base view, not a join
+	assert.Equal(t, []string{"join buyer requires join orders which is not part of explore orders"}, joinWarnings(result))
+}
+
+func TestJoins_OutsideSelection(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir}
+
+	selected := createJoinModels(models.DbtMetaLookerJoin{
+		JoinModel: utils.StringPtr("ref('suppliers')"),
+		SQLON:     utils.StringPtr("${orders.supplier_id} = ${suppliers.id}"),
+	})
+
+	result, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), selected, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.NotContains(t, content, "join:")
+
+	warnings := joinWarnings(result)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "ref('suppliers') is not among the selected models")
+}
+
+func TestJoins_FieldReferences(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir}
+
+	selected := createJoinModels(models.DbtMetaLookerJoin{
+		JoinModel: utils.StringPtr("customers"),
+		SQLON:     utils.StringPtr("${orders.customer_key} = ${customers.id} AND ${products.id} = ${TABLE.id}"),
+		Fields:    []string{"customers.name", "email", "ALL_FIELDS*"},
+	})
+
+	result, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), selected, GenerationOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"join customers fields references customers.email which does not exist",
+		"join customers sql_on references ${orders.customer_key} which does not exist",
+		"join customers sql_on references view products which is not part of the explore",
+	}, joinWarnings(result))
+}
+
+func TestJoins_LayoutIncludes(t *testing.T) {
+	join := models.DbtMetaLookerJoin{
+		JoinModel: utils.StringPtr("customers"),
+		SQLON:     utils.StringPtr("${orders.customer_id} = ${customers.id}"),
+	}
+
+	tests := []struct {
+		name     string
+		cfg      config.Config
+		path     string
+		includes []string
+	}{
+		{
+			name:     "split",
+			cfg:      config.Config{Layout: config.LayoutSplit},
+			path:     "explores/marts/orders.explore.lkml",
+			includes: []string{`include: "/views/marts/orders.view.lkml"`, `include: "/views/core/customers.view.lkml"`},
+		},
+		{
+			name:     "per view",
+			cfg:      config.Config{Layout: config.LayoutPerView},
+			path:     "marts/orders.explore.lkml",
+			includes: []string{`include: "/marts/orders.view.lkml"`, `include: "/core/customers.view.lkml"`},
+		},
+		{
+			name:     "split with refinements",
+			cfg:      config.Config{Layout: config.LayoutSplit, Refinements: true},
+			path:     "generated/explores/marts/orders.explore.lkml",
+			includes: []string{`include: "/generated/views/marts/orders.view.lkml"`, `include: "/generated/views/core/customers.view.lkml"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputDir := t.TempDir()
+			cfg := tt.cfg
+			cfg.OutputDir = outputDir
+
+			_, err := NewLookMLGenerator(&cfg).GenerateAllWithOptions(context.Background(), createJoinModels(join), GenerationOptions{})
+			require.NoError(t, err)
+
+			content := readOutput(t, outputDir, tt.path)
+			for _, include := range tt.includes {
+				assert.Contains(t, content, include)
+			}
+			assert.Contains(t, content, "  join: customers {")
+		})
+	}
+}
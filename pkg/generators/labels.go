@@ -0,0 +1,123 @@
+package generators
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+)
+
+// LabelGenerator derives human labels from view, field and column names. Names are split
+// on underscores, dots and CamelCase, and every word is written from the label dictionary:
+// replacements first, then acronyms, then lowercase words, and title case otherwise.
+type LabelGenerator struct {
+	acronyms       map[string]string
+	replacements   map[string]string
+	lowercaseWords map[string]bool
+	yesNoPrefixes  []labelPrefixRule
+}
+
+// labelPrefixRule rephrases the label of a boolean field whose name starts with prefix
+type labelPrefixRule struct {
+	prefix string
+	format string
+}
+
+// NewLabelGenerator creates the label generator configured by the labels options
+func NewLabelGenerator(cfg *config.Config) *LabelGenerator {
+	labels := &LabelGenerator{
+		acronyms:       make(map[string]string),
+		replacements:   make(map[string]string),
+		lowercaseWords: make(map[string]bool),
+	}
+	for _, acronym := range cfg.GetLabelAcronyms() {
+		labels.acronyms[strings.ToLower(acronym)] = acronym
+	}
+	for word, replacement := range cfg.Labels.Replacements {
+		labels.replacements[strings.ToLower(word)] = replacement
+	}
+	for _, word := range cfg.GetLabelLowercaseWords() {
+		labels.lowercaseWords[strings.ToLower(word)] = true
+	}
+	for prefix, format := range cfg.Labels.YesNoPrefixes {
+		labels.yesNoPrefixes = append(labels.yesNoPrefixes, labelPrefixRule{prefix: strings.ToLower(prefix), format: format})
+	}
+	// The longest prefix wins, so has_no_ is tried before has_
+	sort.Slice(labels.yesNoPrefixes, func(i, j int) bool {
+		if len(labels.yesNoPrefixes[i].prefix) != len(labels.yesNoPrefixes[j].prefix) {
+			return len(labels.yesNoPrefixes[i].prefix) > len(labels.yesNoPrefixes[j].prefix)
+		}
+		return labels.yesNoPrefixes[i].prefix < labels.yesNoPrefixes[j].prefix
+	})
+	return labels
+}
+
+// Label returns the label of a name, e.g. "Consumer Item ID" for consumer_item_id and
+// "Item GTIN ID" for Item.GTINId
+func (l *LabelGenerator) Label(name string) string {
+	words := labelWords(name)
+	for i, word := range words {
+		switch {
+		case l.replacements[word] != "":
+			words[i] = l.replacements[word]
+		case l.acronyms[word] != "":
+			words[i] = l.acronyms[word]
+		case i > 0 && l.lowercaseWords[word]:
+			// Lowercase words keep their case inside the label only
+		default:
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// FieldLabel returns the label of a field. Boolean fields matching a yes/no prefix rule are
+// rephrased without the prefix; ok reports whether a rule applied.
+func (l *LabelGenerator) FieldLabel(name string, yesno bool) (label string, ok bool) {
+	if yesno {
+		snakeName := utils.CamelToSnake(name)
+		for _, rule := range l.yesNoPrefixes {
+			if rest := strings.TrimPrefix(snakeName, rule.prefix); rest != snakeName && rest != "" {
+				return strings.ReplaceAll(rule.format, "{label}", l.Label(rest)), true
+			}
+		}
+	}
+	return l.Label(name), false
+}
+
+// GroupLabel returns the group_label of a nested column from its parent path, e.g.
+// "Classification Assortment" for classification.assortment.code
+func (l *LabelGenerator) GroupLabel(path string) *string {
+	parts := strings.Split(path, ".")
+	if len(parts) < 2 {
+		return nil
+	}
+	label := l.Label(strings.Join(parts[:len(parts)-1], "."))
+	return &label
+}
+
+// GroupItemLabel returns the group_item_label of a nested column from its last path part
+func (l *LabelGenerator) GroupItemLabel(path string, yesno bool) *string {
+	parts := strings.Split(path, ".")
+	if len(parts) < 2 {
+		return nil
+	}
+	label, _ := l.FieldLabel(parts[len(parts)-1], yesno)
+	return &label
+}
+
+// labelWords splits a name into lower case words
+func labelWords(name string) []string {
+	var words []string
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return r == '.' || r == '_' || r == '-' || r == ' '
+	}) {
+		for _, word := range strings.Split(utils.CamelToSnake(part), "_") {
+			if word != "" {
+				words = append(words, word)
+			}
+		}
+	}
+	return words
+}
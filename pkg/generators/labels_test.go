@@ -0,0 +1,177 @@
+package generators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelGenerator_Label(t *testing.T) {
+	tests := []struct {
+		name     string
+		labels   config.LabelsConfig
+		input    string
+		expected string
+	}{
+		{name: "snake case", input: "total_amount", expected: "Total Amount"},
+		{name: "default acronym", input: "consumer_item_id", expected: "Consumer Item ID"},
+		{name: "camel case with acronyms", input: "Item.GTINId", expected: "Item GTIN ID"},
+		{name: "lowercase word", input: "depth_unit_of_measure", expected: "Depth Unit of Measure"},
+		{name: "lowercase word first", input: "of_origin", expected: "Of Origin"},
+		{name: "version suffix", input: "d_item_v3", expected: "D Item V3"},
+		{
+			name:     "configured acronyms replace the defaults",
+			labels:   config.LabelsConfig{Acronyms: []string{"ICA"}},
+			input:    "ica_swedish_accreditation_id",
+			expected: "ICA Swedish Accreditation Id",
+		},
+		{
+			name:     "replacement",
+			labels:   config.LabelsConfig{Replacements: map[string]string{"qty": "Quantity", "uom": "Unit of Measure"}},
+			input:    "net_content_qty_uom",
+			expected: "Net Content Quantity Unit of Measure",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labels := NewLabelGenerator(&config.Config{Labels: tt.labels})
+			assert.Equal(t, tt.expected, labels.Label(tt.input))
+		})
+	}
+}
+
+func TestLabelGenerator_YesNoPrefixes(t *testing.T) {
+	labels := NewLabelGenerator(&config.Config{Labels: config.LabelsConfig{
+		YesNoPrefixes: map[string]string{"is_": "{label}?", "has_": "Has {label}?", "has_no_": "Without {label}"},
+	}})
+
+	tests := []struct {
+		input    string
+		yesno    bool
+		expected string
+		applied  bool
+	}{
+		{input: "is_active", yesno: true, expected: "Active?", applied: true},
+		{input: "IsPrimaryConsumerItem", yesno: true, expected: "Primary Consumer Item?", applied: true},
+		{input: "has_discount", yesno: true, expected: "Has Discount?", applied: true},
+		{input: "has_no_returns", yesno: true, expected: "Without Returns", applied: true},
+		{input: "is_", yesno: true, expected: "Is", applied: false},
+		{input: "is_active", yesno: false, expected: "Is Active", applied: false},
+		{input: "deleted", yesno: true, expected: "Deleted", applied: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			label, applied := labels.FieldLabel(tt.input, tt.yesno)
+			assert.Equal(t, tt.expected, label)
+			assert.Equal(t, tt.applied, applied)
+		})
+	}
+}
+
+func TestLabelGenerator_NestedLabels(t *testing.T) {
+	labels := NewLabelGenerator(&config.Config{})
+
+	assert.Nil(t, labels.GroupLabel("status"))
+	assert.Nil(t, labels.GroupItemLabel("status", false))
+
+	groupLabel := labels.GroupLabel("SupplierInformation.consumer_item.gtin_id")
+	require.NotNil(t, groupLabel)
+	assert.Equal(t, "Supplier Information Consumer Item", *groupLabel)
+
+	itemLabel := labels.GroupItemLabel("SupplierInformation.consumer_item.gtin_id", false)
+	require.NotNil(t, itemLabel)
+	assert.Equal(t, "GTIN ID", *itemLabel)
+}
+
+func TestLabelGenerator_Generators(t *testing.T) {
+	cfg := &config.Config{Labels: config.LabelsConfig{YesNoPrefixes: map[string]string{"is_": "{label}?"}}}
+	model := &models.DbtModel{
+		DbtNode:      models.DbtNode{Name: "sku_prices"},
+		RelationName: "`project.dataset.sku_prices`",
+		Columns: map[string]models.DbtModelColumn{
+			"is_active":       {Name: "is_active", DataType: utils.StringPtr("BOOL")},
+			"item.is_deleted": {Name: "item.is_deleted", DataType: utils.StringPtr("BOOL")},
+			"vat_rate":        {Name: "vat_rate", DataType: utils.StringPtr("NUMERIC")},
+		},
+	}
+
+	dimensions := NewDimensionGenerator(cfg)
+
+	active := model.Columns["is_active"]
+	dimension, err := dimensions.GenerateDimension(model, &active)
+	require.NoError(t, err)
+	require.NotNil(t, dimension.Label)
+	assert.Equal(t, "Active?", *dimension.Label)
+
+	deleted := model.Columns["item.is_deleted"]
+	dimension, err = dimensions.GenerateDimension(model, &deleted)
+	require.NoError(t, err)
+	assert.Nil(t, dimension.Label)
+	require.NotNil(t, dimension.GroupItemLabel)
+	assert.Equal(t, "Deleted?", *dimension.GroupItemLabel)
+
+	vatRate := model.Columns["vat_rate"]
+	measure, err := NewMeasureGenerator(cfg).GenerateColumnMeasure(model, &vatRate, &models.DbtMetaLookerMeasure{Type: enums.MeasureSum})
+	require.NoError(t, err)
+	assert.Equal(t, "Sum VAT Rate", *measure.Label)
+
+	viewLabel := NewExploreGenerator(cfg).getNestedViewLabel(model, "item")
+	assert.Equal(t, "SKU Prices: Item", viewLabel)
+}
+
+func TestLabelGenerator_Rendered(t *testing.T) {
+	outputDir := t.TempDir()
+	model := &models.DbtModel{
+		DbtNode:      models.DbtNode{Name: "d_item_v3"},
+		RelationName: "`project.dataset.d_item_v3`",
+		Path:         "d_item_v3.sql",
+		Columns: map[string]models.DbtModelColumn{
+			"gtin_id":         {Name: "gtin_id", DataType: utils.StringPtr("STRING")},
+			"valid_from_date": {Name: "valid_from_date", DataType: utils.StringPtr("DATE")},
+		},
+	}
+
+	_, err := NewLookMLGenerator(&config.Config{OutputDir: outputDir}).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "d_item_v3.view.lkml")
+	assert.Contains(t, content, "  label: \"D Item V3\"\n")
+	assert.Contains(t, content, "  dimension: gtin_id {\n"+
+		"    type: string\n"+
+		"    sql: ${TABLE}.gtin_id ;;\n"+
+		"    label: \"GTIN ID\"\n"+
+		"  }\n")
+	assert.Contains(t, content, "  dimension_group: valid_from {\n")
+	assert.Contains(t, content, "    label: \"Valid From\"\n")
+}
+
+func TestLabelGenerator_NestedViewFromManifest(t *testing.T) {
+	outputDir := t.TempDir()
+	manifest, catalog := createTestManifest("orders", "marts/orders.sql", "OrderId", map[string]string{
+		"OrderId":          "INT64",
+		"Lines":            "ARRAY<STRUCT<GTINId STRING, IsReturned BOOL>>",
+		"Lines.GTINId":     "STRING",
+		"Lines.IsReturned": "BOOL",
+	})
+	cfg := &config.Config{OutputDir: outputDir, Labels: config.LabelsConfig{YesNoPrefixes: map[string]string{"is_": "{label}?"}}}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), parseTestModels(t, manifest, catalog), GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.Contains(t, content, "  dimension: order_id {\n    primary_key: yes\n    type: number\n    sql: ${TABLE}.OrderId ;;\n    label: \"Order ID\"\n")
+
+	nested := nestedViewContent(t, content, "orders__lines")
+	assert.Contains(t, nested, "  dimension: gtin_id {\n    type: string\n")
+	assert.Contains(t, nested, "    label: \"GTIN ID\"\n")
+	assert.Contains(t, nested, "    label: \"Returned?\"\n")
+	assert.NotContains(t, nested, "label: \"Lines\"")
+}
@@ -0,0 +1,262 @@
+package generators
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+)
+
+// manifestFilename is the LookML project manifest holding localization_settings
+const manifestFilename = "manifest.lkml"
+
+// localizationStrings collects the texts of localization keys during a run
+type localizationStrings struct {
+	defaults     map[string]string            // key -> text in the default locale
+	translations map[string]map[string]string // locale -> key -> text
+	labels       *LabelGenerator              // Labels of fields without one
+}
+
+// newLocalizationStrings creates an empty collection for the configured locales
+func newLocalizationStrings(cfg *config.Config) *localizationStrings {
+	collected := &localizationStrings{
+		defaults:     make(map[string]string),
+		translations: make(map[string]map[string]string),
+		labels:       NewLabelGenerator(cfg),
+	}
+	for _, locale := range cfg.Localization.Locales {
+		if locale != cfg.GetDefaultLocale() {
+			collected.translations[locale] = make(map[string]string)
+		}
+	}
+	return collected
+}
+
+// localizeView replaces the labels, descriptions and group labels of a view and its visible
+// fields with localization keys, collecting the existing texts and their translations
+func (s *localizationStrings) localizeView(cfg *config.Config, view *models.LookMLView) error {
+	prefix := view.Name
+	label, description, err := s.localize(cfg, prefix, view.Name, view.Label, view.Description, view.Translations)
+	if err != nil {
+		return fmt.Errorf("view %s: %w", view.Name, err)
+	}
+	view.Label, view.Description = label, description
+
+	localizeField := func(name string, hidden *bool, label, description **string, parameters *models.LookMLFieldParameters) error {
+		if hidden != nil && *hidden {
+			return nil
+		}
+		localizedLabel, localizedDescription, err := s.localize(cfg, prefix+"."+name, name, *label, *description, parameters.Translations)
+		if err != nil {
+			return fmt.Errorf("field %s.%s: %w", view.Name, name, err)
+		}
+		*label, *description = localizedLabel, localizedDescription
+		return nil
+	}
+
+	// Fields sharing a group label share its key, so Looker still groups them together
+	localizeGroupLabel := func(hidden *bool, groupLabel **string, parameters *models.LookMLFieldParameters) {
+		if (hidden != nil && *hidden) || *groupLabel == nil {
+			return
+		}
+		key := prefix + "." + utils.SanitizeIdentifier(strings.ToLower(**groupLabel)) + ".group_label"
+		s.add(cfg, key, **groupLabel, parameters.Translations, func(t models.DbtMetaLookerTranslation) *string { return t.GroupLabel })
+		*groupLabel = &key
+	}
+
+	for i := range view.Dimensions {
+		dimension := &view.Dimensions[i]
+		if err := localizeField(dimension.Name, dimension.Hidden, &dimension.Label, &dimension.Description, &dimension.LookMLFieldParameters); err != nil {
+			return err
+		}
+		localizeGroupLabel(dimension.Hidden, &dimension.GroupLabel, &dimension.LookMLFieldParameters)
+		if dimension.GroupItemLabel != nil && (dimension.Hidden == nil || !*dimension.Hidden) {
+			key := prefix + "." + dimension.Name + ".group_item_label"
+			s.add(cfg, key, *dimension.GroupItemLabel, dimension.Translations, func(t models.DbtMetaLookerTranslation) *string { return t.GroupItemLabel })
+			dimension.GroupItemLabel = &key
+		}
+	}
+	for i := range view.DimensionGroups {
+		dimensionGroup := &view.DimensionGroups[i]
+		if err := localizeField(dimensionGroup.Name, dimensionGroup.Hidden, &dimensionGroup.Label, &dimensionGroup.Description, &dimensionGroup.LookMLFieldParameters); err != nil {
+			return err
+		}
+		localizeGroupLabel(dimensionGroup.Hidden, &dimensionGroup.GroupLabel, &dimensionGroup.LookMLFieldParameters)
+	}
+	for i := range view.Measures {
+		measure := &view.Measures[i]
+		if err := localizeField(measure.Name, measure.Hidden, &measure.Label, &measure.Description, &measure.LookMLFieldParameters); err != nil {
+			return err
+		}
+		localizeGroupLabel(measure.Hidden, &measure.GroupLabel, &measure.LookMLFieldParameters)
+	}
+	return nil
+}
+
+// localize returns the keys replacing a label and description. A missing label defaults to
+// the name as Looker displays it; a description only gets a key when there is a text for it.
+func (s *localizationStrings) localize(cfg *config.Config, prefix, name string, label, description *string, translations map[string]models.DbtMetaLookerTranslation) (*string, *string, error) {
+	for locale := range translations {
+		if !config.IsValidLocale(locale) {
+			return nil, nil, fmt.Errorf("invalid locale %q in translations", locale)
+		}
+	}
+
+	labelText := s.labels.Label(name)
+	if label != nil {
+		labelText = *label
+	}
+	labelKey := prefix + ".label"
+	s.add(cfg, labelKey, labelText, translations, func(t models.DbtMetaLookerTranslation) *string { return t.Label })
+
+	var descriptionKey *string
+	hasTranslatedDescription := false
+	for _, translation := range translations {
+		hasTranslatedDescription = hasTranslatedDescription || translation.Description != nil
+	}
+	if description != nil || hasTranslatedDescription {
+		descriptionText := ""
+		if description != nil {
+			descriptionText = *description
+		}
+		key := prefix + ".description"
+		s.add(cfg, key, descriptionText, translations, func(t models.DbtMetaLookerTranslation) *string { return t.Description })
+		descriptionKey = &key
+	}
+
+	return &labelKey, descriptionKey, nil
+}
+
+// add records the default text of a key and its translations
+func (s *localizationStrings) add(cfg *config.Config, key, text string, translations map[string]models.DbtMetaLookerTranslation, get func(models.DbtMetaLookerTranslation) *string) {
+	s.defaults[key] = text
+	for locale, translation := range translations {
+		if locale == cfg.GetDefaultLocale() {
+			if value := get(translation); value != nil {
+				s.defaults[key] = *value
+			}
+			continue
+		}
+		if s.translations[locale] == nil {
+			s.translations[locale] = make(map[string]string)
+		}
+		if value := get(translation); value != nil {
+			s.translations[locale][key] = *value
+		}
+	}
+}
+
+// missingTranslations returns, per locale, the sorted keys without a translation
+func (s *localizationStrings) missingTranslations() map[string][]string {
+	if s == nil {
+		return nil
+	}
+
+	missing := make(map[string][]string)
+	for locale, translated := range s.translations {
+		for key := range s.defaults {
+			if _, ok := translated[key]; !ok {
+				missing[locale] = append(missing[locale], key)
+			}
+		}
+		sort.Strings(missing[locale])
+	}
+	return missing
+}
+
+// planLocalizationFiles plans a strings file per locale and a manifest scaffold with the
+// localization settings. Looker only reads localization_settings from the project manifest,
+// so an existing manifest is never overwritten.
+func (g *LookMLGenerator) planLocalizationFiles() ([]outputFile, error) {
+	if g.localization == nil {
+		return nil, nil
+	}
+
+	locales := map[string]map[string]string{g.config.GetDefaultLocale(): g.localization.defaults}
+	for locale, translated := range g.localization.translations {
+		locales[locale] = translated
+	}
+
+	names := make([]string, 0, len(locales))
+	for locale := range locales {
+		names = append(names, locale)
+	}
+	sort.Strings(names)
+
+	files := make([]outputFile, 0, len(names)+1)
+	for _, locale := range names {
+		// encoding/json writes map keys in sorted order
+		data, err := json.MarshalIndent(locales[locale], "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode strings of locale %s: %w", locale, err)
+		}
+		files = append(files, outputFile{
+			Path:    locale + ".strings.json",
+			Content: string(data) + "\n",
+		})
+	}
+
+	if !g.config.Localization.SkipManifest {
+		files = append(files, outputFile{
+			Path:     manifestFilename,
+			Content:  g.localizationSettingsToLookML(),
+			Scaffold: true,
+		})
+	}
+	return files, nil
+}
+
+// localizationSettingsToLookML renders the manifest holding the localization settings
+func (g *LookMLGenerator) localizationSettingsToLookML() string {
+	var builder strings.Builder
+
+	builder.WriteString("# Project manifest for Looker localization. dbt2lookml creates this file once and never\n")
+	builder.WriteString("# overwrites it; move it to the project root if the output directory is not the root.\n")
+	builder.WriteString("localization_settings: {\n")
+	builder.WriteString(fmt.Sprintf("  default_locale: %s\n", g.config.GetDefaultLocale()))
+	builder.WriteString(fmt.Sprintf("  localization_level: %s\n", g.config.GetLocalizationLevel()))
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
+
+// warnManifestWithoutLocalization warns when an existing manifest, which is kept as it is,
+// has no localization_settings
+func (g *LookMLGenerator) warnManifestWithoutLocalization() {
+	filePath := g.config.GetOutputPath(manifestFilename)
+	content, err := os.ReadFile(filePath)
+	if err != nil || strings.Contains(string(content), "localization_settings") {
+		return
+	}
+	g.config.Logger().Warn().Str("file", filePath).Msg("Existing manifest has no localization_settings; add them or set localization.skip_manifest")
+}
+
+// writeLocalizationFiles writes the strings files and manifest after all views have been generated
+func (g *LookMLGenerator) writeLocalizationFiles() error {
+	files, err := g.planLocalizationFiles()
+	if err != nil {
+		return err
+	}
+
+	for i := range files {
+		written, err := g.writeOutputFile(&files[i])
+		if err != nil {
+			return err
+		}
+		if !written && files[i].Path == manifestFilename {
+			g.warnManifestWithoutLocalization()
+		}
+	}
+
+	for locale, keys := range g.localization.missingTranslations() {
+		if len(keys) > 0 {
+			g.config.Logger().Warn().Str("locale", locale).Int("missing", len(keys)).Msg("Missing translations")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package generators
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createLocalizationModel returns an orders model with a view, a measure and a dimension
+// translated into Swedish, and an untranslated dimension
+func createLocalizationModel() *models.DbtModel {
+	status := testColumn("status", "STRING")
+	status.Description = utils.StringPtr("Order status")
+	status.Meta = &models.DbtModelColumnMeta{Looker: &models.DbtMetaLooker{
+		Dimension: &models.DbtMetaLookerDimension{DbtMetaLookerBase: models.DbtMetaLookerBase{Label: utils.StringPtr("Status")}},
+		Translations: map[string]models.DbtMetaLookerTranslation{
+			"sv": {Label: utils.StringPtr("Status"), Description: utils.StringPtr("Orderns status")},
+		},
+	}}
+
+	model := createTestModel("orders", "sales/orders.sql", testColumn("order_id", "INT64"), status)
+	model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{
+		View: &models.DbtMetaLookerBase{Label: utils.StringPtr("Orders")},
+		Translations: map[string]models.DbtMetaLookerTranslation{
+			"sv": {Label: utils.StringPtr("Ordrar")},
+		},
+		Measures: []models.DbtMetaLookerMeasure{{
+			Type: enums.MeasureSum,
+			Name: utils.StringPtr("total_amount"),
+			SQL:  utils.StringPtr("${TABLE}.amount"),
+			Translations: map[string]models.DbtMetaLookerTranslation{
+				"sv": {Label: utils.StringPtr("Totalt belopp")},
+			},
+		}},
+	}}
+	return model
+}
+
+// readStrings returns the generated strings file of locale by key
+func readStrings(t *testing.T, outputDir, locale string) map[string]string {
+	t.Helper()
+
+	var values map[string]string
+	require.NoError(t, json.Unmarshal([]byte(readOutput(t, outputDir, locale+".strings.json")), &values))
+	return values
+}
+
+func TestLocalization_StringsFiles(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:    outputDir,
+		Localization: config.LocalizationConfig{Enabled: true, Locales: []string{"sv", "de"}},
+	}
+
+	result, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createLocalizationModel()}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "sales/orders.view.lkml")
+	assert.Contains(t, content, "  label: \"orders.label\"\n")
+	assert.Contains(t, content, "    label: \"orders.status.label\"\n    description: \"orders.status.description\"\n")
+	assert.Contains(t, content, "    label: \"orders.order_id.label\"\n")
+
+	assert.Equal(t, map[string]string{
+		"orders.label":              "Orders",
+		"orders.order_id.label":     "Order ID",
+		"orders.status.label":       "Status",
+		"orders.status.description": "Order status",
+		"orders.total_amount.label": "Total Amount",
+		"orders.count.label":        "Count",
+	}, readStrings(t, outputDir, "en"))
+
+	assert.Equal(t, map[string]string{
+		"orders.label":              "Ordrar",
+		"orders.status.label":       "Status",
+		"orders.status.description": "Orderns status",
+		"orders.total_amount.label": "Totalt belopp",
+	}, readStrings(t, outputDir, "sv"))
+	assert.Empty(t, readStrings(t, outputDir, "de"))
+
+	assert.Equal(t, "# Project manifest for Looker localization. dbt2lookml creates this file once and never\n"+
+		"# overwrites it; move it to the project root if the output directory is not the root.\n"+
+		"localization_settings: {\n"+
+		"  default_locale: en\n"+
+		"  localization_level: permissive\n"+
+		"}\n", readOutput(t, outputDir, "manifest.lkml"))
+
+	assert.Equal(t, []string{"orders.count.label", "orders.order_id.label"}, result.MissingTranslations["sv"])
+	assert.Len(t, result.MissingTranslations["de"], 6)
+}
+
+func TestLocalization_KeepsExistingManifest(t *testing.T) {
+	outputDir := t.TempDir()
+	manifest := "project_name: \"analytics\"\n"
+	require.NoError(t, os.WriteFile(filepath.Join(outputDir, "manifest.lkml"), []byte(manifest), 0o644))
+
+	cfg := &config.Config{
+		OutputDir:    outputDir,
+		Localization: config.LocalizationConfig{Enabled: true},
+	}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createLocalizationModel()}, GenerationOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, manifest, readOutput(t, outputDir, "manifest.lkml"))
+}
+
+func TestLocalization_GroupLabels(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:    outputDir,
+		Localization: config.LocalizationConfig{Enabled: true, Locales: []string{"sv"}},
+	}
+
+	model := createLocalizationModel()
+	model.Columns["consumer_item.gtin_id"] = models.DbtModelColumn{
+		Name:     "consumer_item.gtin_id",
+		DataType: utils.StringPtr("STRING"),
+		Meta: &models.DbtModelColumnMeta{Looker: &models.DbtMetaLooker{
+			Translations: map[string]models.DbtMetaLookerTranslation{
+				"sv": {GroupLabel: utils.StringPtr("Konsumentartikel"), GroupItemLabel: utils.StringPtr("GTIN-ID")},
+			},
+		}},
+	}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "sales/orders.view.lkml")
+	assert.Contains(t, content, "    group_label: \"orders.consumer_item.group_label\"\n"+
+		"    group_item_label: \"orders.consumer_item__gtin_id.group_item_label\"\n")
+
+	defaults := readStrings(t, outputDir, "en")
+	assert.Equal(t, "Consumer Item", defaults["orders.consumer_item.group_label"])
+	assert.Equal(t, "GTIN ID", defaults["orders.consumer_item__gtin_id.group_item_label"])
+
+	translated := readStrings(t, outputDir, "sv")
+	assert.Equal(t, "Konsumentartikel", translated["orders.consumer_item.group_label"])
+	assert.Equal(t, "GTIN-ID", translated["orders.consumer_item__gtin_id.group_item_label"])
+}
+
+func TestLocalization_Disabled(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir}
+
+	result, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createLocalizationModel()}, GenerationOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, result.MissingTranslations)
+
+	content := readOutput(t, outputDir, "sales/orders.view.lkml")
+	assert.Contains(t, content, "  label: \"Orders\"\n")
+	assert.NoFileExists(t, outputDir+"/en.strings.json")
+	assert.NoFileExists(t, outputDir+"/manifest.lkml")
+}
+
+func TestLocalization_InvalidLocale(t *testing.T) {
+	model := createLocalizationModel()
+	model.Meta.Looker.Translations["../sv"] = models.DbtMetaLookerTranslation{Label: utils.StringPtr("Ordrar")}
+
+	cfg := &config.Config{
+		OutputDir:    t.TempDir(),
+		Localization: config.LocalizationConfig{Enabled: true},
+	}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `view orders: invalid locale "../sv" in translations`)
+}
@@ -14,16 +14,23 @@ var _ MeasureGeneratorInterface = (*MeasureGenerator)(nil)
 
 // MeasureGenerator handles generation of LookML measures
 type MeasureGenerator struct {
-	config *config.Config
+	config             *config.Config
+	dimensionGenerator *DimensionGenerator
 }
 
 // NewMeasureGenerator creates a new MeasureGenerator instance
 func NewMeasureGenerator(cfg *config.Config) *MeasureGenerator {
 	return &MeasureGenerator{
-		config: cfg,
+		config:             cfg,
+		dimensionGenerator: NewDimensionGenerator(cfg),
 	}
 }
 
+// SetNamingStrategy replaces the naming strategy of the generator
+func (g *MeasureGenerator) SetNamingStrategy(naming NamingStrategy) {
+	g.dimensionGenerator.SetNamingStrategy(naming)
+}
+
 // GenerateMeasure generates a LookML measure from measure metadata
 func (g *MeasureGenerator) GenerateMeasure(model *models.DbtModel, measureMeta *models.DbtMetaLookerMeasure) (*models.LookMLMeasure, error) {
 	// Validate measure attributes
@@ -31,10 +38,15 @@ func (g *MeasureGenerator) GenerateMeasure(model *models.DbtModel, measureMeta *
 		return nil, fmt.Errorf("invalid measure attributes: %w", err)
 	}
 
+	sql, err := g.getMeasureSQL(model, measureMeta)
+	if err != nil {
+		return nil, err
+	}
+
 	measure := &models.LookMLMeasure{
 		Name:                 g.getMeasureName(measureMeta),
 		Type:                 measureMeta.Type,
-		SQL:                  g.getMeasureSQL(model, measureMeta),
+		SQL:                  sql,
 		Label:                g.getMeasureLabel(measureMeta),
 		Description:          g.getMeasureDescription(measureMeta),
 		Hidden:               g.getMeasureHidden(measureMeta),
@@ -46,11 +58,98 @@ func (g *MeasureGenerator) GenerateMeasure(model *models.DbtModel, measureMeta *
 		SQLDistinctKey:       measureMeta.SQLDistinctKey,
 		Percentile:           measureMeta.Percentile,
 		Filters:              measureMeta.Filters,
+		BasedOn:              measureMeta.BasedOn,
+		BasedOnTime:          measureMeta.BasedOnTime,
+		Period:               measureMeta.Period,
+		Kind:                 measureMeta.Kind,
+		DrillFields:          measureMeta.DrillFields,
 	}
+	measure.LookMLFieldParameters = measureFieldParameters(measureMeta)
 
 	return measure, nil
 }
 
+// GenerateColumnMeasure generates a measure declared in a column's meta.looker.measures.
+// The measure aggregates the column's dimension unless it sets its own sql, and its name
+// and label default to the measure type plus the dimension (e.g. sum_amount, "Sum Amount").
+func (g *MeasureGenerator) GenerateColumnMeasure(model *models.DbtModel, column *models.DbtModelColumn, measureMeta *models.DbtMetaLookerMeasure) (*models.LookMLMeasure, error) {
+	if measureMeta.Column != nil {
+		return nil, fmt.Errorf("measure on column %s cannot set column", column.Name)
+	}
+	if measureMeta.Expression != nil || measureMeta.Type == enums.MeasurePeriodOverPeriod {
+		return nil, fmt.Errorf("measure on column %s cannot be a derived or period_over_period measure; declare it on the model", column.Name)
+	}
+
+	columnMeasure := *measureMeta
+	if columnMeasure.SQL == nil && columnMeasure.Type != enums.MeasureCount {
+		sql := g.columnReference(model, column)
+		columnMeasure.SQL = &sql
+	}
+
+	dimensionName := g.columnDimensionName(model, column)
+	if columnMeasure.Name == nil {
+		name := fmt.Sprintf("%s_%s", columnMeasure.Type, dimensionName)
+		columnMeasure.Name = &name
+	}
+	if columnMeasure.Label == nil {
+		label := fmt.Sprintf("%s %s", g.dimensionGenerator.labels.Label(string(columnMeasure.Type)), g.columnLabel(column, dimensionName))
+		columnMeasure.Label = &label
+	}
+
+	return g.GenerateMeasure(model, &columnMeasure)
+}
+
+// columnDimensionName returns the name of the field generated for a column
+func (g *MeasureGenerator) columnDimensionName(model *models.DbtModel, column *models.DbtModelColumn) string {
+	if g.dimensionGenerator.shouldBeDimensionGroup(column) {
+		return g.dimensionGenerator.getDimensionGroupName(column)
+	}
+	return g.dimensionGenerator.getDimensionNameForMainView(model, column)
+}
+
+// columnReference returns the SQL a measure uses to aggregate a column: a reference to
+// its dimension, or the column itself for dimension groups, which have no single field
+func (g *MeasureGenerator) columnReference(model *models.DbtModel, column *models.DbtModelColumn) string {
+	if g.dimensionGenerator.shouldBeDimensionGroup(column) {
+		return g.dimensionGenerator.getDimensionSQL(model, column)
+	}
+	return fmt.Sprintf("${%s}", g.dimensionGenerator.getDimensionNameForMainView(model, column))
+}
+
+// columnLabel returns the label of a column's dimension, derived from its name unless set in meta
+func (g *MeasureGenerator) columnLabel(column *models.DbtModelColumn, dimensionName string) string {
+	if g.dimensionGenerator.shouldBeDimensionGroup(column) {
+		return *g.dimensionGenerator.getDimensionGroupFieldLabel(column)
+	}
+	if label := g.dimensionGenerator.getDimensionLabel(column); label != nil {
+		return *label
+	}
+	return g.dimensionGenerator.labels.Label(dimensionName)
+}
+
+// findMeasureColumn finds the main view column a model-level measure refers to
+func (g *MeasureGenerator) findMeasureColumn(model *models.DbtModel, name string) (*models.DbtModelColumn, error) {
+	columnCollections, err := newColumnCollections(g.config, model)
+	if err != nil {
+		return nil, err
+	}
+	mainViewColumns := columnCollections.MainViewColumns
+
+	if column, ok := mainViewColumns[name]; ok {
+		return &column, nil
+	}
+	for columnName, column := range mainViewColumns {
+		if strings.EqualFold(columnName, name) {
+			return &column, nil
+		}
+	}
+
+	if _, ok := model.Columns[name]; ok {
+		return nil, fmt.Errorf("column %s is inside an array and cannot be aggregated in the main view", name)
+	}
+	return nil, fmt.Errorf("column %s not found in model %s", name, model.Name)
+}
+
 // GenerateDefaultCountMeasure generates a default count measure for a model
 func (g *MeasureGenerator) GenerateDefaultCountMeasure(model *models.DbtModel) *models.LookMLMeasure {
 	measureName := "count"
@@ -82,27 +181,34 @@ func (g *MeasureGenerator) getMeasureName(measureMeta *models.DbtMetaLookerMeasu
 	return string(measureMeta.Type)
 }
 
-// getMeasureSQL gets the SQL expression for the measure
-func (g *MeasureGenerator) getMeasureSQL(model *models.DbtModel, measureMeta *models.DbtMetaLookerMeasure) *string {
-	// For most measure types, SQL is optional and Looker will generate it
-	// Only return SQL if it's explicitly needed or provided
+// getMeasureSQL gets the SQL expression for the measure from its sql or column setting
+func (g *MeasureGenerator) getMeasureSQL(model *models.DbtModel, measureMeta *models.DbtMetaLookerMeasure) (*string, error) {
+	if measureMeta.SQL != nil {
+		return measureMeta.SQL, nil
+	}
+
+	if measureMeta.Column != nil {
+		column, err := g.findMeasureColumn(model, *measureMeta.Column)
+		if err != nil {
+			return nil, fmt.Errorf("measure %s: %w", g.getMeasureName(measureMeta), err)
+		}
+		sql := g.columnReference(model, column)
+		return &sql, nil
+	}
 
 	switch measureMeta.Type {
 	case enums.MeasureCount:
 		// Count measures don't need SQL
-		return nil
+		return nil, nil
 	case enums.MeasureCountDistinct:
 		if measureMeta.SQLDistinctKey != nil {
 			sql := fmt.Sprintf("${TABLE}.%s", strings.ToLower(*measureMeta.SQLDistinctKey))
-			return &sql
+			return &sql, nil
 		}
-		return nil
-	case enums.MeasureSum, enums.MeasureAverage, enums.MeasureMin, enums.MeasureMax:
-		// These measures need a column to aggregate
-		// This would need to be specified in the measure metadata
-		return nil
+		return nil, nil
 	default:
-		return nil
+		// Aggregating measures need sql or column; LookMLMeasure.Validate reports the omission
+		return nil, nil
 	}
 }
 
@@ -120,8 +226,7 @@ func (g *MeasureGenerator) getMeasureLabel(measureMeta *models.DbtMetaLookerMeas
 		label = string(measureMeta.Type)
 	}
 
-	// Convert to title case
-	label = g.toTitleCase(label)
+	label = g.dimensionGenerator.labels.Label(label)
 	return &label
 }
 
@@ -134,104 +239,3 @@ func (g *MeasureGenerator) getMeasureDescription(measureMeta *models.DbtMetaLook
 func (g *MeasureGenerator) getMeasureHidden(measureMeta *models.DbtMetaLookerMeasure) *bool {
 	return measureMeta.Hidden
 }
-
-// toTitleCase converts a string to title case
-func (g *MeasureGenerator) toTitleCase(s string) string {
-	// Simple title case conversion
-	if len(s) == 0 {
-		return s
-	}
-
-	// Convert first character to uppercase
-	result := string(s[0])
-	if result >= "a" && result <= "z" {
-		result = string(s[0] - 32)
-	}
-
-	// Add the rest of the string
-	if len(s) > 1 {
-		result += s[1:]
-	}
-
-	return result
-}
-
-// GeneratePrimaryKeyMeasure generates a count distinct measure for primary key columns
-func (g *MeasureGenerator) GeneratePrimaryKeyMeasure(model *models.DbtModel, pkColumn *models.DbtModelColumn) *models.LookMLMeasure {
-	measureName := fmt.Sprintf("count_distinct_%s", *pkColumn.LookMLName)
-	label := fmt.Sprintf("Count Distinct %s", *pkColumn.LookMLName)
-	description := fmt.Sprintf("Count of distinct %s values", *pkColumn.LookMLName)
-
-	sql := fmt.Sprintf("${TABLE}.%s", strings.ToLower(pkColumn.Name))
-
-	return &models.LookMLMeasure{
-		Name:        measureName,
-		Type:        enums.MeasureCountDistinct,
-		SQL:         &sql,
-		Label:       &label,
-		Description: &description,
-	}
-}
-
-// GenerateNumericMeasures generates sum/average measures for numeric columns
-func (g *MeasureGenerator) GenerateNumericMeasures(model *models.DbtModel, column *models.DbtModelColumn) []*models.LookMLMeasure {
-	if column.DataType == nil {
-		return nil
-	}
-
-	// Check if column is numeric
-	dataType := *column.DataType
-	if !g.isNumericType(dataType) {
-		return nil
-	}
-
-	var measures []*models.LookMLMeasure
-	columnName := *column.LookMLName
-	sql := fmt.Sprintf("${TABLE}.%s", strings.ToLower(column.Name))
-
-	// Generate sum measure
-	sumName := fmt.Sprintf("sum_%s", columnName)
-	sumLabel := fmt.Sprintf("Sum %s", columnName)
-	sumDescription := fmt.Sprintf("Sum of %s values", columnName)
-
-	sumMeasure := &models.LookMLMeasure{
-		Name:        sumName,
-		Type:        enums.MeasureSum,
-		SQL:         &sql,
-		Label:       &sumLabel,
-		Description: &sumDescription,
-	}
-	measures = append(measures, sumMeasure)
-
-	// Generate average measure
-	avgName := fmt.Sprintf("avg_%s", columnName)
-	avgLabel := fmt.Sprintf("Average %s", columnName)
-	avgDescription := fmt.Sprintf("Average of %s values", columnName)
-
-	avgMeasure := &models.LookMLMeasure{
-		Name:        avgName,
-		Type:        enums.MeasureAverage,
-		SQL:         &sql,
-		Label:       &avgLabel,
-		Description: &avgDescription,
-	}
-	measures = append(measures, avgMeasure)
-
-	return measures
-}
-
-// isNumericType checks if a data type is numeric
-func (g *MeasureGenerator) isNumericType(dataType string) bool {
-	numericTypes := []string{
-		"INT64", "INTEGER", "FLOAT", "FLOAT64",
-		"NUMERIC", "DECIMAL", "BIGNUMERIC",
-	}
-
-	for _, numType := range numericTypes {
-		if dataType == numType {
-			return true
-		}
-	}
-
-	return false
-}
@@ -173,7 +173,7 @@ func TestMeasureGenerator_Precision(t *testing.T) {
 	}
 }
 
-// TestMeasureGenerator_SQLDistinctKey tests sql_distinct_key for count_distinct measures
+// TestMeasureGenerator_SQLDistinctKey tests sql_distinct_key for count_distinct and *_distinct measures
 func TestMeasureGenerator_SQLDistinctKey(t *testing.T) {
 	cfg := &config.Config{}
 	generator := NewMeasureGenerator(cfg)
@@ -203,6 +203,20 @@ func TestMeasureGenerator_SQLDistinctKey(t *testing.T) {
 				assert.Equal(t, "${TABLE}.customer_id", *measure.SQLDistinctKey)
 			},
 		},
+		{
+			name: "sum_distinct with sql_distinct_key",
+			measureMeta: &models.DbtMetaLookerMeasure{
+				Name:           measureStringTestPtr("total_shipping"),
+				Type:           enums.MeasureSumDistinct,
+				SQL:            measureStringTestPtr("${TABLE}.shipping_cost"),
+				SQLDistinctKey: measureStringTestPtr("${TABLE}.order_id"),
+			},
+			expectError: false,
+			checkFunc: func(t *testing.T, measure *models.LookMLMeasure) {
+				require.NotNil(t, measure.SQLDistinctKey)
+				assert.Equal(t, "${TABLE}.order_id", *measure.SQLDistinctKey)
+			},
+		},
 		{
 			name: "sum with sql_distinct_key should error",
 			measureMeta: &models.DbtMetaLookerMeasure{
@@ -0,0 +1,148 @@
+package generators
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+)
+
+// GenerateTemplateMeasures generates the measures of the configured measure templates for
+// the main view columns of a model, ordered by column and then by template. Models and
+// columns opt out with meta.looker.auto_measures: false.
+func (g *MeasureGenerator) GenerateTemplateMeasures(model *models.DbtModel) ([]*models.LookMLMeasure, error) {
+	if len(g.config.MeasureTemplates) == 0 {
+		return nil, nil
+	}
+	if model.Meta != nil && autoMeasuresDisabled(model.Meta.Looker) {
+		return nil, nil
+	}
+
+	columnCollections, err := newColumnCollections(g.config, model)
+	if err != nil {
+		return nil, err
+	}
+	mainViewColumns := columnCollections.MainViewColumns
+	columnNames := make([]string, 0, len(mainViewColumns))
+	for columnName := range mainViewColumns {
+		columnNames = append(columnNames, columnName)
+	}
+	sort.Strings(columnNames)
+
+	var measures []*models.LookMLMeasure
+	for _, columnName := range columnNames {
+		column := mainViewColumns[columnName]
+		if column.Meta != nil && autoMeasuresDisabled(column.Meta.Looker) {
+			continue
+		}
+
+		for _, template := range g.config.MeasureTemplates {
+			if !templateMatchesColumn(template, &column) {
+				continue
+			}
+
+			measure, err := g.generateTemplateMeasure(model, &column, template)
+			if err != nil {
+				return nil, fmt.Errorf("measure template %s on column %s: %w", template.Type, columnName, err)
+			}
+			measures = append(measures, measure)
+		}
+	}
+
+	return measures, nil
+}
+
+// generateTemplateMeasure generates the measure a template declares for a column
+func (g *MeasureGenerator) generateTemplateMeasure(model *models.DbtModel, column *models.DbtModelColumn, template config.MeasureTemplateConfig) (*models.LookMLMeasure, error) {
+	measureMeta := models.DbtMetaLookerMeasure{
+		Type: enums.LookerMeasureType(template.Type),
+	}
+
+	dimensionName := g.columnDimensionName(model, column)
+	replacer := strings.NewReplacer(
+		"{type}", template.Type,
+		"{column}", dimensionName,
+		"{label}", g.columnLabel(column, dimensionName),
+	)
+
+	if template.Name != "" {
+		measureMeta.Name = utils.StringPtr(replacer.Replace(template.Name))
+	}
+	if template.Label != "" {
+		measureMeta.Label = utils.StringPtr(replacer.Replace(template.Label))
+	}
+	if template.Description != "" {
+		measureMeta.Description = utils.StringPtr(replacer.Replace(template.Description))
+	}
+	if template.Hidden {
+		measureMeta.Hidden = utils.BoolPtr(true)
+	}
+
+	if template.ValueFormatName != "" {
+		valueFormat := enums.LookerValueFormatName(template.ValueFormatName)
+		measureMeta.ValueFormatName = &valueFormat
+	} else if template.InheritValueFormat {
+		measureMeta.ValueFormatName = g.dimensionGenerator.getDimensionValueFormat(column)
+	}
+
+	return g.GenerateColumnMeasure(model, column, &measureMeta)
+}
+
+// templateMatchesColumn checks a column against a template's data types, column name
+// patterns and primary key requirement. All conditions the template sets must hold.
+func templateMatchesColumn(template config.MeasureTemplateConfig, column *models.DbtModelColumn) bool {
+	if template.PrimaryKey && !column.IsPrimaryKey {
+		return false
+	}
+
+	if len(template.DataTypes) > 0 {
+		if column.DataType == nil || !containsString(template.DataTypes, baseDataType(*column.DataType)) {
+			return false
+		}
+	}
+
+	if len(template.Columns) > 0 {
+		name := strings.ToLower(column.Name)
+		matched := false
+		for _, pattern := range template.Columns {
+			if ok, _ := path.Match(pattern, name); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// baseDataType strips parameters from a BigQuery type, e.g. NUMERIC(10, 2) -> NUMERIC
+func baseDataType(dataType string) string {
+	dataType = strings.ToUpper(strings.TrimSpace(dataType))
+	if index := strings.IndexAny(dataType, "(<"); index >= 0 {
+		dataType = strings.TrimSpace(dataType[:index])
+	}
+	return dataType
+}
+
+// autoMeasuresDisabled reports whether meta opts out of measure templates
+func autoMeasuresDisabled(looker *models.DbtMetaLooker) bool {
+	return looker != nil && looker.AutoMeasures != nil && !*looker.AutoMeasures
+}
+
+// containsString checks whether a slice contains a string
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
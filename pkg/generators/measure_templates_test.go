@@ -0,0 +1,201 @@
+package generators
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTemplateModel returns an orders model with a primary key, formatted and unformatted
+// amounts, a quantity and a date
+func createTemplateModel() *models.DbtModel {
+	usd := enums.FormatUSD
+	orderID := testColumn("order_id", "STRING")
+	orderID.IsPrimaryKey = true
+	netAmount := testColumn("net_amount", "NUMERIC(18, 2)")
+	netAmount.Meta = &models.DbtModelColumnMeta{Looker: &models.DbtMetaLooker{
+		Dimension: &models.DbtMetaLookerDimension{ValueFormatName: &usd},
+	}}
+
+	return createTestModel("orders", "marts/orders.sql",
+		orderID,
+		netAmount,
+		testColumn("tax_amount", "NUMERIC"),
+		testColumn("quantity", "INT64"),
+		testColumn("created_date", "DATE"),
+	)
+}
+
+// measureNames returns the names of measures in order
+func measureNames(measures []*models.LookMLMeasure) []string {
+	names := make([]string, 0, len(measures))
+	for _, measure := range measures {
+		names = append(names, measure.Name)
+	}
+	return names
+}
+
+func TestMeasureGenerator_GenerateTemplateMeasures(t *testing.T) {
+	sumAmounts := config.MeasureTemplateConfig{Type: "sum", DataTypes: []string{"NUMERIC"}, Columns: []string{"*_amount"}, InheritValueFormat: true}
+	avgAmounts := config.MeasureTemplateConfig{Type: "average", Columns: []string{"*_amount"}, Name: "avg_{column}", Label: "Avg {label}", Hidden: true}
+	distinctKeys := config.MeasureTemplateConfig{Type: "count_distinct", PrimaryKey: true, Description: "Distinct {column} values"}
+	dateBounds := []config.MeasureTemplateConfig{
+		{Type: "min", DataTypes: []string{"DATE"}, Name: "first_{column}"},
+		{Type: "max", DataTypes: []string{"DATE"}, Name: "last_{column}"},
+	}
+
+	tests := []struct {
+		name      string
+		templates []config.MeasureTemplateConfig
+		modify    func(model *models.DbtModel)
+		expected  []string
+	}{
+		{
+			name:     "no templates",
+			expected: []string{},
+		},
+		{
+			name:      "data type and column pattern",
+			templates: []config.MeasureTemplateConfig{sumAmounts, avgAmounts},
+			expected:  []string{"sum_net_amount", "avg_net_amount", "sum_tax_amount", "avg_tax_amount"},
+		},
+		{
+			name:      "primary key",
+			templates: []config.MeasureTemplateConfig{distinctKeys},
+			expected:  []string{"count_distinct_order_id"},
+		},
+		{
+			name:      "date columns use the dimension group name",
+			templates: dateBounds,
+			expected:  []string{"first_created", "last_created"},
+		},
+		{
+			name:      "model opt-out",
+			templates: []config.MeasureTemplateConfig{sumAmounts},
+			modify: func(model *models.DbtModel) {
+				model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{AutoMeasures: utils.BoolPtr(false)}}
+			},
+			expected: []string{},
+		},
+		{
+			name:      "column opt-out",
+			templates: []config.MeasureTemplateConfig{sumAmounts},
+			modify: func(model *models.DbtModel) {
+				column := model.Columns["tax_amount"]
+				column.Meta = &models.DbtModelColumnMeta{Looker: &models.DbtMetaLooker{AutoMeasures: utils.BoolPtr(false)}}
+				model.Columns["tax_amount"] = column
+			},
+			expected: []string{"sum_net_amount"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := createTemplateModel()
+			if tt.modify != nil {
+				tt.modify(model)
+			}
+
+			generator := NewMeasureGenerator(&config.Config{MeasureTemplates: tt.templates})
+			measures, err := generator.GenerateTemplateMeasures(model)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, measureNames(measures))
+		})
+	}
+}
+
+func TestMeasureGenerator_TemplateAttributes(t *testing.T) {
+	cfg := &config.Config{MeasureTemplates: []config.MeasureTemplateConfig{
+		{Type: "sum", Columns: []string{"net_amount"}, InheritValueFormat: true},
+		{Type: "average", Columns: []string{"*_amount"}, Name: "avg_{column}", Label: "Avg {label}", Hidden: true, ValueFormatName: "decimal_2"},
+		{Type: "count_distinct", PrimaryKey: true, Description: "Distinct {column} values"},
+	}}
+
+	measures, err := NewMeasureGenerator(cfg).GenerateTemplateMeasures(createTemplateModel())
+	require.NoError(t, err)
+	require.Len(t, measures, 4)
+
+	sum := measures[0]
+	assert.Equal(t, "sum_net_amount", sum.Name)
+	assert.Equal(t, enums.MeasureSum, sum.Type)
+	assert.Equal(t, "${net_amount}", *sum.SQL)
+	assert.Equal(t, "Sum Net Amount", *sum.Label)
+	require.NotNil(t, sum.ValueFormatName)
+	assert.Equal(t, enums.FormatUSD, *sum.ValueFormatName)
+
+	avg := measures[1]
+	assert.Equal(t, "avg_net_amount", avg.Name)
+	assert.Equal(t, "Avg Net Amount", *avg.Label)
+	assert.True(t, *avg.Hidden)
+	assert.Equal(t, enums.LookerValueFormatName("decimal_2"), *avg.ValueFormatName)
+
+	distinct := measures[2]
+	assert.Equal(t, "count_distinct_order_id", distinct.Name)
+	assert.Equal(t, "Distinct order_id values", *distinct.Description)
+	assert.Nil(t, distinct.ValueFormatName)
+
+	// tax_amount has no dimension value format to inherit
+	assert.Equal(t, "avg_tax_amount", measures[3].Name)
+}
+
+func TestMeasureTemplates_Rendered(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir: outputDir,
+		MeasureTemplates: []config.MeasureTemplateConfig{
+			{Type: "sum", DataTypes: []string{"NUMERIC"}, Columns: []string{"*_amount"}, InheritValueFormat: true},
+		},
+	}
+
+	model := createTemplateModel()
+	// Measures declared in meta win over template measures with the same name
+	model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{Measures: []models.DbtMetaLookerMeasure{
+		{Name: utils.StringPtr("sum_tax_amount"), Type: enums.MeasureSum, SQL: utils.StringPtr("${tax_amount} * -1")},
+	}}}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.Contains(t, content, "  measure: sum_net_amount {\n"+
+		"    type: sum\n"+
+		"    sql: ${net_amount} ;;\n"+
+		"    label: \"Sum Net Amount\"\n"+
+		"    value_format_name: usd\n"+
+		"    drill_fields: [detail*]\n"+
+		"  }\n")
+	assert.Contains(t, content, "sql: ${tax_amount} * -1 ;;")
+	assert.Equal(t, 1, strings.Count(content, "measure: sum_tax_amount {"))
+	assert.Contains(t, content, "  measure: count {")
+}
+
+func TestMeasureTemplates_PrimaryKeyFromManifest(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir: outputDir,
+		MeasureTemplates: []config.MeasureTemplateConfig{
+			{Type: "count_distinct", PrimaryKey: true},
+		},
+	}
+	manifest, catalog := createTestManifest("orders", "marts/orders.sql", "OrderId", map[string]string{
+		"OrderId":   "STRING",
+		"NetAmount": "NUMERIC",
+	})
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), parseTestModels(t, manifest, catalog), GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.Contains(t, content, "  measure: count_distinct_order_id {\n"+
+		"    type: count_distinct\n"+
+		"    sql: ${order_id} ;;\n"+
+		"    label: \"Count Distinct Order ID\"\n")
+	assert.NotContains(t, content, "count_distinct_net_amount")
+}
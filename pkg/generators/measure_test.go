@@ -1,6 +1,7 @@
 package generators
 
 import (
+	"context"
 	"testing"
 
 	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
@@ -480,3 +481,196 @@ func measureIntPtr(i int) *int {
 func valueFormatPtr(vf enums.LookerValueFormatName) *enums.LookerValueFormatName {
 	return &vf
 }
+
+// createMeasureSQLModel returns an orders model with a number, a date and an array column
+func createMeasureSQLModel() *models.DbtModel {
+	return createTestModel("orders", "",
+		testColumn("amount", "NUMERIC"),
+		testColumn("created_date", "DATE"),
+		testColumn("lines", "ARRAY<STRUCT<quantity INT64>>"),
+		testColumn("lines.quantity", "INT64"),
+	)
+}
+
+func TestMeasureGenerator_MeasureSQL(t *testing.T) {
+	generator := NewMeasureGenerator(&config.Config{})
+
+	tests := []struct {
+		name        string
+		measureMeta *models.DbtMetaLookerMeasure
+		expectedSQL *string
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:        "explicit sql",
+			measureMeta: &models.DbtMetaLookerMeasure{Type: enums.MeasureSum, SQL: measureStringPtr("${amount} * 2")},
+			expectedSQL: measureStringPtr("${amount} * 2"),
+		},
+		{
+			name:        "column references its dimension",
+			measureMeta: &models.DbtMetaLookerMeasure{Type: enums.MeasureSum, Column: measureStringPtr("AMOUNT")},
+			expectedSQL: measureStringPtr("${amount}"),
+		},
+		{
+			name:        "date column uses the column itself",
+			measureMeta: &models.DbtMetaLookerMeasure{Type: enums.MeasureMax, Column: measureStringPtr("created_date")},
+			expectedSQL: measureStringPtr("${TABLE}.created_date"),
+		},
+		{
+			name:        "sum without sql or column",
+			measureMeta: &models.DbtMetaLookerMeasure{Type: enums.MeasureSum},
+			expectedSQL: nil,
+		},
+		{
+			name:        "unknown column",
+			measureMeta: &models.DbtMetaLookerMeasure{Type: enums.MeasureSum, Column: measureStringPtr("total")},
+			expectError: true,
+			errorMsg:    "column total not found in model orders",
+		},
+		{
+			name:        "column inside an array",
+			measureMeta: &models.DbtMetaLookerMeasure{Type: enums.MeasureSum, Column: measureStringPtr("lines.quantity")},
+			expectError: true,
+			errorMsg:    "inside an array",
+		},
+		{
+			name: "sql and column",
+			measureMeta: &models.DbtMetaLookerMeasure{
+				Type:   enums.MeasureSum,
+				SQL:    measureStringPtr("${amount}"),
+				Column: measureStringPtr("amount"),
+			},
+			expectError: true,
+			errorMsg:    "sql and column cannot both be set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := generator.GenerateMeasure(createMeasureSQLModel(), tt.measureMeta)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				if tt.errorMsg != "" {
+					assert.Contains(t, err.Error(), tt.errorMsg)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSQL, result.SQL)
+		})
+	}
+}
+
+func TestMeasureGenerator_GenerateColumnMeasure(t *testing.T) {
+	generator := NewMeasureGenerator(&config.Config{})
+	model := createMeasureSQLModel()
+
+	tests := []struct {
+		name          string
+		column        string
+		measureMeta   *models.DbtMetaLookerMeasure
+		expectedName  string
+		expectedLabel string
+		expectedSQL   *string
+		expectError   bool
+	}{
+		{
+			name:          "generated name and label",
+			column:        "amount",
+			measureMeta:   &models.DbtMetaLookerMeasure{Type: enums.MeasureAverage},
+			expectedName:  "average_amount",
+			expectedLabel: "Average Amount",
+			expectedSQL:   measureStringPtr("${amount}"),
+		},
+		{
+			name:          "multi word type",
+			column:        "amount",
+			measureMeta:   &models.DbtMetaLookerMeasure{Type: enums.MeasureSumDistinct},
+			expectedName:  "sum_distinct_amount",
+			expectedLabel: "Sum Distinct Amount",
+			expectedSQL:   measureStringPtr("${amount}"),
+		},
+		{
+			name:   "explicit name, label and sql",
+			column: "amount",
+			measureMeta: &models.DbtMetaLookerMeasure{
+				DbtMetaLookerBase: models.DbtMetaLookerBase{Label: measureStringPtr("Revenue")},
+				Type:              enums.MeasureSum,
+				Name:              measureStringPtr("revenue"),
+				SQL:               measureStringPtr("${amount} / 100"),
+			},
+			expectedName:  "revenue",
+			expectedLabel: "Revenue",
+			expectedSQL:   measureStringPtr("${amount} / 100"),
+		},
+		{
+			name:          "date column",
+			column:        "created_date",
+			measureMeta:   &models.DbtMetaLookerMeasure{Type: enums.MeasureMin},
+			expectedName:  "min_created",
+			expectedLabel: "Min Created",
+			expectedSQL:   measureStringPtr("${TABLE}.created_date"),
+		},
+		{
+			name:        "column cannot be overridden",
+			column:      "amount",
+			measureMeta: &models.DbtMetaLookerMeasure{Type: enums.MeasureSum, Column: measureStringPtr("other")},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			column := model.Columns[tt.column]
+			result, err := generator.GenerateColumnMeasure(model, &column, tt.measureMeta)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, result.Name)
+			require.NotNil(t, result.Label)
+			assert.Equal(t, tt.expectedLabel, *result.Label)
+			assert.Equal(t, tt.expectedSQL, result.SQL)
+			assert.NoError(t, result.Validate())
+		})
+	}
+}
+
+func TestMeasures_Rendered(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir}
+
+	model := createMeasureSQLModel()
+	model.Path = "marts/orders.sql"
+	amount := model.Columns["amount"]
+	amount.Meta = &models.DbtModelColumnMeta{Looker: &models.DbtMetaLooker{Measures: []models.DbtMetaLookerMeasure{
+		{
+			Type:            enums.MeasureSum,
+			ValueFormatName: valueFormatPtr(enums.FormatUSD),
+			GroupLabel:      measureStringPtr("Revenue"),
+			Filters:         []models.DbtMetaLookerMeasureFilter{{FilterDimension: "status", FilterExpression: "complete"}},
+		},
+	}}}
+	model.Columns["amount"] = amount
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.Contains(t, content, "  measure: sum_amount {\n"+
+		"    type: sum\n"+
+		"    sql: ${amount} ;;\n"+
+		"    label: \"Sum Amount\"\n"+
+		"    group_label: \"Revenue\"\n"+
+		"    value_format_name: usd\n"+
+		"    filters: [status: \"complete\"]\n"+
+		"    drill_fields: [detail*]\n"+
+		"  }\n")
+}
@@ -0,0 +1,563 @@
+package generators
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/parsers"
+)
+
+const (
+	// mergeStateFilename is the sidecar file in the output directory that records
+	// which fields dbt2lookml generated, so merge mode can tell them from hand edits
+	mergeStateFilename = ".dbt2lookml-state.json"
+
+	// mergeStateVersion is bumped when the state file format changes
+	mergeStateVersion = 1
+
+	// droppedFieldComment is written above fields commented out by merge mode
+	droppedFieldComment = "# Column removed from the dbt model - commented out by dbt2lookml"
+)
+
+// mergeState records the fields generated into each output file by the previous run
+type mergeState struct {
+	Version int                        `json:"version"`
+	Files   map[string]*mergeFileState `json:"files"`
+
+	// missing marks a state created because no state file exists yet. Existing fields are
+	// then adopted by name, as there is no record of who wrote them.
+	missing bool
+}
+
+// mergeFileState records hashes of the generated blocks of one file.
+// A field is owned by dbt2lookml while its current text still matches the recorded hash.
+type mergeFileState struct {
+	// Views maps view name -> field or parameter key (e.g. "dimension:id", "param:label") -> hash
+	Views map[string]map[string]string `json:"views,omitempty"`
+
+	// Explores maps explore name -> hash
+	Explores map[string]string `json:"explores,omitempty"`
+
+	// Deleted maps view name -> keys of generated fields deleted by hand, which are not added back
+	Deleted map[string][]string `json:"deleted,omitempty"`
+}
+
+// textEdit replaces content[Start:End] with Text
+type textEdit struct {
+	Start int
+	End   int
+	Text  string
+}
+
+// loadMergeState reads the merge state file, returning an empty state when it does not exist yet
+func loadMergeState(path string) (*mergeState, error) {
+	state := &mergeState{
+		Version: mergeStateVersion,
+		Files:   make(map[string]*mergeFileState),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			state.missing = true
+			return state, nil
+		}
+		return nil, fmt.Errorf("failed to read merge state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse merge state %s: %w", path, err)
+	}
+	if state.Version != mergeStateVersion {
+		return nil, fmt.Errorf("unsupported merge state version %d in %s", state.Version, path)
+	}
+	if state.Files == nil {
+		state.Files = make(map[string]*mergeFileState)
+	}
+
+	return state, nil
+}
+
+// save writes the merge state file
+func (s *mergeState) save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode merge state: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), filePermissions); err != nil {
+		return fmt.Errorf("failed to write merge state: %w", err)
+	}
+
+	return nil
+}
+
+// loadedMergeState returns the merge state, loading it on first use
+func (g *LookMLGenerator) loadedMergeState() (*mergeState, error) {
+	if g.mergeState == nil {
+		state, err := loadMergeState(g.config.GetOutputPath(mergeStateFilename))
+		if err != nil {
+			return nil, err
+		}
+		g.mergeState = state
+	}
+	return g.mergeState, nil
+}
+
+// mergeOutputFile renders a planned file for merge mode. New files are rendered as usual;
+// existing files only get their generated fields updated, added or dropped, while
+// hand-written fields, parameters and comments are kept as they are.
+func (g *LookMLGenerator) mergeOutputFile(file *outputFile, filePath string) (string, error) {
+	state, err := g.loadedMergeState()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to read %s: %w", file.Path, err)
+		}
+
+		content, err := g.renderOutputFile(file)
+		if err != nil {
+			return "", err
+		}
+		state.Files[file.Path] = g.generatedFileState(file)
+		return content, nil
+	}
+
+	previous := state.Files[file.Path]
+	if previous == nil {
+		previous = &mergeFileState{}
+	}
+
+	content, next, err := g.mergeContent(file, string(data), previous, state.missing)
+	if err != nil {
+		return "", err
+	}
+	state.Files[file.Path] = next
+
+	return content, nil
+}
+
+// generatedFileState records every generated block of a file as owned
+func (g *LookMLGenerator) generatedFileState(file *outputFile) *mergeFileState {
+	fileState := &mergeFileState{
+		Views:    make(map[string]map[string]string),
+		Explores: make(map[string]string),
+	}
+
+	for _, view := range file.Views {
+		fields := make(map[string]string)
+		for _, field := range g.viewMergeBlocks(view) {
+			fields[field.key()] = blockHash(field.Text)
+		}
+		fileState.Views[view.Name] = fields
+	}
+
+	for _, explore := range file.Explores {
+		if rendered, err := g.exploreToLookML(explore); err == nil {
+			fileState.Explores[explore.Name] = blockHash(exploreBlockText(rendered))
+		}
+	}
+
+	return fileState
+}
+
+// viewMergeBlocks returns the generated parameters and fields of a view that merge mode owns
+func (g *LookMLGenerator) viewMergeBlocks(view *models.LookMLView) []renderedBlock {
+	return append(g.viewParamBlocks(view), g.viewFieldBlocks(view)...)
+}
+
+// mergeContent applies the planned views and explores to existing file content. Without
+// recorded ownership, adopt takes over existing blocks that share a name with generated ones.
+func (g *LookMLGenerator) mergeContent(file *outputFile, existing string, previous *mergeFileState, adopt bool) (string, *mergeFileState, error) {
+	next := &mergeFileState{
+		Views:    make(map[string]map[string]string),
+		Explores: make(map[string]string),
+		Deleted:  make(map[string][]string),
+	}
+
+	blocks := parsers.ParseLookMLBlocks(existing)
+	planned := make(map[string]bool, len(file.Views))
+	var edits []textEdit
+	var appended strings.Builder
+
+	for _, view := range file.Views {
+		planned[view.Name] = true
+		existingView := findLookMLBlock(blocks, "view", view.Name)
+		if existingView == nil {
+			rendered, err := g.viewToLookML(view)
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to convert view %s to LookML: %w", view.Name, err)
+			}
+			appended.WriteString("\n" + rendered)
+
+			fields := make(map[string]string)
+			for _, field := range g.viewMergeBlocks(view) {
+				fields[field.key()] = blockHash(field.Text)
+			}
+			next.Views[view.Name] = fields
+			continue
+		}
+
+		viewEdits, fields, deleted := g.mergeViewFields(file, existing, existingView, view.Name, g.viewMergeBlocks(view), previous, adopt)
+		edits = append(edits, viewEdits...)
+		next.Views[view.Name] = fields
+		if len(deleted) > 0 {
+			next.Deleted[view.Name] = deleted
+		}
+	}
+
+	// Views generated previously that are no longer planned, such as the nested views of
+	// removed arrays
+	previousViews := make([]string, 0, len(previous.Views))
+	for viewName := range previous.Views {
+		previousViews = append(previousViews, viewName)
+	}
+	sort.Strings(previousViews)
+	for _, viewName := range previousViews {
+		existingView := findLookMLBlock(blocks, "view", viewName)
+		if planned[viewName] || existingView == nil {
+			continue
+		}
+		if !ownsViewBlock(existing, existingView, previous.Views[viewName]) {
+			g.diagnostics.Warn(file.Model, WarningMergeConflict, fmt.Sprintf("%s: view %s is no longer generated but was edited by hand; keeping it", file.Path, viewName))
+			continue
+		}
+		edits = append(edits, g.dropBlockEdit(existing, existingView))
+		g.config.Logger().Debug().Str("file", file.Path).Str("view", viewName).Msg("Dropped view no longer in dbt model")
+	}
+
+	for _, explore := range file.Explores {
+		rendered, err := g.exploreToLookML(explore)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to convert explore %s to LookML: %w", explore.Name, err)
+		}
+		generated := exploreBlockText(rendered)
+		generatedHash := blockHash(generated)
+
+		existingExplore := findLookMLBlock(blocks, "explore", explore.Name)
+		if existingExplore == nil {
+			appended.WriteString(rendered)
+			next.Explores[explore.Name] = generatedHash
+			continue
+		}
+
+		currentHash := blockHash(existingExplore.Text(existing))
+		previousHash, owned := previous.Explores[explore.Name]
+		switch {
+		case currentHash == generatedHash:
+			next.Explores[explore.Name] = generatedHash
+		case owned && currentHash == previousHash:
+			edits = append(edits, textEdit{Start: existingExplore.Start, End: existingExplore.End, Text: generated})
+			next.Explores[explore.Name] = generatedHash
+		case owned:
+			g.diagnostics.Warn(file.Model, WarningMergeConflict, fmt.Sprintf("%s: explore %s was edited by hand; keeping the existing definition", file.Path, explore.Name))
+			next.Explores[explore.Name] = previousHash
+		case adopt:
+			edits = append(edits, textEdit{Start: existingExplore.Start, End: existingExplore.End, Text: generated})
+			next.Explores[explore.Name] = generatedHash
+			g.diagnostics.Warn(file.Model, WarningMergeAdopted, fmt.Sprintf("%s: explore %s has no merge state; adopting and regenerating it", file.Path, explore.Name))
+		default:
+			g.diagnostics.Warn(file.Model, WarningMergeConflict, fmt.Sprintf("%s: explore %s was not generated by dbt2lookml; keeping the existing definition", file.Path, explore.Name))
+		}
+	}
+
+	content := applyTextEdits(existing, edits)
+	content = addMissingIncludes(content, file.Includes)
+	if appended.Len() > 0 {
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		content += appended.String()
+	}
+
+	return content, next, nil
+}
+
+// mergeViewFields updates the fields and parameters of an existing view block and returns the
+// edits and the new ownership hashes for the view. Generated fields that were deleted by hand
+// are returned as deleted and stay out of the view.
+func (g *LookMLGenerator) mergeViewFields(file *outputFile, existing string, existingView *parsers.LookMLBlock, viewName string, generatedFields []renderedBlock, previousFile *mergeFileState, adopt bool) ([]textEdit, map[string]string, []string) {
+	var edits []textEdit
+	var paramInserts, inserts, dimensionInserts strings.Builder
+	var deleted []string
+	owned := make(map[string]string)
+	generatedKeys := make(map[string]bool, len(generatedFields))
+	previous := previousFile.Views[viewName]
+
+	previouslyDeleted := make(map[string]bool)
+	for _, key := range previousFile.Deleted[viewName] {
+		previouslyDeleted[key] = true
+	}
+
+	// States written before view parameters were tracked record fields only
+	adoptParams := adopt || !recordsParams(previous)
+
+	existingBlocks := existingViewBlocks(existingView)
+	for _, field := range generatedFields {
+		key := field.key()
+		generatedKeys[key] = true
+		generatedHash := blockHash(field.Text)
+
+		current := findLookMLBlock(existingBlocks, field.Kind, field.Name)
+		if current == nil {
+			// A field generated before and missing now was deleted by hand
+			if _, wasOwned := previous[key]; wasOwned || previouslyDeleted[key] {
+				deleted = append(deleted, key)
+				continue
+			}
+			switch field.Kind {
+			case viewParamKind:
+				paramInserts.WriteString("\n" + strings.TrimRight(field.Text, "\n"))
+			case "measure", "set":
+				inserts.WriteString(field.Text)
+			default:
+				dimensionInserts.WriteString("\n\n" + strings.TrimRight(field.Text, "\n"))
+			}
+			owned[key] = generatedHash
+			continue
+		}
+
+		currentHash := blockHash(current.Text(existing))
+		previousHash, wasOwned := previous[key]
+		switch {
+		case currentHash == generatedHash:
+			// Unchanged (or identical to what would be generated) - adopt it
+			owned[key] = generatedHash
+		case wasOwned && currentHash == previousHash:
+			edits = append(edits, textEdit{Start: current.Start, End: current.End, Text: strings.TrimSpace(field.Text)})
+			owned[key] = generatedHash
+		case wasOwned:
+			g.diagnostics.Warn(file.Model, WarningMergeConflict, fmt.Sprintf("%s: %s %s.%s was edited by hand; keeping the existing definition", file.Path, field.Kind, viewName, field.Name))
+			owned[key] = previousHash
+		case adopt || (adoptParams && field.Kind == viewParamKind):
+			edits = append(edits, textEdit{Start: current.Start, End: current.End, Text: strings.TrimSpace(field.Text)})
+			owned[key] = generatedHash
+			g.diagnostics.Warn(file.Model, WarningMergeAdopted, fmt.Sprintf("%s: %s %s.%s has no merge state; adopting and regenerating it", file.Path, field.Kind, viewName, field.Name))
+		default:
+			g.diagnostics.Warn(file.Model, WarningMergeConflict, fmt.Sprintf("%s: %s %s.%s was not generated by dbt2lookml; keeping the existing definition", file.Path, field.Kind, viewName, field.Name))
+		}
+	}
+
+	// Fields generated previously whose column is gone
+	for i := range existingBlocks {
+		current := &existingBlocks[i]
+		key := renderedBlock{Kind: current.Kind, Name: current.Name}.key()
+		previousHash, wasOwned := previous[key]
+		if generatedKeys[key] || !wasOwned {
+			continue
+		}
+
+		if blockHash(current.Text(existing)) != previousHash {
+			g.diagnostics.Warn(file.Model, WarningMergeConflict, fmt.Sprintf("%s: %s %s.%s is no longer generated but was edited by hand; keeping it", file.Path, current.Kind, viewName, current.Name))
+			continue
+		}
+
+		// A parameter is not a dropped column, so it is removed rather than commented out
+		if current.Kind == viewParamKind {
+			edits = append(edits, removeBlockEdit(existing, current))
+		} else {
+			edits = append(edits, g.dropBlockEdit(existing, current))
+		}
+		g.config.Logger().Debug().Str("file", file.Path).Str("field", current.Name).Msg("Dropped field no longer in dbt model")
+	}
+
+	// New parameters go first, new dimensions after the existing ones, measures and anything
+	// else at the end of the view
+	if paramInserts.Len() > 0 {
+		edits = append(edits, textEdit{Start: existingView.BodyFrom, End: existingView.BodyFrom, Text: paramInserts.String()})
+	}
+	if dimensionInserts.Len() > 0 {
+		if anchor := lastDimensionEnd(existingView); anchor > 0 {
+			edits = append(edits, textEdit{Start: anchor, End: anchor, Text: dimensionInserts.String()})
+		} else {
+			inserts.WriteString(strings.TrimPrefix(dimensionInserts.String(), "\n\n") + "\n\n")
+		}
+	}
+
+	if inserts.Len() > 0 {
+		text := inserts.String()
+		if existingView.BodyTo > 0 && existing[existingView.BodyTo-1] != '\n' {
+			text = "\n" + text
+		}
+		edits = append(edits, textEdit{Start: existingView.BodyTo, End: existingView.BodyTo, Text: text})
+	}
+
+	sort.Strings(deleted)
+	return edits, owned, deleted
+}
+
+// ownsViewBlock reports whether a view block only holds the generated fields and parameters
+// recorded for it, unchanged, so it can be dropped without losing hand edits
+func ownsViewBlock(existing string, view *parsers.LookMLBlock, fields map[string]string) bool {
+	blocks := existingViewBlocks(view)
+	for i := range blocks {
+		block := &blocks[i]
+		hash, owned := fields[renderedBlock{Kind: block.Kind, Name: block.Name}.key()]
+		if !owned || blockHash(block.Text(existing)) != hash {
+			return false
+		}
+	}
+	return true
+}
+
+// recordsParams reports whether the recorded fields of a view include view parameters
+func recordsParams(fields map[string]string) bool {
+	for key := range fields {
+		if strings.HasPrefix(key, viewParamKind+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// existingViewBlocks returns the fields of an existing view followed by its simple parameters
+// as blocks of kind param, so merge mode can match, update and drop both alike
+func existingViewBlocks(view *parsers.LookMLBlock) []parsers.LookMLBlock {
+	keys := make([]string, 0, len(view.ParamRanges))
+	for key := range view.ParamRanges {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	blocks := append([]parsers.LookMLBlock(nil), view.Children...)
+	for _, key := range keys {
+		paramRange := view.ParamRanges[key]
+		blocks = append(blocks, parsers.LookMLBlock{Kind: viewParamKind, Name: key, Start: paramRange.Start, End: paramRange.End})
+	}
+	return blocks
+}
+
+// dropBlockEdit removes or comments out a block that is no longer generated, as set by
+// merge_dropped_fields
+func (g *LookMLGenerator) dropBlockEdit(existing string, block *parsers.LookMLBlock) textEdit {
+	if g.config.MergeDroppedFields == config.MergeDroppedRemove {
+		return removeBlockEdit(existing, block)
+	}
+	return commentOutBlockEdit(existing, block)
+}
+
+// lastDimensionEnd returns the end offset of the last dimension or dimension group in a view, or 0
+func lastDimensionEnd(view *parsers.LookMLBlock) int {
+	end := 0
+	for _, child := range view.Children {
+		if child.Kind == "dimension" || child.Kind == "dimension_group" {
+			end = child.End
+		}
+	}
+	return end
+}
+
+// findLookMLBlock returns the top-level block with the given kind and name
+func findLookMLBlock(blocks []parsers.LookMLBlock, kind, name string) *parsers.LookMLBlock {
+	for i := range blocks {
+		if blocks[i].Kind == kind && blocks[i].Name == name {
+			return &blocks[i]
+		}
+	}
+	return nil
+}
+
+// blockHash hashes a block ignoring indentation and blank lines, so re-indenting a
+// generated field is not treated as a hand edit
+func blockHash(text string) string {
+	var normalized []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			normalized = append(normalized, line)
+		}
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(normalized, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// exploreBlockText returns the explore block of rendered explore output without leading comments
+func exploreBlockText(rendered string) string {
+	if index := strings.Index(rendered, "explore:"); index >= 0 {
+		rendered = rendered[index:]
+	}
+	return strings.TrimSpace(rendered)
+}
+
+// commentOutBlockEdit comments out every line of a block
+func commentOutBlockEdit(content string, block *parsers.LookMLBlock) textEdit {
+	indent := lineIndent(content, block.Start)
+	lines := strings.Split(block.Text(content), "\n")
+
+	var builder strings.Builder
+	builder.WriteString(droppedFieldComment + "\n" + indent)
+	for i, line := range lines {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		trimmed := strings.TrimLeft(line, " \t")
+		builder.WriteString(line[:len(line)-len(trimmed)] + "# " + trimmed)
+	}
+
+	return textEdit{Start: block.Start, End: block.End, Text: builder.String()}
+}
+
+// removeBlockEdit removes a block together with its indentation and trailing blank line
+func removeBlockEdit(content string, block *parsers.LookMLBlock) textEdit {
+	start := block.Start - len(lineIndent(content, block.Start))
+
+	end := block.End
+	for i := 0; i < 2 && end < len(content); i++ {
+		next := end + len(content[end:]) - len(strings.TrimLeft(content[end:], " \t"))
+		if next >= len(content) || content[next] != '\n' {
+			break
+		}
+		end = next + 1
+	}
+
+	return textEdit{Start: start, End: end, Text: ""}
+}
+
+// lineIndent returns the whitespace between the start of the line and pos
+func lineIndent(content string, pos int) string {
+	lineStart := strings.LastIndex(content[:pos], "\n") + 1
+	if strings.TrimSpace(content[lineStart:pos]) != "" {
+		return ""
+	}
+	return content[lineStart:pos]
+}
+
+// applyTextEdits applies non-overlapping edits to content
+func applyTextEdits(content string, edits []textEdit) string {
+	sort.SliceStable(edits, func(i, j int) bool {
+		return edits[i].Start > edits[j].Start
+	})
+
+	for _, edit := range edits {
+		content = content[:edit.Start] + edit.Text + content[edit.End:]
+	}
+
+	return content
+}
+
+// addMissingIncludes prepends include statements that the existing content lacks
+func addMissingIncludes(content string, includes []string) string {
+	var missing strings.Builder
+	for _, include := range includes {
+		statement := fmt.Sprintf("include: \"%s\"", include)
+		if !strings.Contains(content, statement) {
+			missing.WriteString(statement + "\n")
+		}
+	}
+
+	if missing.Len() == 0 {
+		return content
+	}
+	return missing.String() + content
+}
@@ -0,0 +1,291 @@
+package generators
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/parsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createMergeModel returns an orders model with columns of the given BigQuery types by name
+func createMergeModel(columns map[string]string) *models.DbtModel {
+	model := createTestModel("orders", "")
+	for name, dataType := range columns {
+		model.Columns[name] = testColumn(name, dataType)
+	}
+	return model
+}
+
+// runMerge generates the views of model with cfg, failing the test on errors
+func runMerge(t *testing.T, cfg *config.Config, model *models.DbtModel) *GenerationResult {
+	t.Helper()
+
+	result, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+	return result
+}
+
+func TestMerge_UpdatesOwnedFieldsOnly(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:          outputDir,
+		Merge:              true,
+		MergeDroppedFields: config.MergeDroppedComment,
+	}
+
+	runMerge(t, cfg, createMergeModel(map[string]string{
+		"id":      "INT64",
+		"status":  "STRING",
+		"amount":  "INT64",
+		"channel": "STRING",
+	}))
+
+	viewPath := findOutputFile(t, outputDir, "orders.view.lkml")
+	_, err := os.Stat(filepath.Join(outputDir, mergeStateFilename))
+	require.NoError(t, err, "merge state file should be written")
+
+	original, err := os.ReadFile(viewPath)
+	require.NoError(t, err)
+	content := string(original)
+
+	// Hand edits: a custom field with a comment, a view parameter and an edited generated field
+	content = strings.Replace(content, "view: orders {\n", "view: orders {\n  # Keep this comment\n  view_label: \"Sales\"\n\n  dimension: status_upper {\n    type: string\n    sql: UPPER(${status}) ;;\n  }\n\n", 1)
+	content = strings.Replace(content, "  dimension: channel {\n    type: string", "  dimension: channel {\n    type: string\n    label: \"Sales Channel\"", 1)
+	require.NoError(t, os.WriteFile(viewPath, []byte(content), 0644))
+
+	// Second run: amount changes type, status is dropped, region is added
+	result := runMerge(t, cfg, createMergeModel(map[string]string{
+		"id":      "INT64",
+		"amount":  "STRING",
+		"channel": "INT64",
+		"region":  "STRING",
+	}))
+
+	merged, err := os.ReadFile(viewPath)
+	require.NoError(t, err)
+	mergedContent := string(merged)
+
+	// Hand-written content survives
+	assert.Contains(t, mergedContent, "# Keep this comment")
+	assert.Contains(t, mergedContent, "view_label: \"Sales\"")
+	assert.Contains(t, mergedContent, "dimension: status_upper {")
+	assert.Contains(t, mergedContent, "label: \"Sales Channel\"")
+
+	blocks := parsers.ParseLookMLBlocks(mergedContent)
+	view := findLookMLBlock(blocks, "view", "orders")
+	require.NotNil(t, view)
+
+	// Owned fields are updated and new ones added
+	amount := view.FindChild("dimension", "amount")
+	require.NotNil(t, amount)
+	assert.Equal(t, "string", amount.Params["type"])
+	assert.NotNil(t, view.FindChild("dimension", "region"))
+
+	// Dropped field is commented out
+	assert.Nil(t, view.FindChild("dimension", "status"))
+	assert.Contains(t, mergedContent, droppedFieldComment)
+	assert.Contains(t, mergedContent, "# dimension: status {")
+
+	// Hand-edited generated field is kept and reported
+	channel := view.FindChild("dimension", "channel")
+	require.NotNil(t, channel)
+	assert.Equal(t, "string", channel.Params["type"])
+
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, WarningMergeConflict, result.Warnings[0].Category)
+	assert.Contains(t, result.Warnings[0].Message, "dimension orders.channel was edited by hand")
+
+	// A third run without changes is stable
+	runMerge(t, cfg, createMergeModel(map[string]string{
+		"id":      "INT64",
+		"amount":  "STRING",
+		"channel": "INT64",
+		"region":  "STRING",
+	}))
+	again, err := os.ReadFile(viewPath)
+	require.NoError(t, err)
+	assert.Equal(t, mergedContent, string(again))
+}
+
+func TestMerge_RemoveDroppedFields(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:          outputDir,
+		Merge:              true,
+		MergeDroppedFields: config.MergeDroppedRemove,
+	}
+
+	runMerge(t, cfg, createMergeModel(map[string]string{"id": "INT64", "status": "STRING"}))
+	runMerge(t, cfg, createMergeModel(map[string]string{"id": "INT64"}))
+
+	merged, err := os.ReadFile(findOutputFile(t, outputDir, "orders.view.lkml"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(merged), "status")
+	assert.NotContains(t, string(merged), droppedFieldComment)
+}
+
+func TestMerge_ExistingFieldsWithoutState(t *testing.T) {
+	outputDir := t.TempDir()
+
+	// A file written without merge mode has no ownership state
+	_, err := NewLookMLGenerator(&config.Config{OutputDir: outputDir}).GenerateAllWithOptions(
+		context.Background(), []*models.DbtModel{createMergeModel(map[string]string{"id": "INT64", "status": "STRING"})}, GenerationOptions{})
+	require.NoError(t, err)
+
+	cfg := &config.Config{OutputDir: outputDir, Merge: true}
+	model := createMergeModel(map[string]string{"id": "INT64", "status": "INT64"})
+	model.Schema = "sales"
+	result := runMerge(t, cfg, model)
+
+	// Identical fields are adopted silently, differing ones by name and reported
+	require.Len(t, result.Warnings, 2)
+	for _, warning := range result.Warnings {
+		assert.Equal(t, WarningMergeAdopted, warning.Category)
+	}
+	assert.Contains(t, result.Warnings[0].Message, "param orders.sql_table_name has no merge state")
+	assert.Contains(t, result.Warnings[1].Message, "dimension orders.status has no merge state")
+
+	merged, err := os.ReadFile(findOutputFile(t, outputDir, "orders.view.lkml"))
+	require.NoError(t, err)
+	view := findLookMLBlock(parsers.ParseLookMLBlocks(string(merged)), "view", "orders")
+	require.NotNil(t, view)
+	assert.Equal(t, "`sales.orders`", view.Params["sql_table_name"])
+	status := view.FindChild("dimension", "status")
+	require.NotNil(t, status)
+	assert.Equal(t, "number", status.Params["type"])
+
+	// Adopted fields are owned from now on
+	result = runMerge(t, cfg, model)
+	assert.Empty(t, result.Warnings)
+}
+
+func TestMerge_UpdatesViewParameters(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir, Merge: true}
+
+	runMerge(t, cfg, createMergeModel(map[string]string{"id": "INT64"}))
+	viewPath := findOutputFile(t, outputDir, "orders.view.lkml")
+	original, err := os.ReadFile(viewPath)
+	require.NoError(t, err)
+
+	// The user relabels the view
+	content := strings.Replace(string(original), "  label: \"Orders\"\n", "  label: \"Sales Orders\"\n", 1)
+	require.NoError(t, os.WriteFile(viewPath, []byte(content), 0644))
+
+	// The model moves to another table and gets a description
+	model := createMergeModel(map[string]string{"id": "INT64"})
+	model.Schema = "sales"
+	model.Description = "One row per order"
+	result := runMerge(t, cfg, model)
+
+	merged, err := os.ReadFile(viewPath)
+	require.NoError(t, err)
+	view := findLookMLBlock(parsers.ParseLookMLBlocks(string(merged)), "view", "orders")
+	require.NotNil(t, view)
+	assert.Equal(t, "`sales.orders`", view.Params["sql_table_name"])
+	assert.Equal(t, `"One row per order"`, view.Params["description"])
+	assert.Equal(t, `"Sales Orders"`, view.Params["label"])
+	assert.Contains(t, string(merged), "view: orders {\n  description: \"One row per order\"\n  sql_table_name: `sales.orders` ;;\n")
+
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, WarningMergeConflict, result.Warnings[0].Category)
+	assert.Contains(t, result.Warnings[0].Message, "param orders.label was edited by hand")
+
+	// Without the description the generated parameter is dropped
+	model.Description = ""
+	runMerge(t, cfg, model)
+	merged, err = os.ReadFile(viewPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(merged), "One row per order")
+	assert.Contains(t, string(merged), "view: orders {\n  sql_table_name: `sales.orders` ;;\n  label: \"Sales Orders\"\n  dimension: id {\n")
+}
+
+func TestMerge_StateWithoutViewParameters(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir, Merge: true}
+
+	runMerge(t, cfg, createMergeModel(map[string]string{"id": "INT64"}))
+
+	// A state written before view parameters were tracked
+	statePath := filepath.Join(outputDir, mergeStateFilename)
+	state, err := loadMergeState(statePath)
+	require.NoError(t, err)
+	for _, file := range state.Files {
+		for _, fields := range file.Views {
+			for key := range fields {
+				if strings.HasPrefix(key, viewParamKind+":") {
+					delete(fields, key)
+				}
+			}
+		}
+	}
+	require.NoError(t, state.save(statePath))
+
+	model := createMergeModel(map[string]string{"id": "INT64"})
+	model.Schema = "sales"
+	result := runMerge(t, cfg, model)
+
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, WarningMergeAdopted, result.Warnings[0].Category)
+
+	merged, err := os.ReadFile(findOutputFile(t, outputDir, "orders.view.lkml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(merged), "  sql_table_name: `sales.orders` ;;\n")
+}
+
+func TestMerge_HandDeletedFieldsStayDeleted(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir, Merge: true}
+	columns := map[string]string{"id": "INT64", "status": "STRING"}
+
+	runMerge(t, cfg, createMergeModel(columns))
+	viewPath := findOutputFile(t, outputDir, "orders.view.lkml")
+	original, err := os.ReadFile(viewPath)
+	require.NoError(t, err)
+
+	// The user deletes a generated field
+	status := findLookMLBlock(parsers.ParseLookMLBlocks(string(original)), "view", "orders").FindChild("dimension", "status")
+	require.NotNil(t, status)
+	edited := string(original[:status.Start]) + string(original[status.End:])
+	require.NoError(t, os.WriteFile(viewPath, []byte(edited), 0644))
+
+	for run := 0; run < 2; run++ {
+		runMerge(t, cfg, createMergeModel(columns))
+		merged, err := os.ReadFile(viewPath)
+		require.NoError(t, err)
+		assert.Nil(t, findLookMLBlock(parsers.ParseLookMLBlocks(string(merged)), "view", "orders").FindChild("dimension", "status"))
+	}
+}
+
+func TestMerge_DropsNestedViewsOfRemovedArrays(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir, Merge: true, MergeDroppedFields: config.MergeDroppedRemove}
+
+	runMerge(t, cfg, createMergeModel(map[string]string{"id": "INT64", "tags": "ARRAY<STRING>"}))
+	viewPath := findOutputFile(t, outputDir, "orders.view.lkml")
+	original, err := os.ReadFile(viewPath)
+	require.NoError(t, err)
+	require.NotNil(t, findLookMLBlock(parsers.ParseLookMLBlocks(string(original)), "view", "orders__tags"))
+
+	runMerge(t, cfg, createMergeModel(map[string]string{"id": "INT64"}))
+	merged, err := os.ReadFile(viewPath)
+	require.NoError(t, err)
+	assert.Nil(t, findLookMLBlock(parsers.ParseLookMLBlocks(string(merged)), "view", "orders__tags"))
+	assert.NotNil(t, findLookMLBlock(parsers.ParseLookMLBlocks(string(merged)), "view", "orders"))
+}
+
+func TestBlockHash_IgnoresIndentation(t *testing.T) {
+	assert.Equal(t,
+		blockHash("dimension: id {\n  type: number\n}"),
+		blockHash("  dimension: id {\n\n      type: number\n  }\n\n"))
+	assert.NotEqual(t,
+		blockHash("dimension: id {\n  type: number\n}"),
+		blockHash("dimension: id {\n  type: string\n}"))
+}
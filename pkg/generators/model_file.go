@@ -0,0 +1,378 @@
+package generators
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+)
+
+// exploreRootTagPrefix selects explore roots by dbt tag in the explore_roots option
+const exploreRootTagPrefix = "tag:"
+
+// modelFileGroup collects the generated files and explore roots of one model file
+type modelFileGroup struct {
+	name         string
+	connections  map[string][]string // connection -> dbt model names
+	files        []string            // output paths of the files to include
+	exploreRoots []*models.LookMLExplore
+	rootModels   []string
+}
+
+// recordModelFile registers the files planned for a model with its model file group
+func (g *LookMLGenerator) recordModelFile(model *models.DbtModel, files []outputFile) error {
+	if !g.config.ModelFiles {
+		return nil
+	}
+
+	connection, err := g.config.GetConnection(model.Database)
+	if err != nil {
+		return err
+	}
+
+	if g.modelFiles == nil {
+		g.modelFiles = make(map[string]*modelFileGroup)
+	}
+
+	name := g.getModelFileName(model)
+	group, ok := g.modelFiles[name]
+	if !ok {
+		group = &modelFileGroup{name: name, connections: make(map[string][]string)}
+		g.modelFiles[name] = group
+	}
+
+	group.connections[connection] = append(group.connections[connection], model.Name)
+
+	for _, file := range files {
+		// With refinements the refinement file includes its generated base
+		if g.config.Refinements && !file.Scaffold {
+			continue
+		}
+		group.files = append(group.files, file.Path)
+	}
+
+	if g.isExploreRoot(model) {
+		group.rootModels = append(group.rootModels, model.Name)
+		for _, file := range files {
+			for _, explore := range file.Explores {
+				group.exploreRoots = append(group.exploreRoots, explore)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getModelFileName returns the model file a dbt model belongs to.
+// meta.looker.model takes precedence over the configured grouping.
+func (g *LookMLGenerator) getModelFileName(model *models.DbtModel) string {
+	if model.Meta != nil && model.Meta.Looker != nil && model.Meta.Looker.Model != nil && *model.Meta.Looker.Model != "" {
+		return utils.ToLookMLName(*model.Meta.Looker.Model)
+	}
+
+	name := g.config.ModelName
+	switch g.config.ModelGrouping {
+	case config.ModelGroupingFolder:
+		// Top-level folder below the dbt models directory
+		if dir := strings.Trim(path.Dir(model.Path), "/."); dir != "" {
+			name = strings.Split(dir, "/")[0]
+		}
+	case config.ModelGroupingTag:
+		if len(model.Tags) > 0 {
+			name = model.Tags[0]
+		}
+	}
+
+	if name == "" {
+		name = "dbt"
+	}
+	return utils.ToLookMLName(name)
+}
+
+// isExploreRoot reports whether a model is selected by the explore_roots option
+func (g *LookMLGenerator) isExploreRoot(model *models.DbtModel) bool {
+	for _, root := range g.config.ExploreRoots {
+		if tag, ok := strings.CutPrefix(root, exploreRootTagPrefix); ok {
+			for _, modelTag := range model.Tags {
+				if strings.EqualFold(modelTag, tag) {
+					return true
+				}
+			}
+			continue
+		}
+		if root == model.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// planLookMLModelFiles builds the model files for all recorded groups
+func (g *LookMLGenerator) planLookMLModelFiles() ([]outputFile, error) {
+	if len(g.modelFiles) == 0 {
+		return nil, nil
+	}
+
+	// Directories shared by several model files cannot be included with a glob
+	dirGroups := make(map[string]map[string]bool)
+	for _, group := range g.modelFiles {
+		for _, file := range group.files {
+			key := includeGlob(file)
+			if dirGroups[key] == nil {
+				dirGroups[key] = make(map[string]bool)
+			}
+			dirGroups[key][group.name] = true
+		}
+	}
+
+	names := make([]string, 0, len(g.modelFiles))
+	for name := range g.modelFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	files := make([]outputFile, 0, len(names))
+	for _, name := range names {
+		group := g.modelFiles[name]
+
+		lookmlModel := &models.LookMLModel{
+			Name:         name,
+			Connection:   g.modelConnection(group),
+			Includes:     g.modelIncludes(group, dirGroups),
+			Datagroups:   g.modelDatagroups(),
+			AccessGrants: g.modelAccessGrants(),
+		}
+		g.applyModelCalendar(lookmlModel)
+		for _, explore := range group.exploreRoots {
+			lookmlModel.Explores = append(lookmlModel.Explores, *explore)
+		}
+
+		if err := lookmlModel.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid model file %s: %w", name, err)
+		}
+
+		files = append(files, outputFile{
+			Path:    fmt.Sprintf("%s.model.lkml", name),
+			Content: g.modelToLookML(lookmlModel),
+		})
+	}
+
+	g.warnMissingExploreRoots()
+
+	return files, nil
+}
+
+// writeModelFiles writes the model files after all views have been generated
+func (g *LookMLGenerator) writeModelFiles() error {
+	files, err := g.planLookMLModelFiles()
+	if err != nil {
+		return err
+	}
+
+	for i := range files {
+		if _, err := g.writeOutputFile(&files[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// modelConnection picks the connection of a group, reporting groups that span several connections
+func (g *LookMLGenerator) modelConnection(group *modelFileGroup) string {
+	connections := make([]string, 0, len(group.connections))
+	for connection := range group.connections {
+		connections = append(connections, connection)
+	}
+	sort.Strings(connections)
+
+	if len(connections) == 0 {
+		return ""
+	}
+
+	// Prefer the connection used by most models
+	chosen := connections[0]
+	for _, connection := range connections {
+		if len(group.connections[connection]) > len(group.connections[chosen]) {
+			chosen = connection
+		}
+	}
+
+	for _, connection := range connections {
+		if connection == chosen {
+			continue
+		}
+		for _, modelName := range group.connections[connection] {
+			g.diagnostics.Warn(modelName, WarningModelFile, fmt.Sprintf("model file %s uses connection %q but this model maps to %q", group.name, chosen, connection))
+		}
+	}
+
+	return chosen
+}
+
+// modelIncludes returns include statements for the files of a group, using one glob per
+// directory when the directory only contains files of this model file
+func (g *LookMLGenerator) modelIncludes(group *modelFileGroup, dirGroups map[string]map[string]bool) []string {
+	seen := make(map[string]bool)
+	var includes []string
+
+	for _, file := range group.files {
+		include := file
+		if glob := includeGlob(file); len(dirGroups[glob]) == 1 {
+			include = glob
+		}
+		include = g.config.GetIncludePath(include)
+		if !seen[include] {
+			seen[include] = true
+			includes = append(includes, include)
+		}
+	}
+	sort.Strings(includes)
+
+	for _, include := range g.config.ModelIncludes {
+		if !seen[include] {
+			seen[include] = true
+			includes = append(includes, include)
+		}
+	}
+
+	return includes
+}
+
+// modelDatagroups converts the configured datagroups
+func (g *LookMLGenerator) modelDatagroups() []models.LookMLDatagroup {
+	datagroups := make([]models.LookMLDatagroup, 0, len(g.config.Datagroups))
+	for _, datagroup := range g.config.Datagroups {
+		lookmlDatagroup := models.LookMLDatagroup{Name: datagroup.Name}
+		if datagroup.Label != "" {
+			lookmlDatagroup.Label = utils.StringPtr(datagroup.Label)
+		}
+		if datagroup.Description != "" {
+			lookmlDatagroup.Description = utils.StringPtr(datagroup.Description)
+		}
+		if datagroup.SQLTrigger != "" {
+			lookmlDatagroup.SQLTrigger = utils.StringPtr(datagroup.SQLTrigger)
+		}
+		if datagroup.IntervalTrigger != "" {
+			lookmlDatagroup.IntervalTrigger = utils.StringPtr(datagroup.IntervalTrigger)
+		}
+		if datagroup.MaxCacheAge != "" {
+			lookmlDatagroup.MaxCacheAge = utils.StringPtr(datagroup.MaxCacheAge)
+		}
+		datagroups = append(datagroups, lookmlDatagroup)
+	}
+	return datagroups
+}
+
+// modelAccessGrants converts the configured access grants
+func (g *LookMLGenerator) modelAccessGrants() []models.LookMLAccessGrant {
+	grants := make([]models.LookMLAccessGrant, 0, len(g.config.AccessGrants))
+	for _, grant := range g.config.AccessGrants {
+		grants = append(grants, models.LookMLAccessGrant{
+			Name:          grant.Name,
+			UserAttribute: grant.UserAttribute,
+			AllowedValues: grant.AllowedValues,
+		})
+	}
+	return grants
+}
+
+// warnMissingExploreRoots reports explore_roots entries that matched no generated model
+func (g *LookMLGenerator) warnMissingExploreRoots() {
+	found := make(map[string]bool)
+	for _, group := range g.modelFiles {
+		for _, modelName := range group.rootModels {
+			found[modelName] = true
+		}
+	}
+
+	for _, root := range g.config.ExploreRoots {
+		if strings.HasPrefix(root, exploreRootTagPrefix) || found[root] {
+			continue
+		}
+		g.diagnostics.Warn(root, WarningModelFile, fmt.Sprintf("explore root %s is not among the generated models", root))
+	}
+}
+
+// includeGlob returns the glob matching all files of the same kind in the file's directory,
+// e.g. "mart/orders.view.lkml" -> "mart/*.view.lkml"
+func includeGlob(file string) string {
+	dir, base := path.Split(file)
+	extension := base
+	if index := strings.Index(base, "."); index >= 0 {
+		extension = base[index:]
+	}
+	return dir + "*" + extension
+}
+
+// applyModelCalendar sets the fiscal month offset and week start day of a model file. Looker
+// applies them to the week and fiscal timeframes of every explore in the model.
+func (g *LookMLGenerator) applyModelCalendar(model *models.LookMLModel) {
+	model.FiscalMonthOffset = g.config.FiscalMonthOffset
+	if g.config.WeekStartDay != "" {
+		day := enums.LookerWeekStartDay(g.config.WeekStartDay)
+		model.WeekStartDay = &day
+	}
+}
+
+// modelToLookML renders a LookML model file
+func (g *LookMLGenerator) modelToLookML(model *models.LookMLModel) string {
+	var builder strings.Builder
+
+	builder.WriteString("# Generated by dbt2lookml - changes will be overwritten\n")
+	builder.WriteString(fmt.Sprintf("connection: \"%s\"\n", model.Connection))
+	if model.FiscalMonthOffset != 0 {
+		builder.WriteString(fmt.Sprintf("fiscal_month_offset: %d\n", model.FiscalMonthOffset))
+	}
+	if model.WeekStartDay != nil {
+		builder.WriteString(fmt.Sprintf("week_start_day: %s\n", *model.WeekStartDay))
+	}
+
+	if len(model.Includes) > 0 {
+		builder.WriteString("\n")
+		for _, include := range model.Includes {
+			builder.WriteString(fmt.Sprintf("include: \"%s\"\n", include))
+		}
+	}
+
+	for _, datagroup := range model.Datagroups {
+		builder.WriteString(fmt.Sprintf("\ndatagroup: %s {\n", datagroup.Name))
+		if datagroup.Label != nil {
+			builder.WriteString(fmt.Sprintf("  label: %s\n", quoteString(*datagroup.Label)))
+		}
+		if datagroup.Description != nil {
+			builder.WriteString(fmt.Sprintf("  description: %s\n", quoteString(*datagroup.Description)))
+		}
+		if datagroup.SQLTrigger != nil {
+			builder.WriteString(fmt.Sprintf("  sql_trigger: %s ;;\n", *datagroup.SQLTrigger))
+		}
+		if datagroup.IntervalTrigger != nil {
+			builder.WriteString(fmt.Sprintf("  interval_trigger: \"%s\"\n", *datagroup.IntervalTrigger))
+		}
+		if datagroup.MaxCacheAge != nil {
+			builder.WriteString(fmt.Sprintf("  max_cache_age: \"%s\"\n", *datagroup.MaxCacheAge))
+		}
+		builder.WriteString("}\n")
+	}
+
+	for _, grant := range model.AccessGrants {
+		builder.WriteString(fmt.Sprintf("\naccess_grant: %s {\n", grant.Name))
+		builder.WriteString(fmt.Sprintf("  user_attribute: %s\n", grant.UserAttribute))
+		builder.WriteString(fmt.Sprintf("  allowed_values: [%s]\n", quoteList(grant.AllowedValues)))
+		builder.WriteString("}\n")
+	}
+
+	// Explore roots un-hide the explores generated next to their views
+	for _, explore := range model.Explores {
+		builder.WriteString(fmt.Sprintf("\nexplore: +%s {\n", explore.Name))
+		builder.WriteString("  hidden: no\n")
+		builder.WriteString("}\n")
+	}
+
+	return builder.String()
+}
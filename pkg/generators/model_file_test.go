@@ -0,0 +1,283 @@
+package generators
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createModelFileModel returns a model with an id column in the given database, path and tags
+func createModelFileModel(name, path, database string, tags ...string) *models.DbtModel {
+	model := createTestModel(name, path, testColumn("id", "INT64"))
+	model.RelationName = "`" + database + ".dataset." + name + "`"
+	model.Database = database
+	model.Tags = tags
+	return model
+}
+
+func TestModelFiles_SingleModel(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:     outputDir,
+		ModelFiles:    true,
+		ModelName:     "analytics",
+		ModelGrouping: config.ModelGroupingSingle,
+		Connection:    "bigquery_default",
+		Connections:   map[string]string{"prod-project": "bigquery_prod"},
+		ExploreRoots:  []string{"orders", "tag:explore", "missing_model"},
+		ModelIncludes: []string{"/explores/*.explore.lkml"},
+		Datagroups: []config.DatagroupConfig{
+			{Name: "daily", SQLTrigger: "SELECT CURRENT_DATE()", MaxCacheAge: "24 hours"},
+		},
+	}
+
+	dbtModels := []*models.DbtModel{
+		createModelFileModel("orders", "marts/sales/orders.sql", "prod-project"),
+		createModelFileModel("customers", "marts/crm/customers.sql", "prod-project", "explore"),
+		createModelFileModel("events", "staging/events.sql", "prod-project"),
+	}
+
+	result, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), dbtModels, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "analytics.model.lkml")
+	assert.Contains(t, content, `connection: "bigquery_prod"`)
+	assert.Contains(t, content, `include: "/marts/sales/*.view.lkml"`)
+	assert.Contains(t, content, `include: "/marts/crm/*.view.lkml"`)
+	assert.Contains(t, content, `include: "/staging/*.view.lkml"`)
+	assert.Contains(t, content, `include: "/explores/*.explore.lkml"`)
+	assert.Contains(t, content, "datagroup: daily {\n  sql_trigger: SELECT CURRENT_DATE() ;;\n  max_cache_age: \"24 hours\"\n}")
+	assert.Contains(t, content, "explore: +orders {\n  hidden: no\n}")
+	assert.Contains(t, content, "explore: +customers {\n  hidden: no\n}")
+	assert.NotContains(t, content, "explore: +events")
+
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, WarningModelFile, result.Warnings[0].Category)
+	assert.Contains(t, result.Warnings[0].Message, "missing_model")
+}
+
+func TestModelFiles_Grouping(t *testing.T) {
+	tests := []struct {
+		name     string
+		grouping string
+		model    *models.DbtModel
+		expected string
+	}{
+		{
+			name:     "single uses model name",
+			grouping: config.ModelGroupingSingle,
+			model:    createModelFileModel("orders", "marts/sales/orders.sql", "p"),
+			expected: "analytics",
+		},
+		{
+			name:     "folder uses top-level folder",
+			grouping: config.ModelGroupingFolder,
+			model:    createModelFileModel("orders", "marts/sales/orders.sql", "p"),
+			expected: "marts",
+		},
+		{
+			name:     "folder falls back for root models",
+			grouping: config.ModelGroupingFolder,
+			model:    createModelFileModel("orders", "orders.sql", "p"),
+			expected: "analytics",
+		},
+		{
+			name:     "tag uses first tag",
+			grouping: config.ModelGroupingTag,
+			model:    createModelFileModel("orders", "orders.sql", "p", "Finance", "daily"),
+			expected: "finance",
+		},
+		{
+			name:     "meta looker model wins",
+			grouping: config.ModelGroupingTag,
+			model: func() *models.DbtModel {
+				model := createModelFileModel("orders", "orders.sql", "p", "finance")
+				model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{Model: utils.StringPtr("sales")}}
+				return model
+			}(),
+			expected: "sales",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := NewLookMLGenerator(&config.Config{ModelName: "analytics", ModelGrouping: tt.grouping})
+			assert.Equal(t, tt.expected, gen.getModelFileName(tt.model))
+		})
+	}
+}
+
+func TestModelFiles_SharedDirectoryListsFiles(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:     outputDir,
+		ModelFiles:    true,
+		ModelName:     "dbt",
+		ModelGrouping: config.ModelGroupingTag,
+		Connection:    "bigquery",
+		Flatten:       true,
+	}
+
+	dbtModels := []*models.DbtModel{
+		createModelFileModel("orders", "marts/orders.sql", "p", "sales"),
+		createModelFileModel("customers", "marts/customers.sql", "p", "crm"),
+	}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), dbtModels, GenerationOptions{})
+	require.NoError(t, err)
+
+	sales := readOutput(t, outputDir, "sales.model.lkml")
+	assert.Contains(t, sales, `include: "/orders.view.lkml"`)
+	assert.NotContains(t, sales, "*.view.lkml")
+	assert.NotContains(t, sales, "customers")
+
+	crm := readOutput(t, outputDir, "crm.model.lkml")
+	assert.Contains(t, crm, `include: "/customers.view.lkml"`)
+}
+
+func TestModelFiles_IncludesRefinements(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:   outputDir,
+		ModelFiles:  true,
+		ModelName:   "dbt",
+		Connection:  "bigquery",
+		Refinements: true,
+		IncludeRoot: "lookml",
+	}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createModelFileModel("orders", "marts/orders.sql", "p")}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "dbt.model.lkml")
+	assert.Contains(t, content, `include: "/lookml/refinements/marts/*.view.lkml"`)
+	assert.NotContains(t, content, "generated/")
+}
+
+func TestModelFiles_Calendar(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:         outputDir,
+		ModelFiles:        true,
+		ModelName:         "dbt",
+		Connection:        "bigquery",
+		FiscalMonthOffset: 3,
+		WeekStartDay:      "sunday",
+	}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createModelFileModel("orders", "orders.sql", "p")}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "dbt.model.lkml")
+	assert.Contains(t, content, "connection: \"bigquery\"\nfiscal_month_offset: 3\nweek_start_day: sunday\n")
+}
+
+func TestModelFiles_Disabled(t *testing.T) {
+	outputDir := t.TempDir()
+
+	_, err := NewLookMLGenerator(&config.Config{OutputDir: outputDir}).GenerateAllWithOptions(
+		context.Background(), []*models.DbtModel{createModelFileModel("orders", "orders.sql", "p")}, GenerationOptions{})
+	require.NoError(t, err)
+
+	matches, err := filepath.Glob(filepath.Join(outputDir, "*.model.lkml"))
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestModelFiles_UnmappedDatabase(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:   outputDir,
+		ModelFiles:  true,
+		Connections: map[string]string{"prod-project": "bigquery_prod"},
+	}
+
+	result, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{
+		createModelFileModel("orders", "orders.sql", "prod-project"),
+		createModelFileModel("events", "events.sql", "dev-project"),
+	}, GenerationOptions{ErrorStrategy: ContinueOnError})
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "events", result.Errors[0].ModelName)
+	assert.Contains(t, result.Errors[0].Error.Error(), "no connection for database dev-project")
+
+	content := readOutput(t, outputDir, "dbt.model.lkml")
+	assert.Contains(t, content, "connection: \"bigquery_prod\"")
+	assert.NotContains(t, content, "connection: \"\"")
+}
+
+func TestModelFiles_NotWrittenWhenViewsFail(t *testing.T) {
+	tests := []struct {
+		name   string
+		models func() []*models.DbtModel
+		block  string
+	}{
+		{
+			name: "writing fails",
+			models: func() []*models.DbtModel {
+				return []*models.DbtModel{
+					createModelFileModel("orders", "orders.sql", "prod-project"),
+					createModelFileModel("events", "events.sql", "prod-project"),
+				}
+			},
+			block: "events.view.lkml",
+		},
+		{
+			name: "planning fails",
+			models: func() []*models.DbtModel {
+				return []*models.DbtModel{
+					createModelFileModel("orders", "orders.sql", "prod-project"),
+					createModelFileModel("events", "events.sql", "dev-project"),
+				}
+			},
+		},
+		{
+			name: "paths collide",
+			models: func() []*models.DbtModel {
+				return []*models.DbtModel{
+					createModelFileModel("orders", "sales/orders.sql", "prod-project"),
+					createModelFileModel("orders", "archive/orders.sql", "prod-project"),
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputDir := t.TempDir()
+			cfg := &config.Config{
+				OutputDir:   outputDir,
+				OutputPath:  "{{ .ViewName }}.view.lkml",
+				ModelFiles:  true,
+				Connections: map[string]string{"prod-project": "bigquery_prod"},
+			}
+			if tt.block != "" {
+				// A directory in place of the view makes writing it fail
+				require.NoError(t, os.Mkdir(filepath.Join(outputDir, tt.block), 0o755))
+			}
+
+			_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), tt.models(), GenerationOptions{ErrorStrategy: FailFast})
+			require.Error(t, err)
+
+			_, err = NewLookMLGenerator(cfg).GenerateAllWithContext(context.Background(), tt.models())
+			require.Error(t, err)
+
+			matches, err := filepath.Glob(filepath.Join(outputDir, "*.model.lkml"))
+			require.NoError(t, err)
+			assert.Empty(t, matches)
+		})
+	}
+}
+
+func TestIncludeGlob(t *testing.T) {
+	assert.Equal(t, "marts/*.view.lkml", includeGlob("marts/orders.view.lkml"))
+	assert.Equal(t, "*.view.lkml", includeGlob("orders.view.lkml"))
+	assert.Equal(t, "explores/*.explore.lkml", includeGlob("explores/orders.explore.lkml"))
+}
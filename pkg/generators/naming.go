@@ -0,0 +1,104 @@
+package generators
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+)
+
+// NamingStrategy computes the names of generated views, explores, nested views and fields.
+// Every generator uses the same strategy, so views, joins and references agree on names.
+type NamingStrategy interface {
+	// ViewName returns the name of the view and explore of a model
+	ViewName(model *models.DbtModel) string
+
+	// NestedViewName returns the name of the view unnesting an array column of a model
+	NestedViewName(model *models.DbtModel, arrayPath string) string
+
+	// FieldName returns the LookML name of a column path such as "Item.GTINId"
+	FieldName(path string) string
+
+	// Join joins the parts of a nested name
+	Join(parts ...string) string
+}
+
+// namingRule rewrites view names matching a pattern
+type namingRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// configNamingStrategy is the NamingStrategy configured by the naming options
+type configNamingStrategy struct {
+	strategy  string
+	rules     []namingRule
+	separator string
+}
+
+// NewNamingStrategy creates the naming strategy configured by the naming options. Rules
+// with invalid patterns are skipped; Config.Validate rejects them.
+func NewNamingStrategy(cfg *config.Config) NamingStrategy {
+	naming := &configNamingStrategy{
+		strategy:  cfg.GetNamingStrategy(),
+		separator: cfg.GetNestedSeparator(),
+	}
+	for _, rule := range cfg.Naming.Rules {
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			continue
+		}
+		naming.rules = append(naming.rules, namingRule{pattern: pattern, replacement: rule.Replacement})
+	}
+	return naming
+}
+
+// ViewName returns the base name of the strategy rewritten by the naming rules, falling back
+// to the dbt model name when a strategy has nothing to go on
+func (n *configNamingStrategy) ViewName(model *models.DbtModel) string {
+	name := n.baseName(model)
+	for _, rule := range n.rules {
+		name = rule.pattern.ReplaceAllString(name, rule.replacement)
+	}
+	if name == "" {
+		return model.Name
+	}
+	return name
+}
+
+// baseName returns the name of a model before the naming rules are applied
+func (n *configNamingStrategy) baseName(model *models.DbtModel) string {
+	switch n.strategy {
+	case config.NamingStrategyTableName:
+		// Extract just the table name from relation_name (remove project.dataset prefix and backticks)
+		parts := strings.Split(model.RelationName, ".")
+		return strings.ToLower(strings.Trim(parts[len(parts)-1], "`"))
+	case config.NamingStrategyAlias:
+		if model.Alias != "" {
+			return strings.ToLower(model.Alias)
+		}
+	case config.NamingStrategyFQN:
+		// The first part of the fqn is the dbt project, the same for every model
+		if len(model.FQN) > 1 {
+			return utils.ToLookMLName(strings.Join(model.FQN[1:], "_"))
+		}
+	}
+	return model.Name
+}
+
+// NestedViewName joins the view name of the model and the name of the array column
+func (n *configNamingStrategy) NestedViewName(model *models.DbtModel, arrayPath string) string {
+	return n.Join(n.ViewName(model), n.FieldName(arrayPath))
+}
+
+// FieldName converts a column path to a LookML name, joining nested parts with the separator
+func (n *configNamingStrategy) FieldName(path string) string {
+	return utils.ToLookMLNameWithSeparator(path, n.separator)
+}
+
+// Join joins the parts of a nested name with the separator
+func (n *configNamingStrategy) Join(parts ...string) string {
+	return strings.Join(parts, n.separator)
+}
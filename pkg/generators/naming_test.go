@@ -0,0 +1,118 @@
+package generators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamingStrategy_ViewName(t *testing.T) {
+	model := &models.DbtModel{
+		DbtNode:      models.DbtNode{Name: "stg_orders_v2"},
+		RelationName: "`project`.`dataset`.`Orders_Table`",
+		Alias:        "orders_alias",
+		FQN:          []string{"shop", "staging", "sales", "stg_orders_v2"},
+	}
+
+	tests := []struct {
+		name     string
+		naming   config.NamingConfig
+		useTable bool
+		expected string
+	}{
+		{name: "dbt name by default", expected: "stg_orders_v2"},
+		{name: "use_table_name selects table name", useTable: true, expected: "orders_table"},
+		{name: "table name", naming: config.NamingConfig{Strategy: config.NamingStrategyTableName}, expected: "orders_table"},
+		{name: "alias", naming: config.NamingConfig{Strategy: config.NamingStrategyAlias}, expected: "orders_alias"},
+		{name: "fqn without project", naming: config.NamingConfig{Strategy: config.NamingStrategyFQN}, expected: "staging_sales_stg_orders_v2"},
+		{
+			name: "rules rewrite prefixes and suffixes",
+			naming: config.NamingConfig{Rules: []config.NamingRuleConfig{
+				{Pattern: "^stg_"},
+				{Pattern: `_v(\d+)$`, Replacement: "_version$1"},
+			}},
+			expected: "orders_version2",
+		},
+		{
+			name:     "rules rewriting everything fall back to the model name",
+			naming:   config.NamingConfig{Rules: []config.NamingRuleConfig{{Pattern: ".*"}}},
+			expected: "stg_orders_v2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			naming := NewNamingStrategy(&config.Config{Naming: tt.naming, UseTableName: tt.useTable})
+			assert.Equal(t, tt.expected, naming.ViewName(model))
+		})
+	}
+}
+
+func TestNamingStrategy_NestedNames(t *testing.T) {
+	model := &models.DbtModel{DbtNode: models.DbtNode{Name: "items"}}
+
+	naming := NewNamingStrategy(&config.Config{})
+	assert.Equal(t, "items__supplier_information", naming.NestedViewName(model, "SupplierInformation"))
+	assert.Equal(t, "gtin__gtin_id", naming.FieldName("GTIN.GTINId"))
+
+	naming = NewNamingStrategy(&config.Config{Naming: config.NamingConfig{NestedSeparator: "_"}})
+	assert.Equal(t, "items_markings_marking", naming.NestedViewName(model, "Markings.Marking"))
+	assert.Equal(t, "a_b", naming.Join("a", "b"))
+}
+
+func TestNamingStrategy_ConsistentOutput(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir: outputDir,
+		Naming: config.NamingConfig{
+			Rules:           []config.NamingRuleConfig{{Pattern: "^stg_"}},
+			NestedSeparator: "_",
+		},
+	}
+	model := createLayoutModel()
+	model.Name = "stg_orders"
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.Contains(t, content, "view: orders {\n")
+	assert.Contains(t, content, "view: orders_lines {\n")
+	assert.Contains(t, content, "    sql: orders_lines ;;\n")
+	assert.Contains(t, content, "explore: orders {\n  hidden: yes\n")
+	assert.Contains(t, content, "  join: orders_lines {\n")
+	assert.Contains(t, content, "    sql: LEFT JOIN UNNEST(${orders.lines}) as orders_lines ;;\n")
+}
+
+// prefixNaming is a custom strategy prefixing view names
+type prefixNaming struct {
+	NamingStrategy
+}
+
+func (n prefixNaming) ViewName(model *models.DbtModel) string {
+	return "dbt_" + n.NamingStrategy.ViewName(model)
+}
+
+func (n prefixNaming) NestedViewName(model *models.DbtModel, arrayPath string) string {
+	return n.Join(n.ViewName(model), n.FieldName(arrayPath))
+}
+
+func TestLookMLGenerator_SetNamingStrategy(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir}
+
+	generator := NewLookMLGenerator(cfg)
+	generator.SetNamingStrategy(prefixNaming{NamingStrategy: NewNamingStrategy(cfg)})
+
+	_, err := generator.GenerateAllWithOptions(context.Background(), []*models.DbtModel{createLayoutModel()}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/dbt_orders.view.lkml")
+	assert.Contains(t, content, "view: dbt_orders {\n")
+	assert.Contains(t, content, "view: dbt_orders__lines {\n")
+	assert.Contains(t, content, "    sql: LEFT JOIN UNNEST(${dbt_orders.lines}) as dbt_orders__lines ;;\n")
+}
@@ -0,0 +1,260 @@
+package generators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+)
+
+// nestedArrayLimits returns how deeply the arrays of a model are unnested and how deeper
+// arrays are handled: model meta first, then the nested_arrays options
+func nestedArrayLimits(cfg *config.Config, model *models.DbtModel) (maxDepth int, overflow string, err error) {
+	maxDepth = cfg.NestedArrays.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = models.MaxNestedArrayDepth
+	}
+	overflow = cfg.GetNestedArrayOverflow()
+
+	if model.Meta == nil || model.Meta.Looker == nil || model.Meta.Looker.NestedArrays == nil {
+		return maxDepth, overflow, nil
+	}
+	meta := model.Meta.Looker.NestedArrays
+	if meta.MaxDepth != nil {
+		if *meta.MaxDepth < 1 {
+			return maxDepth, overflow, fmt.Errorf("invalid nested_arrays.max_depth in meta: %d (must be at least 1)", *meta.MaxDepth)
+		}
+		maxDepth = *meta.MaxDepth
+	}
+	if meta.Overflow != nil {
+		metaOverflow := strings.ToLower(*meta.Overflow)
+		if metaOverflow != config.NestedArrayOverflowDrop && metaOverflow != config.NestedArrayOverflowJSON {
+			return maxDepth, overflow, fmt.Errorf("invalid nested_arrays.overflow in meta: %s (must be one of: %v)", *meta.Overflow, []string{config.NestedArrayOverflowDrop, config.NestedArrayOverflowJSON})
+		}
+		overflow = metaOverflow
+	}
+	return maxDepth, overflow, nil
+}
+
+// newColumnCollections classifies the columns of a model under its nested array depth limit
+func newColumnCollections(cfg *config.Config, model *models.DbtModel) (*models.ColumnCollections, error) {
+	maxDepth, _, err := nestedArrayLimits(cfg, model)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewColumnCollectionsWithRules(model, nil, models.NewNestedArrayRulesWithDepth(maxDepth)), nil
+}
+
+// flattensOverflowArrays reports whether arrays beyond the depth limit of a model are kept as
+// JSON string dimensions
+func flattensOverflowArrays(cfg *config.Config, model *models.DbtModel) (bool, error) {
+	_, overflow, err := nestedArrayLimits(cfg, model)
+	if err != nil {
+		return false, err
+	}
+	return overflow == config.NestedArrayOverflowJSON, nil
+}
+
+// unnestsWithOffset reports whether a single-value array of a model is unnested WITH OFFSET,
+// exposing the position of its elements
+func unnestsWithOffset(cfg *config.Config, model *models.DbtModel, arrayColumn *models.DbtModelColumn) bool {
+	if arrayColumn == nil || arrayElementType(arrayColumn) == "" {
+		return false
+	}
+	if model.Meta != nil && model.Meta.Looker != nil && model.Meta.Looker.NestedArrays != nil && model.Meta.Looker.NestedArrays.Offset != nil {
+		return *model.Meta.Looker.NestedArrays.Offset
+	}
+	return cfg.NestedArrays.Offset
+}
+
+// nestedOffsetAlias returns the SQL alias of the element positions of a nested view
+func nestedOffsetAlias(nestedViewName string) string {
+	return nestedViewName + "_offset"
+}
+
+// overflowArrayNames returns the names of the arrays beyond the depth limit directly inside
+// parentArray ("" for the main view), in order
+func overflowArrayNames(columnCollections *models.ColumnCollections, parentArray string) []string {
+	names := make([]string, 0, len(columnCollections.OverflowArrays[parentArray]))
+	for name := range columnCollections.OverflowArrays[parentArray] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// overflowDimension returns the string dimension holding an array beyond the depth limit as JSON
+func overflowDimension(name, sql string, column *models.DbtModelColumn) models.LookMLDimension {
+	return models.LookMLDimension{
+		Name:        name,
+		Type:        string(enums.DataTypeString),
+		SQL:         fmt.Sprintf("TO_JSON_STRING(%s)", sql),
+		Description: column.Description,
+	}
+}
+
+// reportNestedArrayOverflow validates the nested_arrays meta of a model and warns about every
+// column of the arrays beyond its depth limit, which were dropped or flattened to JSON
+func (g *LookMLGenerator) reportNestedArrayOverflow(model *models.DbtModel) error {
+	maxDepth, overflow, err := nestedArrayLimits(g.config, model)
+	if err != nil {
+		return err
+	}
+
+	columnCollections, err := newColumnCollections(g.config, model)
+	if err != nil {
+		return err
+	}
+	var arrayNames []string
+	for parentArray := range columnCollections.OverflowArrays {
+		arrayNames = append(arrayNames, overflowArrayNames(columnCollections, parentArray)...)
+	}
+	sort.Strings(arrayNames)
+
+	for _, arrayName := range arrayNames {
+		if overflow == config.NestedArrayOverflowJSON {
+			g.diagnostics.Warn(model.Name, WarningNestedArray, fmt.Sprintf("array %s is nested deeper than %d levels and was flattened to a JSON string", arrayName, maxDepth))
+		} else {
+			g.diagnostics.Warn(model.Name, WarningNestedArray, fmt.Sprintf("array %s is nested deeper than %d levels and was dropped", arrayName, maxDepth))
+		}
+
+		var columnNames []string
+		for columnName := range columnCollections.ExcludedColumns {
+			if strings.HasPrefix(columnName, arrayName+".") {
+				columnNames = append(columnNames, columnName)
+			}
+		}
+		sort.Strings(columnNames)
+		for _, columnName := range columnNames {
+			if overflow == config.NestedArrayOverflowJSON {
+				g.diagnostics.Warn(model.Name, WarningNestedArray, fmt.Sprintf("column %s is only available inside the JSON string of array %s", columnName, arrayName))
+			} else {
+				g.diagnostics.Warn(model.Name, WarningNestedArray, fmt.Sprintf("column %s was dropped with array %s", columnName, arrayName))
+			}
+		}
+	}
+	return nil
+}
+
+// nestedPrimaryKeyName returns the name of the hidden compound primary key of a nested view.
+// It is prefixed with the view name, like the offset alias, so it does not collide with a
+// field of the array named primary_key.
+func nestedPrimaryKeyName(nestedViewName string) string {
+	return nestedViewName + "_pk"
+}
+
+// primaryKeyColumns returns the primary key columns of the main view of a model, in order
+func primaryKeyColumns(model *models.DbtModel) []models.DbtModelColumn {
+	var columns []models.DbtModelColumn
+	for name, column := range model.Columns {
+		if column.IsPrimaryKey && !column.Nested {
+			column.Name = name
+			columns = append(columns, column)
+		}
+	}
+	sort.Slice(columns, func(i, j int) bool { return columns[i].Name < columns[j].Name })
+	return columns
+}
+
+// joinsWithOffset reports whether an array of a model is unnested WITH OFFSET, either to
+// expose the position of its elements or to build the compound primary key of its view
+func joinsWithOffset(cfg *config.Config, model *models.DbtModel, arrayColumn *models.DbtModelColumn) bool {
+	return len(primaryKeyColumns(model)) > 0 || unnestsWithOffset(cfg, model, arrayColumn)
+}
+
+// nestedArrayViewName returns the name of the nested view of an array. The OriginalName of
+// the array column is used when available, which converts PascalCase to snake_case.
+func nestedArrayViewName(naming NamingStrategy, model *models.DbtModel, arrayName string) string {
+	arrayPath := arrayName
+	if column, ok := model.Columns[arrayName]; ok && column.OriginalName != nil && *column.OriginalName != "" {
+		arrayPath = *column.OriginalName
+	}
+	return naming.NestedViewName(model, arrayPath)
+}
+
+// parentArrayName returns the array an array is nested in, "" for arrays of the main view
+func parentArrayName(model *models.DbtModel, arrayName string) string {
+	parts := strings.Split(arrayName, ".")
+	for i := len(parts) - 1; i > 0; i-- {
+		parent := strings.Join(parts[:i], ".")
+		if column, ok := model.Columns[parent]; ok && column.IsArrayColumn() {
+			return parent
+		}
+	}
+	return ""
+}
+
+// nestedViewFieldName returns the name of the field of a column in the nested view of an
+// array, from its path inside the array: "GTIN.GTINId" in "SupplierInformation" -> gtin__gtin_id
+func nestedViewFieldName(naming NamingStrategy, arrayName string, column *models.DbtModelColumn) string {
+	originalName := column.Name
+	if column.OriginalName != nil && *column.OriginalName != "" {
+		originalName = *column.OriginalName
+	}
+
+	// The array prefix is matched case-insensitively, as OriginalName keeps PascalCase
+	if strings.HasPrefix(strings.ToLower(originalName), strings.ToLower(arrayName)+".") {
+		return naming.FieldName(originalName[len(arrayName)+1:])
+	}
+	return naming.FieldName(originalName)
+}
+
+// Join styles of arrays, set in the looker.unnest meta of the array column
+const (
+	unnestJoinLeft  = "left"
+	unnestJoinCross = "cross"
+)
+
+// arrayUnnest returns how an array column is joined into the explore: with LEFT JOIN UNNEST,
+// which keeps rows with empty arrays, or CROSS JOIN UNNEST, and the relationship of the join
+func arrayUnnest(column *models.DbtModelColumn) (join string, relationship enums.LookerRelationshipType, err error) {
+	join, relationship = unnestJoinLeft, enums.RelationshipOneToMany
+	if column == nil || column.Meta == nil || column.Meta.Looker == nil || column.Meta.Looker.Unnest == nil {
+		return join, relationship, nil
+	}
+
+	meta := column.Meta.Looker.Unnest
+	if meta.Join != nil {
+		metaJoin := strings.ToLower(*meta.Join)
+		if metaJoin != unnestJoinLeft && metaJoin != unnestJoinCross {
+			return join, relationship, fmt.Errorf("invalid unnest.join in meta of column %s: %s (must be one of: %v)", column.Name, *meta.Join, []string{unnestJoinLeft, unnestJoinCross})
+		}
+		join = metaJoin
+	}
+	if meta.Relationship != nil {
+		// Arrays known to hold a single element can be joined one_to_one
+		metaRelationship := enums.LookerRelationshipType(strings.ToLower(string(*meta.Relationship)))
+		if metaRelationship != enums.RelationshipOneToMany && metaRelationship != enums.RelationshipOneToOne {
+			return join, relationship, fmt.Errorf("invalid unnest.relationship in meta of column %s: %s (must be one of: %v)", column.Name, *meta.Relationship, []enums.LookerRelationshipType{enums.RelationshipOneToMany, enums.RelationshipOneToOne})
+		}
+		relationship = metaRelationship
+	}
+	return join, relationship, nil
+}
+
+// checkArrayUnnests validates the looker.unnest meta of the columns of a model. Invalid meta
+// falls back to LEFT JOIN UNNEST in the explore generator.
+func checkArrayUnnests(model *models.DbtModel) error {
+	names := make([]string, 0, len(model.Columns))
+	for name := range model.Columns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		column := model.Columns[name]
+		if column.Meta == nil || column.Meta.Looker == nil || column.Meta.Looker.Unnest == nil {
+			continue
+		}
+		if !column.IsArrayColumn() {
+			return fmt.Errorf("unnest meta of column %s: only array columns are unnested", name)
+		}
+		if _, _, err := arrayUnnest(&column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,331 @@
+package generators
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/parsers"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createDeepArrayModel returns the layout model with an array inside its array and an array
+// of strings inside a struct
+func createDeepArrayModel() *models.DbtModel {
+	model := createLayoutModel()
+	for _, column := range []models.DbtModelColumn{
+		testColumn("lines.parts", "ARRAY<STRUCT<code STRING>>"),
+		testColumn("lines.parts.code", "STRING"),
+		testColumn("info", "STRUCT<history ARRAY<STRING>>"),
+		testColumn("info.history", "ARRAY<STRING>"),
+	} {
+		model.Columns[column.Name] = column
+	}
+	return model
+}
+
+// warningMessages returns the messages of the nested array warnings
+func warningMessages(warnings []ModelWarning) []string {
+	messages := make([]string, 0, len(warnings))
+	for _, warning := range warnings {
+		if warning.Category == WarningNestedArray {
+			messages = append(messages, warning.Message)
+		}
+	}
+	return messages
+}
+
+func TestNestedArrays_DropBeyondDepth(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir, NestedArrays: config.NestedArraysConfig{MaxDepth: 1}}
+
+	result, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createDeepArrayModel()}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.Contains(t, content, "view: orders__lines {")
+	assert.NotContains(t, content, "parts")
+	assert.NotContains(t, content, "history")
+
+	assert.Equal(t, []string{
+		"array info.history is nested deeper than 1 levels and was dropped",
+		"array lines.parts is nested deeper than 1 levels and was dropped",
+		"column lines.parts.code was dropped with array lines.parts",
+	}, warningMessages(result.Warnings))
+}
+
+func TestNestedArrays_FlattenToJSON(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir, NestedArrays: config.NestedArraysConfig{MaxDepth: 1}}
+	model := createDeepArrayModel()
+	model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{
+		NestedArrays: &models.DbtMetaLookerNestedArrays{Overflow: utils.StringPtr("json")},
+	}}
+
+	result, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.Contains(t, content, "  dimension: info__history {\n    type: string\n    sql: TO_JSON_STRING(${TABLE}.info.history) ;;\n")
+	assert.Contains(t, content, "  dimension: parts {\n    type: string\n    sql: TO_JSON_STRING(${TABLE}.parts) ;;\n")
+	assert.NotContains(t, content, "orders__lines__parts")
+
+	assert.Contains(t, warningMessages(result.Warnings), "array lines.parts is nested deeper than 1 levels and was flattened to a JSON string")
+	assert.Contains(t, warningMessages(result.Warnings), "column lines.parts.code is only available inside the JSON string of array lines.parts")
+}
+
+func TestNestedArrays_ModelDepth(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir, NestedArrays: config.NestedArraysConfig{MaxDepth: 1}}
+	model := createDeepArrayModel()
+	model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{
+		NestedArrays: &models.DbtMetaLookerNestedArrays{MaxDepth: utils.IntPtr(2)},
+	}}
+
+	result, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.Contains(t, content, "view: orders__lines__parts {")
+	assert.Contains(t, content, "view: orders__info__history {")
+	assert.Empty(t, warningMessages(result.Warnings))
+}
+
+func TestNestedArrays_InvalidMeta(t *testing.T) {
+	cfg := &config.Config{OutputDir: t.TempDir()}
+	model := createDeepArrayModel()
+	model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{
+		NestedArrays: &models.DbtMetaLookerNestedArrays{Overflow: utils.StringPtr("keep")},
+	}}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{ErrorStrategy: FailFast})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid nested_arrays.overflow in meta: keep")
+}
+
+func TestNestedArrays_InvalidMetaFailsGenerators(t *testing.T) {
+	cfg := &config.Config{}
+	model := createDeepArrayModel()
+	model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{
+		NestedArrays: &models.DbtMetaLookerNestedArrays{MaxDepth: utils.IntPtr(0)},
+	}}
+
+	_, err := NewViewGenerator(cfg).GenerateView(model)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid nested_arrays.max_depth in meta: 0")
+
+	_, err = NewExploreGenerator(cfg).GenerateExplore(model)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid nested_arrays.max_depth in meta: 0")
+}
+
+// createScalarArrayModel returns the layout model with arrays of numbers, dates and strings,
+// the strings typed only through their inner types
+func createScalarArrayModel() *models.DbtModel {
+	model := createLayoutModel()
+	labels := testColumn("labels", "ARRAY")
+	labels.InnerTypes = []string{"STRING"}
+	for _, column := range []models.DbtModelColumn{
+		testColumn("scores", "ARRAY<INT64>"),
+		testColumn("holidays", "ARRAY<DATE>"),
+		labels,
+	} {
+		model.Columns[column.Name] = column
+	}
+	return model
+}
+
+func TestNestedArrays_ScalarElementTypes(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createScalarArrayModel()}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.Contains(t, content, "view: orders__scores {\n  dimension: orders__scores {\n    type: number\n    sql: orders__scores ;;\n  }\n")
+	assert.Contains(t, content, "view: orders__labels {\n  dimension: orders__labels {\n    type: string\n    sql: orders__labels ;;\n  }\n")
+	assert.Contains(t, content, "  dimension_group: orders__holidays {\n    type: time\n    sql: orders__holidays ;;\n")
+	assert.Contains(t, content, "    datatype: date\n")
+
+	// Without offsets, arrays are unnested as before
+	assert.Contains(t, content, "sql: LEFT JOIN UNNEST(${orders.scores}) as orders__scores ;;")
+	assert.NotContains(t, content, "WITH OFFSET")
+	assert.NotContains(t, content, "dimension: offset {")
+}
+
+func TestNestedArrays_Offset(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir, NestedArrays: config.NestedArraysConfig{Offset: true}}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createScalarArrayModel()}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.Contains(t, content, "sql: LEFT JOIN UNNEST(${orders.scores}) as orders__scores WITH OFFSET as orders__scores_offset ;;")
+	assert.Contains(t, content, "  dimension: offset {\n    type: number\n    sql: orders__scores_offset ;;\n    description: \"Position of the element in the array, starting at 0\"\n  }\n")
+	assert.Contains(t, content, "  measure: count {\n    type: count\n    drill_fields: [detail*]\n  }\n")
+
+	// ARRAY<STRUCT> columns have no single element to position
+	assert.Contains(t, content, "sql: LEFT JOIN UNNEST(${orders.lines}) as orders__lines ;;")
+}
+
+func TestNestedArrays_OffsetMeta(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir, NestedArrays: config.NestedArraysConfig{Offset: true}}
+	model := createScalarArrayModel()
+	model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{
+		NestedArrays: &models.DbtMetaLookerNestedArrays{Offset: boolPtr(false)},
+	}}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+
+	assert.NotContains(t, readOutput(t, outputDir, "marts/orders.view.lkml"), "WITH OFFSET")
+}
+
+func TestNestedArrays_CompoundPrimaryKey(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir}
+	model := createDeepArrayModel()
+	id := model.Columns["id"]
+	id.IsPrimaryKey = true
+	model.Columns["id"] = id
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.Contains(t, content, "  dimension: id {\n    primary_key: yes\n    type: number\n")
+	assert.Contains(t, content, "view: orders__lines {\n  dimension: orders__lines_pk {\n    primary_key: yes\n    type: string\n    sql: CONCAT(CAST(${orders.id} AS STRING), '-', CAST(orders__lines_offset AS STRING)) ;;\n    hidden: yes\n  }\n")
+	assert.Contains(t, content, "    sql: CONCAT(CAST(${orders.id} AS STRING), '-', CAST(orders__info__history_offset AS STRING)) ;;\n")
+
+	// Arrays in arrays extend the key of the enclosing nested view
+	assert.Contains(t, content, "    sql: CONCAT(${orders__lines.orders__lines_pk}, '-', CAST(orders__lines__parts_offset AS STRING)) ;;\n")
+
+	assert.Contains(t, content, "sql: LEFT JOIN UNNEST(${orders.lines}) as orders__lines WITH OFFSET as orders__lines_offset ;;")
+	for _, view := range []string{"orders__lines", "orders__lines__parts", "orders__info__history"} {
+		assert.Contains(t, nestedViewContent(t, content, view), "  measure: count {\n    type: count\n    drill_fields: [detail*]\n  }\n")
+	}
+}
+
+// createTestManifest returns the raw manifest and catalog of a model at models/<path> with
+// columns of the given BigQuery types, keyed by the primary_key constraint of one of them
+func createTestManifest(name, path, primaryKey string, columns map[string]string) (map[string]interface{}, map[string]interface{}) {
+	uniqueID := "model.test." + name
+	manifestColumns := make(map[string]interface{})
+	catalogColumns := make(map[string]interface{})
+	for columnName, dataType := range columns {
+		manifestColumn := map[string]interface{}{"name": columnName}
+		if columnName == primaryKey {
+			manifestColumn["constraints"] = []interface{}{map[string]interface{}{"type": "primary_key"}}
+		}
+		manifestColumns[columnName] = manifestColumn
+		catalogColumns[columnName] = map[string]interface{}{"name": columnName, "type": dataType}
+	}
+
+	manifest := map[string]interface{}{
+		"metadata": map[string]interface{}{"adapter_type": "bigquery"},
+		"nodes": map[string]interface{}{
+			uniqueID: map[string]interface{}{
+				"name":          name,
+				"resource_type": "model",
+				"unique_id":     uniqueID,
+				"relation_name": "`project.dataset." + name + "`",
+				"path":          path,
+				"columns":       manifestColumns,
+			},
+		},
+	}
+	catalog := map[string]interface{}{
+		"nodes": map[string]interface{}{
+			uniqueID: map[string]interface{}{"columns": catalogColumns},
+		},
+	}
+	return manifest, catalog
+}
+
+// parseTestModels parses the models of a raw manifest and catalog as a run does
+func parseTestModels(t *testing.T, manifest, catalog map[string]interface{}) []*models.DbtModel {
+	t.Helper()
+
+	parser, err := parsers.NewDbtParser(&config.Config{}, manifest, catalog)
+	require.NoError(t, err)
+	dbtModels, err := parser.GetModels()
+	require.NoError(t, err)
+	return dbtModels
+}
+
+func TestNestedArrays_CompoundPrimaryKeyWithPrimaryKeyField(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir}
+	model := createLayoutModel()
+	id := model.Columns["id"]
+	id.IsPrimaryKey = true
+	model.Columns["id"] = id
+	model.Columns["lines"] = testColumn("lines", "ARRAY<STRUCT<sku STRING, quantity INT64, primary_key STRING>>")
+	model.Columns["lines.primary_key"] = testColumn("lines.primary_key", "STRING")
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+
+	// A field of the array named primary_key keeps its name next to the compound key
+	lines := nestedViewContent(t, readOutput(t, outputDir, "marts/orders.view.lkml"), "orders__lines")
+	assert.Equal(t, 1, strings.Count(lines, "  dimension: primary_key {\n"))
+	assert.Equal(t, 1, strings.Count(lines, "  dimension: orders__lines_pk {\n"))
+	assert.Equal(t, 1, strings.Count(lines, "primary_key: yes"))
+}
+
+func TestNestedArrays_CompoundPrimaryKeyFromManifest(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir}
+	manifest, catalog := createTestManifest("orders", "marts/orders.sql", "OrderId", map[string]string{
+		"OrderId":        "INT64",
+		"Lines":          "ARRAY<STRUCT<Sku STRING, Quantity INT64>>",
+		"Lines.Sku":      "STRING",
+		"Lines.Quantity": "INT64",
+	})
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), parseTestModels(t, manifest, catalog), GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.Contains(t, content, "  dimension: order_id {\n    primary_key: yes\n")
+	assert.Contains(t, nestedViewContent(t, content, "orders__lines"), "    primary_key: yes\n    type: string\n    sql: CONCAT(CAST(${orders.order_id} AS STRING), '-', CAST(orders__lines_offset AS STRING)) ;;\n")
+	assert.Contains(t, content, "sql: LEFT JOIN UNNEST(${orders.lines}) as orders__lines WITH OFFSET as orders__lines_offset ;;")
+}
+
+func TestNestedArrays_WithoutPrimaryKey(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createLayoutModel()}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.NotContains(t, content, "primary_key")
+	assert.Contains(t, content, "sql: LEFT JOIN UNNEST(${orders.lines}) as orders__lines ;;")
+
+	// Nested views are still counted
+	assert.Contains(t, nestedViewContent(t, content, "orders__lines"), "  measure: count {\n    type: count\n    drill_fields: [detail*]\n  }\n")
+}
+
+// nestedViewContent returns the LookML of a view, up to the next view or explore
+func nestedViewContent(t *testing.T, content, viewName string) string {
+	t.Helper()
+
+	start := strings.Index(content, "view: "+viewName+" {")
+	require.NotEqual(t, -1, start, "view %s not found", viewName)
+	rest := content[start+1:]
+	if end := strings.Index(rest, "\nview: "); end != -1 {
+		rest = rest[:end]
+	}
+	if end := strings.Index(rest, "\nexplore: "); end != -1 {
+		rest = rest[:end]
+	}
+	return rest
+}
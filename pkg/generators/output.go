@@ -0,0 +1,152 @@
+package generators
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+)
+
+// outputFile is a single file planned for a model before it is written.
+// Files are rendered from their LookML objects unless Content is set.
+type outputFile struct {
+	// Model is the name of the dbt model the file was planned for
+	Model string
+
+	// Path is relative to the output directory
+	Path string
+
+	// Includes are emitted as include: statements at the top of the file
+	Includes []string
+
+	// Views and Explores are rendered in order (views first)
+	Views    []*models.LookMLView
+	Explores []*models.LookMLExplore
+
+	// Content, when set, is written verbatim instead of rendering views and explores
+	Content string
+
+	// Scaffold files are only written when they do not exist yet, so hand edits survive
+	Scaffold bool
+}
+
+// renderOutputFile renders the LookML content of a planned file
+func (g *LookMLGenerator) renderOutputFile(file *outputFile) (string, error) {
+	if file.Content != "" {
+		return file.Content, nil
+	}
+
+	var builder strings.Builder
+
+	for _, include := range file.Includes {
+		builder.WriteString(fmt.Sprintf("include: \"%s\"\n", include))
+	}
+	if len(file.Includes) > 0 {
+		builder.WriteString("\n")
+	}
+
+	for i, view := range file.Views {
+		viewContent, err := g.viewToLookML(view)
+		if err != nil {
+			return "", fmt.Errorf("failed to convert view %s to LookML: %w", view.Name, err)
+		}
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(viewContent)
+	}
+
+	for _, explore := range file.Explores {
+		exploreContent, err := g.exploreToLookML(explore)
+		if err != nil {
+			return "", fmt.Errorf("failed to convert explore %s to LookML: %w", explore.Name, err)
+		}
+		builder.WriteString(exploreContent)
+	}
+
+	return builder.String(), nil
+}
+
+// renderedBlock is a rendered LookML block identified by its kind and name
+type renderedBlock struct {
+	Kind string
+	Name string
+	Text string
+}
+
+// viewParamKind is the kind of rendered blocks holding a simple view parameter, such as label
+const viewParamKind = "param"
+
+// key identifies the block within its parent, e.g. "dimension:id" or "param:label"
+func (b renderedBlock) key() string {
+	return b.Kind + ":" + b.Name
+}
+
+// writeOutputFile renders and writes a planned file.
+// Returns false without error when a scaffold file already exists.
+func (g *LookMLGenerator) writeOutputFile(file *outputFile) (bool, error) {
+	filePath := g.config.GetOutputPath(file.Path)
+
+	if file.Scaffold {
+		if _, err := os.Stat(filePath); err == nil {
+			g.config.Logger().Debug().Str("file", filePath).Msg("Keeping existing scaffold file")
+			return false, nil
+		}
+	}
+
+	var content string
+	var err error
+	if g.config.Merge && !file.Scaffold && file.Content == "" {
+		content, err = g.mergeOutputFile(file, filePath)
+	} else {
+		content, err = g.renderOutputFile(file)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Create directory if it doesn't exist
+	if err := os.MkdirAll(filepath.Dir(filePath), dirPermissions); err != nil {
+		return false, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, []byte(content), filePermissions); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", file.Path, err)
+	}
+
+	g.config.Logger().Debug().Str("file", filePath).Msg("Generated LookML file")
+	return true, nil
+}
+
+// finishRun writes the files that depend on all generated models and persists run state.
+// It runs only once the views of every planned model have been written.
+func (g *LookMLGenerator) finishRun() error {
+	if err := g.writeModelFiles(); err != nil {
+		return fmt.Errorf("failed to write model files: %w", err)
+	}
+
+	if err := g.writeLocalizationFiles(); err != nil {
+		return fmt.Errorf("failed to write localization files: %w", err)
+	}
+
+	return g.saveMergeState()
+}
+
+// stopRun ends a run stopped while writing views. Model and localization files would include
+// views that were not written, so only the state of the written views is persisted.
+func (g *LookMLGenerator) stopRun(stopErr error) error {
+	if err := g.saveMergeState(); err != nil {
+		g.config.Logger().Warn().Err(err).Msg("Failed to save merge state")
+	}
+	return stopErr
+}
+
+// saveMergeState persists the fields generated into each written view for the next run
+func (g *LookMLGenerator) saveMergeState() error {
+	if g.mergeState != nil {
+		return g.mergeState.save(g.config.GetOutputPath(mergeStateFilename))
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package generators
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+)
+
+// Kinds of files named by the output_path template
+const (
+	outputKindView       = "view"
+	outputKindNestedView = "nested_view"
+	outputKindExplore    = "explore"
+)
+
+// outputPathData holds the variables of the output_path template
+type outputPathData struct {
+	ViewName     string                 // View named by the file; the explore name for explore files
+	ModelName    string                 // dbt model name
+	Kind         string                 // view, nested_view or explore
+	Schema       string                 // Schema of the model, without remove_schema_string
+	Database     string                 // Database (BigQuery project) of the model
+	Package      string                 // dbt package of the model
+	Tags         []string               // dbt tags of the model
+	Meta         map[string]interface{} // All meta keys of the model
+	Path         string                 // Directory of the model in the dbt project, e.g. marts/sales
+	PathSegments []string               // Path split into its directories
+}
+
+// newOutputPathData collects the template variables of a file of a model
+func (g *LookMLGenerator) newOutputPathData(model *models.DbtModel, kind, name string) outputPathData {
+	data := outputPathData{
+		ViewName:  name,
+		ModelName: model.Name,
+		Kind:      kind,
+		Schema:    model.Schema,
+		Database:  model.Database,
+		Package:   model.PackageName,
+		Tags:      model.Tags,
+		Meta:      map[string]interface{}{},
+	}
+	if g.config.RemoveSchemaString != "" {
+		data.Schema = strings.ReplaceAll(data.Schema, g.config.RemoveSchemaString, "")
+	}
+	if model.Meta != nil && model.Meta.Values != nil {
+		data.Meta = model.Meta.Values
+	}
+	if model.Path != "" {
+		data.Path = strings.Trim(filepath.ToSlash(filepath.Dir(model.Path)), "/.")
+	}
+	if data.Path != "" {
+		data.PathSegments = strings.Split(data.Path, "/")
+	}
+	return data
+}
+
+// renderOutputPath renders the output_path template for a file of a model. The path is
+// returned without extension; the extension of the file kind is added by the caller.
+func (g *LookMLGenerator) renderOutputPath(model *models.DbtModel, kind, name string) (string, error) {
+	if g.outputPath == nil {
+		tmpl, err := config.ParseOutputPath(g.config.OutputPath)
+		if err != nil {
+			return "", err
+		}
+		g.outputPath = tmpl
+	}
+
+	var builder strings.Builder
+	if err := g.outputPath.Execute(&builder, g.newOutputPathData(model, kind, name)); err != nil {
+		return "", fmt.Errorf("failed to render output_path for model %s: %w", model.Name, err)
+	}
+
+	rendered := strings.TrimSpace(builder.String())
+	for _, segment := range strings.Split(rendered, "/") {
+		if segment == ".." {
+			return "", fmt.Errorf("output_path for model %s renders %q, which leaves the output directory", model.Name, rendered)
+		}
+	}
+	// Templates name the view file; other kinds get their own extension
+	for _, extension := range []string{".view.lkml", ".explore.lkml", ".lkml"} {
+		if strings.HasSuffix(rendered, extension) {
+			rendered = strings.TrimSuffix(rendered, extension)
+			break
+		}
+	}
+
+	// Empty template values leave empty segments, which are dropped
+	rendered = path.Clean("/" + rendered)[1:]
+	if rendered == "" {
+		return "", fmt.Errorf("output_path for model %s renders an empty path", model.Name)
+	}
+	return rendered, nil
+}
+
+// plannedModel is a model whose files are planned but not written yet
+type plannedModel struct {
+	model *models.DbtModel
+	files []outputFile
+}
+
+// checkPathCollisions reports the files that several models, or several views of a model,
+// would write to the same path
+func checkPathCollisions(planned []plannedModel) error {
+	owners := make(map[string][]string)
+	for _, model := range planned {
+		for _, file := range model.files {
+			// Paths are compared case-insensitively, as some file systems do
+			key := strings.ToLower(file.Path)
+			owners[key] = append(owners[key], fmt.Sprintf("%s (%s)", file.Path, model.model.Name))
+		}
+	}
+
+	var collisions []string
+	for _, files := range owners {
+		if len(files) > 1 {
+			collisions = append(collisions, strings.Join(files, ", "))
+		}
+	}
+	if len(collisions) == 0 {
+		return nil
+	}
+
+	sort.Strings(collisions)
+	return fmt.Errorf("%d output paths are planned more than once, nothing was written: %s", len(collisions), strings.Join(collisions, "; "))
+}
@@ -0,0 +1,181 @@
+package generators
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createOutputPathModel returns the layout model under another name, schema, meta and tags
+func createOutputPathModel(name, schema string, meta map[string]interface{}, tags ...string) *models.DbtModel {
+	model := createLayoutModel()
+	model.Name = name
+	model.Schema = schema
+	model.Database = "project"
+	model.PackageName = "shop"
+	model.Tags = tags
+	model.RelationName = "`project." + schema + "." + name + "`"
+	if meta != nil {
+		model.Meta = &models.DbtModelMeta{Values: meta}
+	}
+	return model
+}
+
+func TestOutputPath_Template(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  outputDir,
+		OutputPath: "{{ .Meta.domain }}/{{ .Schema }}/{{ .ViewName }}.view.lkml",
+	}
+	model := createOutputPathModel("orders", "sales_mart", map[string]interface{}{"domain": "commerce"})
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "commerce/sales_mart/orders.view.lkml")
+	assert.Contains(t, content, "view: orders {")
+	assert.Contains(t, content, "explore: orders {")
+}
+
+func TestOutputPath_SplitLayout(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  outputDir,
+		Layout:     config.LayoutSplit,
+		OutputPath: "{{ .Package }}/{{ .Schema }}/{{ .ViewName }}.view.lkml",
+	}
+	model := createOutputPathModel("orders", "sales", nil)
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+
+	assert.Contains(t, readOutput(t, outputDir, "views/shop/sales/orders.view.lkml"), "view: orders {")
+	assert.Contains(t, readOutput(t, outputDir, "explores/shop/sales/orders.explore.lkml"), "explore: orders {")
+}
+
+func TestOutputPath_Variables(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		model    *models.DbtModel
+		expected string
+	}{
+		{
+			name:     "path segments and kind",
+			template: "{{ index .PathSegments 0 }}/{{ .Kind }}/{{ .ViewName }}",
+			model:    createOutputPathModel("orders", "sales", nil),
+			expected: "marts/view/orders",
+		},
+		{
+			name:     "database and model name",
+			template: "{{ .Database }}/{{ .ModelName }}.lkml",
+			model:    createOutputPathModel("orders", "sales", nil),
+			expected: "project/orders",
+		},
+		{
+			name:     "tag value",
+			template: `{{ tagValue "domain:" .Tags }}/{{ .ViewName }}`,
+			model:    createOutputPathModel("orders", "sales", nil, "nightly", "domain:commerce"),
+			expected: "commerce/orders",
+		},
+		{
+			name:     "default for missing meta key",
+			template: `{{ default "shared" (index .Meta "domain") }}/{{ .ViewName }}`,
+			model:    createOutputPathModel("orders", "sales", nil),
+			expected: "shared/orders",
+		},
+		{
+			name:     "empty segments are dropped",
+			template: `{{ tagValue "domain:" .Tags }}/{{ lower .ViewName }}`,
+			model:    createOutputPathModel("Orders", "sales", nil),
+			expected: "orders",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := NewLookMLGenerator(&config.Config{OutputPath: tt.template})
+
+			rendered, err := generator.renderOutputPath(tt.model, outputKindView, tt.model.Name)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rendered)
+		})
+	}
+}
+
+func TestOutputPath_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		errMsg   string
+	}{
+		{
+			name:     "missing meta key",
+			template: "{{ .Meta.domain }}/{{ .ViewName }}",
+			errMsg:   "failed to render output_path for model orders",
+		},
+		{
+			name:     "leaves the output directory",
+			template: "../{{ .ViewName }}",
+			errMsg:   "leaves the output directory",
+		},
+		{
+			name:     "empty path",
+			template: `{{ tagValue "domain:" .Tags }}`,
+			errMsg:   "renders an empty path",
+		},
+		{
+			name:     "invalid template",
+			template: "{{ .ViewName",
+			errMsg:   "invalid output_path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := NewLookMLGenerator(&config.Config{OutputPath: tt.template})
+			model := createOutputPathModel("orders", "sales", nil)
+
+			_, err := generator.renderOutputPath(model, outputKindView, model.Name)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestOutputPath_Collisions(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  outputDir,
+		OutputPath: "{{ .Schema }}/{{ .ViewName }}.view.lkml",
+		Naming:     config.NamingConfig{Strategy: config.NamingStrategyTableName},
+	}
+	first := createOutputPathModel("sales__orders", "sales", nil)
+	first.RelationName = "`project.sales.orders`"
+	second := createOutputPathModel("archive__orders", "sales", nil)
+	second.RelationName = "`project.sales.orders`"
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{first, second}, GenerationOptions{ErrorStrategy: ContinueOnError})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nothing was written")
+	assert.Contains(t, err.Error(), "sales/orders.view.lkml (sales__orders)")
+	assert.Contains(t, err.Error(), "sales/orders.view.lkml (archive__orders)")
+
+	entries, readErr := os.ReadDir(outputDir)
+	require.NoError(t, readErr)
+	assert.Empty(t, entries)
+}
+
+func TestOutputPath_MetaValues(t *testing.T) {
+	var meta models.DbtModelMeta
+	require.NoError(t, json.Unmarshal([]byte(`{"domain": "commerce", "looker": {"label": "Orders"}}`), &meta))
+
+	assert.Equal(t, "commerce", meta.Values["domain"])
+	require.NotNil(t, meta.Looker)
+}
@@ -0,0 +1,177 @@
+package generators
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+)
+
+// getFieldParameters collects the pass-through parameters of a column's dimension or
+// dimension group. dbt column tags come first, followed by the tags set in meta.
+func (g *DimensionGenerator) getFieldParameters(column *models.DbtModelColumn) models.LookMLFieldParameters {
+	parameters := models.LookMLFieldParameters{Tags: mergeTags(column.Tags, nil)}
+	if column.Meta != nil && column.Meta.Looker != nil && column.Meta.Looker.Dimension != nil {
+		meta := column.Meta.Looker.Dimension
+		parameters.Links = meta.Links
+		parameters.HTML = meta.HTML
+		parameters.Tags = mergeTags(column.Tags, meta.Tags)
+		parameters.RequiredFields = meta.RequiredFields
+		parameters.Alias = meta.Alias
+		parameters.Extra = meta.Extra
+		parameters.RequiredAccessGrants = meta.RequiredAccessGrants
+	}
+	if column.Meta != nil && column.Meta.Looker != nil {
+		parameters.Translations = column.Meta.Looker.Translations
+	}
+
+	g.applyPIIGovernance(column, &parameters)
+	return parameters
+}
+
+// applyDimensionParameters sets the pass-through parameters of a column on its dimension
+func (g *DimensionGenerator) applyDimensionParameters(dimension *models.LookMLDimension, column *models.DbtModelColumn) {
+	dimension.LookMLFieldParameters = g.getFieldParameters(column)
+	if column.Meta == nil || column.Meta.Looker == nil || column.Meta.Looker.Dimension == nil {
+		return
+	}
+
+	meta := column.Meta.Looker.Dimension
+	dimension.OrderByField = meta.OrderByField
+	dimension.SuggestDimension = meta.SuggestDimension
+	dimension.CaseSensitive = meta.CaseSensitive
+}
+
+// measureFieldParameters returns the pass-through parameters of a measure's meta
+func measureFieldParameters(measureMeta *models.DbtMetaLookerMeasure) models.LookMLFieldParameters {
+	return models.LookMLFieldParameters{
+		Links:          measureMeta.Links,
+		HTML:           measureMeta.HTML,
+		Tags:           mergeTags(measureMeta.Tags, nil),
+		RequiredFields: measureMeta.RequiredFields,
+		Alias:          measureMeta.Alias,
+		Extra:          measureMeta.Extra,
+
+		RequiredAccessGrants: measureMeta.RequiredAccessGrants,
+		Translations:         measureMeta.Translations,
+	}
+}
+
+// mergeTags concatenates tag lists, dropping empty and repeated tags
+func mergeTags(tags, more []string) []string {
+	var merged []string
+	seen := make(map[string]bool, len(tags)+len(more))
+	for _, tag := range append(append([]string{}, tags...), more...) {
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		merged = append(merged, tag)
+	}
+	return merged
+}
+
+// fieldParametersToLookML renders the pass-through parameters of a field, except extra
+func fieldParametersToLookML(builder *strings.Builder, parameters *models.LookMLFieldParameters) {
+	for _, link := range parameters.Links {
+		builder.WriteString("    link: {\n")
+		builder.WriteString(fmt.Sprintf("      label: %s\n", quoteString(link.Label)))
+		builder.WriteString(fmt.Sprintf("      url: %s\n", quoteString(link.URL)))
+		if link.IconURL != nil {
+			builder.WriteString(fmt.Sprintf("      icon_url: %s\n", quoteString(*link.IconURL)))
+		}
+		builder.WriteString("    }\n")
+	}
+
+	if parameters.HTML != nil {
+		builder.WriteString(fmt.Sprintf("    html: %s ;;\n", *parameters.HTML))
+	}
+
+	if len(parameters.Tags) > 0 {
+		builder.WriteString(fmt.Sprintf("    tags: [%s]\n", quoteList(parameters.Tags)))
+	}
+
+	if len(parameters.RequiredFields) > 0 {
+		builder.WriteString(fmt.Sprintf("    required_fields: [%s]\n", strings.Join(parameters.RequiredFields, ", ")))
+	}
+
+	if len(parameters.Alias) > 0 {
+		builder.WriteString(fmt.Sprintf("    alias: [%s]\n", strings.Join(parameters.Alias, ", ")))
+	}
+
+	if len(parameters.RequiredAccessGrants) > 0 {
+		builder.WriteString(fmt.Sprintf("    required_access_grants: [%s]\n", strings.Join(parameters.RequiredAccessGrants, ", ")))
+	}
+}
+
+// extraToLookML renders extra parameters in name order. Values are written as given:
+// booleans become yes/no, lists are bracketed, maps become nested blocks and sql and html
+// parameters are terminated by ;;. Values are checked by models.ValidateExtraParameters.
+func extraToLookML(builder *strings.Builder, extra map[string]interface{}, indent string) {
+	keys := make([]string, 0, len(extra))
+	for key := range extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		switch value := extra[key].(type) {
+		case map[string]interface{}:
+			builder.WriteString(fmt.Sprintf("%s%s: {\n", indent, key))
+			extraToLookML(builder, value, indent+"  ")
+			builder.WriteString(fmt.Sprintf("%s}\n", indent))
+		case []interface{}:
+			items := make([]string, 0, len(value))
+			for _, item := range value {
+				items = append(items, extraValueToLookML(item))
+			}
+			builder.WriteString(fmt.Sprintf("%s%s: [%s]\n", indent, key, strings.Join(items, ", ")))
+		case []string:
+			builder.WriteString(fmt.Sprintf("%s%s: [%s]\n", indent, key, strings.Join(value, ", ")))
+		default:
+			if models.IsTerminatedParameter(key) {
+				builder.WriteString(fmt.Sprintf("%s%s: %s ;;\n", indent, key, extraValueToLookML(value)))
+			} else {
+				builder.WriteString(fmt.Sprintf("%s%s: %s\n", indent, key, extraValueToLookML(value)))
+			}
+		}
+	}
+}
+
+// extraValueToLookML renders a scalar extra parameter value
+func extraValueToLookML(value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		return yesNo(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// yesNo renders a boolean as a LookML yes/no value
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+// validateViewParameters checks the dimensions and dimension groups of a nested view, which
+// carry pass-through parameters from meta. Main views are validated as a whole.
+func validateViewParameters(view *models.LookMLView) error {
+	for _, dimension := range view.Dimensions {
+		if err := dimension.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, dimensionGroup := range view.DimensionGroups {
+		if err := dimensionGroup.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package generators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createParametersModel returns an orders model with view, measure and dimension parameters
+// such as tags, links, html, aliases and extra parameters
+func createParametersModel() *models.DbtModel {
+	html := `<a href="/dashboards/7?status={{ value }}">{{ value }}</a>`
+
+	orderID := testColumn("order_id", "INT64")
+	orderID.Tags = []string{"key"}
+
+	status := testColumn("status", "STRING")
+	status.Tags = []string{"pii", "sales"}
+	status.Meta = &models.DbtModelColumnMeta{Looker: &models.DbtMetaLooker{Dimension: &models.DbtMetaLookerDimension{
+		DbtMetaLookerBase: models.DbtMetaLookerBase{
+			Extra: map[string]interface{}{
+				"view_label": `"Order Status"`,
+				"action":     map[string]interface{}{"label": `"Escalate"`, "url": `"https://example.com/escalate"`},
+			},
+		},
+		DbtMetaLookerFieldParameters: models.DbtMetaLookerFieldParameters{
+			Links: []models.DbtMetaLookerLink{{Label: `Search "status"`, URL: "https://example.com/search?q={{ value }}"}},
+			HTML:  &html,
+			Tags:  []string{"sales", "status"},
+			Alias: []string{"order_status"},
+		},
+		OrderByField:  utils.StringPtr("status_rank"),
+		CaseSensitive: utils.BoolPtr(false),
+	}}}
+
+	createdAt := testColumn("created_at", "TIMESTAMP")
+	createdAt.Tags = []string{"audit"}
+	createdAt.Meta = &models.DbtModelColumnMeta{Looker: &models.DbtMetaLooker{Dimension: &models.DbtMetaLookerDimension{
+		DbtMetaLookerFieldParameters: models.DbtMetaLookerFieldParameters{
+			Links:          []models.DbtMetaLookerLink{{Label: "Audit log", URL: "https://example.com/audit?at={{ value }}"}},
+			HTML:           utils.StringPtr("<i>{{ rendered_value }}</i>"),
+			RequiredFields: []string{"order_id"},
+			Alias:          []string{"created"},
+		},
+	}}}
+
+	model := createTestModel("orders", "sales/orders.sql", orderID, status, createdAt)
+	model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{
+		View: &models.DbtMetaLookerBase{Extra: map[string]interface{}{"suggestions": false}},
+		Measures: []models.DbtMetaLookerMeasure{{
+			Type: enums.MeasureSumDistinct,
+			Name: utils.StringPtr("total_shipping"),
+			DbtMetaLookerBase: models.DbtMetaLookerBase{
+				Label: utils.StringPtr("Total Shipping"),
+				Extra: map[string]interface{}{"value_format": `"$#,##0.00"`},
+			},
+			SQL:            utils.StringPtr("${TABLE}.shipping_cost"),
+			SQLDistinctKey: utils.StringPtr("${order_id}"),
+			DbtMetaLookerFieldParameters: models.DbtMetaLookerFieldParameters{
+				Tags:           []string{"finance"},
+				RequiredFields: []string{"order_id"},
+			},
+		}},
+	}}
+	return model
+}
+
+func TestViewGenerator_FieldParameters(t *testing.T) {
+	view, err := NewViewGenerator(&config.Config{}).GenerateView(createParametersModel())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{"suggestions": false}, view.Extra)
+
+	status := findDimension(view, "status")
+	require.NotNil(t, status)
+	assert.Equal(t, []string{"pii", "sales", "status"}, status.Tags)
+	assert.Equal(t, []string{"order_status"}, status.Alias)
+	require.Len(t, status.Links, 1)
+	assert.Equal(t, `Search "status"`, status.Links[0].Label)
+	require.NotNil(t, status.OrderByField)
+	assert.Equal(t, "status_rank", *status.OrderByField)
+	require.NotNil(t, status.CaseSensitive)
+	assert.False(t, *status.CaseSensitive)
+
+	orderID := findDimension(view, "order_id")
+	require.NotNil(t, orderID)
+	assert.Equal(t, []string{"key"}, orderID.Tags)
+
+	created := findDimensionGroup(view, "created_at")
+	require.NotNil(t, created)
+	assert.Equal(t, []string{"audit"}, created.Tags)
+	assert.Equal(t, []string{"created"}, created.Alias)
+	require.Len(t, created.Links, 1)
+	assert.Equal(t, "Audit log", created.Links[0].Label)
+	require.NotNil(t, created.HTML)
+	assert.Equal(t, []string{"order_id"}, created.RequiredFields)
+}
+
+func TestViewGenerator_InvalidParameters(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(model *models.DbtModel)
+		errorMsg string
+	}{
+		{
+			name: "dimension extra breaks out of its block",
+			modify: func(model *models.DbtModel) {
+				column := model.Columns["status"]
+				column.Meta.Looker.Dimension.Extra = map[string]interface{}{"view_label": "\"x\"\n  }\n  dimension: y {"}
+				model.Columns["status"] = column
+			},
+			errorMsg: "invalid parameters in dimension status: extra parameter view_label: value cannot contain line breaks or braces",
+		},
+		{
+			name: "measure extra replaces a generated parameter",
+			modify: func(model *models.DbtModel) {
+				model.Meta.Looker.Measures[0].Extra = map[string]interface{}{"filters": "[]"}
+			},
+			errorMsg: "invalid parameters in measure total_shipping: extra parameter filters is generated",
+		},
+		{
+			name: "view extra replaces a generated parameter",
+			modify: func(model *models.DbtModel) {
+				model.Meta.Looker.View.Extra = map[string]interface{}{"sql_table_name": "other"}
+			},
+			errorMsg: "invalid parameters in view orders: extra parameter sql_table_name is generated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := createParametersModel()
+			tt.modify(model)
+
+			_, err := NewViewGenerator(&config.Config{}).GenerateView(model)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
+func TestFieldParameters_Rendered(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createParametersModel()}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "sales/orders.view.lkml")
+	assert.Contains(t, content, "  suggestions: no\n")
+	assert.Contains(t, content, "  dimension: status {\n"+
+		"    type: string\n"+
+		"    sql: ${TABLE}.status ;;\n"+
+		"    label: \"Status\"\n"+
+		"    link: {\n"+
+		"      label: \"Search \\\"status\\\"\"\n"+
+		"      url: \"https://example.com/search?q={{ value }}\"\n"+
+		"    }\n"+
+		"    html: <a href=\"/dashboards/7?status={{ value }}\">{{ value }}</a> ;;\n"+
+		"    tags: [\"pii\", \"sales\", \"status\"]\n"+
+		"    alias: [order_status]\n"+
+		"    order_by_field: status_rank\n"+
+		"    case_sensitive: no\n"+
+		"    action: {\n"+
+		"      label: \"Escalate\"\n"+
+		"      url: \"https://example.com/escalate\"\n"+
+		"    }\n"+
+		"    view_label: \"Order Status\"\n"+
+		"  }\n")
+	assert.Contains(t, content, "    link: {\n"+
+		"      label: \"Audit log\"\n"+
+		"      url: \"https://example.com/audit?at={{ value }}\"\n"+
+		"    }\n"+
+		"    html: <i>{{ rendered_value }}</i> ;;\n"+
+		"    tags: [\"audit\"]\n"+
+		"    required_fields: [order_id]\n"+
+		"    alias: [created]\n")
+	assert.Contains(t, content, "  measure: total_shipping {\n"+
+		"    type: sum_distinct\n"+
+		"    sql: ${TABLE}.shipping_cost ;;\n"+
+		"    label: \"Total Shipping\"\n"+
+		"    drill_fields: [detail*]\n"+
+		"    sql_distinct_key: ${order_id} ;;\n"+
+		"    tags: [\"finance\"]\n"+
+		"    required_fields: [order_id]\n"+
+		"    value_format: \"$#,##0.00\"\n"+
+		"  }\n")
+}
+
+func TestFieldParameters_QuotedStrings(t *testing.T) {
+	outputDir := t.TempDir()
+	status := testColumn("status", "STRING")
+	status.Description = utils.StringPtr(`Status "as shipped", see C:\orders`)
+	status.Meta = &models.DbtModelColumnMeta{Looker: &models.DbtMetaLooker{Dimension: &models.DbtMetaLookerDimension{
+		DbtMetaLookerBase: models.DbtMetaLookerBase{Label: utils.StringPtr(`Order "Status"`)},
+		GroupLabel:        utils.StringPtr(`Order "State"`),
+	}}}
+	model := createTestModel("orders", "sales/orders.sql", status)
+	model.Description = `Orders "as placed"`
+
+	_, err := NewLookMLGenerator(&config.Config{OutputDir: outputDir}).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "sales/orders.view.lkml")
+	assert.Contains(t, content, "  description: \"Orders \\\"as placed\\\"\"\n")
+	assert.Contains(t, content, "    group_label: \"Order \\\"State\\\"\"\n")
+	assert.Contains(t, content, "    label: \"Order \\\"Status\\\"\"\n")
+	assert.Contains(t, content, "    description: \"Status \\\"as shipped\\\", see C:\\\\orders\"\n")
+}
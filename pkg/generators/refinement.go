@@ -0,0 +1,248 @@
+package generators
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/parsers"
+)
+
+const (
+	// generatedDirName holds the base layer that is overwritten on every run
+	generatedDirName = "generated"
+
+	// refinementsDirName holds the refinement files that are scaffolded once and then owned by users
+	refinementsDirName = "refinements"
+)
+
+// fieldReferencePattern matches LookML field references like ${field} and ${view.field}
+var fieldReferencePattern = regexp.MustCompile(`\$\{([A-Za-z0-9_]+)(?:\.([A-Za-z0-9_]+))?\}`)
+
+// refinableFieldKinds lists the block kinds that define or refine fields inside a view
+var refinableFieldKinds = map[string]bool{
+	"dimension":       true,
+	"dimension_group": true,
+	"measure":         true,
+	"filter":          true,
+	"parameter":       true,
+}
+
+// planRefinementLayer moves the generated files into the generated/ base layer and
+// adds a refinement file that includes the base, so refinements always apply after it.
+func (g *LookMLGenerator) planRefinementLayer(model *models.DbtModel, files []outputFile) ([]outputFile, error) {
+	viewPath, err := g.getViewFilename(model)
+	if err != nil {
+		return nil, err
+	}
+
+	// Includes between generated files must follow them into generated/
+	movedIncludes := make(map[string]string, len(files))
+	for _, file := range files {
+		movedIncludes[g.config.GetIncludePath(file.Path)] = g.config.GetIncludePath(path.Join(generatedDirName, file.Path))
+	}
+
+	var views []*models.LookMLView
+	var explores []*models.LookMLExplore
+	var includes []string
+	for i := range files {
+		files[i].Path = path.Join(generatedDirName, files[i].Path)
+		for j, include := range files[i].Includes {
+			if moved, ok := movedIncludes[include]; ok {
+				files[i].Includes[j] = moved
+			}
+		}
+
+		views = append(views, files[i].Views...)
+		explores = append(explores, files[i].Explores...)
+		includes = append(includes, g.config.GetIncludePath(files[i].Path))
+	}
+
+	refinementPath := path.Join(refinementsDirName, viewPath)
+	g.checkRefinementReferences(model, refinementPath, views)
+
+	refinement := outputFile{
+		Model:    model.Name,
+		Path:     refinementPath,
+		Content:  refinementScaffold(includes, views, explores),
+		Scaffold: true,
+	}
+
+	return append(files, refinement), nil
+}
+
+// refinementScaffold renders the initial content of a refinement file for the generated base files
+func refinementScaffold(includes []string, views []*models.LookMLView, explores []*models.LookMLExplore) string {
+	var builder strings.Builder
+
+	viewName := ""
+	if len(views) > 0 {
+		viewName = views[0].Name
+	}
+
+	builder.WriteString(fmt.Sprintf("# Refinements for the generated view %s.\n", viewName))
+	builder.WriteString("# dbt2lookml creates this file once and never overwrites it: add labels, links and\n")
+	builder.WriteString("# custom fields here. The included base definitions are regenerated on every run.\n")
+	for _, include := range includes {
+		builder.WriteString(fmt.Sprintf("include: \"%s\"\n", include))
+	}
+
+	if viewName != "" {
+		builder.WriteString(fmt.Sprintf("\nview: +%s {\n}\n", viewName))
+	}
+
+	for _, explore := range explores {
+		builder.WriteString(fmt.Sprintf("\nexplore: +%s {\n}\n", explore.Name))
+	}
+
+	return builder.String()
+}
+
+// checkRefinementReferences reports fields used by an existing refinement file
+// that the regenerated base views no longer provide
+func (g *LookMLGenerator) checkRefinementReferences(model *models.DbtModel, refinementPath string, views []*models.LookMLView) {
+	data, err := os.ReadFile(g.config.GetOutputPath(refinementPath))
+	if err != nil {
+		// No refinement file yet - it will be scaffolded
+		return
+	}
+	content := string(data)
+
+	generatedFields := make(map[string]map[string]bool, len(views))
+	for _, view := range views {
+		generatedFields[view.Name] = view.FieldNames()
+	}
+
+	var issues []string
+	for _, block := range parsers.ParseLookMLBlocks(content) {
+		if !strings.HasPrefix(block.Name, "+") {
+			continue
+		}
+		target := strings.TrimPrefix(block.Name, "+")
+
+		switch block.Kind {
+		case "view":
+			fields, ok := generatedFields[target]
+			if !ok {
+				issues = append(issues, fmt.Sprintf("refines view %s which is no longer generated", target))
+				continue
+			}
+			issues = append(issues, g.staleViewRefinementReferences(content, &block, target, fields, generatedFields)...)
+		case "explore":
+			issues = append(issues, staleFieldReferences(block.Body(content), "", nil, generatedFields, fmt.Sprintf("explore %s", target))...)
+		}
+	}
+
+	for _, issue := range uniqueSorted(issues) {
+		g.diagnostics.Warn(model.Name, WarningStaleRefinement, fmt.Sprintf("%s: %s", refinementPath, issue))
+	}
+}
+
+// staleViewRefinementReferences checks a `view: +name` block against the generated fields of that view
+func (g *LookMLGenerator) staleViewRefinementReferences(content string, block *parsers.LookMLBlock, viewName string, fields map[string]bool, generatedFields map[string]map[string]bool) []string {
+	var issues []string
+
+	// Fields added by the refinement itself are valid reference targets
+	available := make(map[string]bool, len(fields))
+	for name := range fields {
+		available[name] = true
+	}
+	for _, child := range block.Children {
+		if !refinableFieldKinds[child.Kind] || !definesNewField(&child) {
+			continue
+		}
+		if child.Kind == "dimension_group" {
+			// Timeframe names are not known without evaluating the group; accept any suffix
+			available[child.Name+"_*"] = true
+		}
+		available[child.Name] = true
+	}
+
+	for _, child := range block.Children {
+		if !refinableFieldKinds[child.Kind] || definesNewField(&child) {
+			continue
+		}
+		if !fieldAvailable(available, child.Name) {
+			issues = append(issues, fmt.Sprintf("refines %s %s.%s which is no longer generated", child.Kind, viewName, child.Name))
+		}
+	}
+
+	issues = append(issues, staleFieldReferences(block.Body(content), viewName, available, generatedFields, fmt.Sprintf("view %s", viewName))...)
+	return issues
+}
+
+// staleFieldReferences finds ${field} and ${view.field} references to generated views that do not resolve.
+// Unqualified references are resolved against the refined view (ownView); references to views that
+// are not generated in this file are ignored.
+func staleFieldReferences(body, ownView string, ownFields map[string]bool, generatedFields map[string]map[string]bool, context string) []string {
+	var issues []string
+
+	for _, match := range fieldReferencePattern.FindAllStringSubmatch(body, -1) {
+		viewName, fieldName := match[1], match[2]
+		if fieldName == "" {
+			// ${field} refers to the view being refined
+			if ownView == "" || viewName == "TABLE" {
+				continue
+			}
+			viewName, fieldName = ownView, match[1]
+		}
+		if fieldName == "SQL_TABLE_NAME" {
+			continue
+		}
+
+		fields := generatedFields[viewName]
+		if viewName == ownView && ownFields != nil {
+			fields = ownFields
+		}
+		if fields == nil {
+			continue
+		}
+
+		if !fieldAvailable(fields, fieldName) {
+			issues = append(issues, fmt.Sprintf("%s references ${%s.%s} which is no longer generated", context, viewName, fieldName))
+		}
+	}
+
+	return issues
+}
+
+// definesNewField reports whether a refinement block declares a field rather than adjusting an existing one
+func definesNewField(block *parsers.LookMLBlock) bool {
+	for _, param := range []string{"sql", "sql_start", "type"} {
+		if _, ok := block.Params[param]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldAvailable checks a field name against a set that may contain "group_*" wildcards
+func fieldAvailable(fields map[string]bool, name string) bool {
+	if fields[name] {
+		return true
+	}
+	for field := range fields {
+		if strings.HasSuffix(field, "_*") && strings.HasPrefix(name, strings.TrimSuffix(field, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueSorted returns the distinct values of a slice in sorted order
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var result []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
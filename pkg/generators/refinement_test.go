@@ -0,0 +1,174 @@
+package generators
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createRefinementModel returns an orders model with a number, a string and a timestamp column
+func createRefinementModel() *models.DbtModel {
+	return createTestModel("orders", "",
+		testColumn("id", "INT64"),
+		testColumn("status", "STRING"),
+		testColumn("created_at", "TIMESTAMP"),
+	)
+}
+
+// findOutputFile returns the path of the first generated file with the given suffix below dir
+func findOutputFile(t *testing.T, dir, suffix string) string {
+	t.Helper()
+
+	var found string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if found == "" && !info.IsDir() && strings.HasSuffix(filepath.ToSlash(path), suffix) {
+			found = path
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, found, "no file ending in %s", suffix)
+	return found
+}
+
+func TestRefinementLayer_ScaffoldsOnce(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:   outputDir,
+		Refinements: true,
+	}
+
+	gen := NewLookMLGenerator(cfg)
+	result, err := gen.GenerateAllWithOptions(context.Background(), []*models.DbtModel{createRefinementModel()}, GenerationOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, result.Warnings)
+
+	basePath := findOutputFile(t, filepath.Join(outputDir, generatedDirName), "orders.view.lkml")
+	refinementPath := findOutputFile(t, filepath.Join(outputDir, refinementsDirName), "orders.view.lkml")
+
+	base, err := os.ReadFile(basePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(base), "view: orders {")
+
+	refinement, err := os.ReadFile(refinementPath)
+	require.NoError(t, err)
+	relativeBase, err := filepath.Rel(outputDir, basePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(refinement), `include: "/`+filepath.ToSlash(relativeBase)+`"`)
+	assert.Contains(t, string(refinement), "view: +orders {")
+	assert.Contains(t, string(refinement), "explore: +orders {")
+
+	// Hand edits to the refinement survive regeneration
+	edited := string(refinement) + "\n# hand-written\n"
+	require.NoError(t, os.WriteFile(refinementPath, []byte(edited), 0644))
+
+	_, err = NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createRefinementModel()}, GenerationOptions{})
+	require.NoError(t, err)
+
+	after, err := os.ReadFile(refinementPath)
+	require.NoError(t, err)
+	assert.Equal(t, edited, string(after))
+}
+
+func TestRefinementLayer_IncludeRoot(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:   outputDir,
+		Refinements: true,
+		IncludeRoot: "lookml",
+	}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createRefinementModel()}, GenerationOptions{})
+	require.NoError(t, err)
+
+	refinement, err := os.ReadFile(findOutputFile(t, filepath.Join(outputDir, refinementsDirName), "orders.view.lkml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(refinement), `include: "/lookml/generated/`)
+}
+
+func TestRefinementLayer_ReportsStaleReferences(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:   outputDir,
+		Refinements: true,
+	}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createRefinementModel()}, GenerationOptions{})
+	require.NoError(t, err)
+
+	refinementPath := findOutputFile(t, filepath.Join(outputDir, refinementsDirName), "orders.view.lkml")
+	content := `view: +orders {
+  dimension: status {
+    label: "Order Status"
+  }
+
+  dimension: legacy_code {
+    label: "Legacy"
+  }
+
+  dimension: status_label {
+    type: string
+    sql: CONCAT(${status}, ${removed_column}, ${TABLE}.raw) ;;
+  }
+
+  dimension_group: shipped {
+    type: time
+    sql: ${TABLE}.shipped_at ;;
+  }
+
+  measure: shipped_count {
+    type: count
+    filters: [shipped_date: "-NULL", created_at_date: "-NULL"]
+    sql: ${shipped_date} ;;
+  }
+}
+
+explore: +orders {
+  sql_always_where: ${orders.created_at_date} IS NOT NULL AND ${orders.old_flag} ;;
+}
+`
+	require.NoError(t, os.WriteFile(refinementPath, []byte(content), 0644))
+
+	result, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createRefinementModel()}, GenerationOptions{})
+	require.NoError(t, err)
+
+	var messages []string
+	for _, warning := range result.Warnings {
+		assert.Equal(t, "orders", warning.ModelName)
+		assert.Equal(t, WarningStaleRefinement, warning.Category)
+		messages = append(messages, warning.Message)
+	}
+	joined := strings.Join(messages, "\n")
+
+	require.Len(t, messages, 3, joined)
+	assert.Contains(t, joined, "dimension orders.legacy_code")
+	assert.Contains(t, joined, "${orders.removed_column}")
+	assert.Contains(t, joined, "explore orders references ${orders.old_flag}")
+	assert.NotContains(t, joined, "shipped_date")
+	assert.NotContains(t, joined, "created_at_date")
+}
+
+func TestRefinementLayer_Disabled(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir: outputDir,
+	}
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{createRefinementModel()}, GenerationOptions{})
+	require.NoError(t, err)
+
+	_, err = os.Stat(filepath.Join(outputDir, generatedDirName))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(outputDir, refinementsDirName))
+	assert.True(t, os.IsNotExist(err))
+}
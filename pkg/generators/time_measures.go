@@ -0,0 +1,122 @@
+package generators
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+)
+
+// periodGranularity orders the timeframes a period_over_period measure can compare, from fine to coarse
+var periodGranularity = map[string]int{
+	string(enums.PeriodDate):          1,
+	string(enums.PeriodWeek):          2,
+	string(enums.PeriodMonth):         3,
+	string(enums.PeriodQuarter):       4,
+	string(enums.PeriodFiscalQuarter): 4,
+	string(enums.PeriodYear):          5,
+	string(enums.PeriodFiscalYear):    5,
+}
+
+// validateTimeMeasures checks the measures of a view that depend on its dimension groups:
+// period_over_period measures and measure filters on dimension group timeframes. Both
+// must reference timeframes the view generates.
+func validateTimeMeasures(view *models.LookMLView) error {
+	measures := make(map[string]*models.LookMLMeasure, len(view.Measures))
+	for i := range view.Measures {
+		measures[view.Measures[i].Name] = &view.Measures[i]
+	}
+
+	dimensions := make(map[string]bool, len(view.Dimensions))
+	for _, dimension := range view.Dimensions {
+		dimensions[dimension.Name] = true
+	}
+
+	for _, measure := range view.Measures {
+		if measure.Type == enums.MeasurePeriodOverPeriod {
+			if err := validatePeriodOverPeriod(view, &measure, measures); err != nil {
+				return fmt.Errorf("measure %s: %w", measure.Name, err)
+			}
+		}
+
+		for _, filter := range measure.Filters {
+			field := strings.TrimPrefix(filter.FilterDimension, view.Name+".")
+			if dimensions[field] || strings.Contains(field, ".") {
+				continue
+			}
+			group, timeframe := splitTimeframeField(view, field)
+			if group != nil && !hasTimeframe(group, timeframe) {
+				return fmt.Errorf("measure %s: filter on %s uses timeframe %s, which is not generated for dimension group %s",
+					measure.Name, field, timeframe, group.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validatePeriodOverPeriod checks that a period_over_period measure compares a measure of
+// the view over a generated dimension group timeframe no coarser than its period
+func validatePeriodOverPeriod(view *models.LookMLView, measure *models.LookMLMeasure, measures map[string]*models.LookMLMeasure) error {
+	basedOn, ok := measures[*measure.BasedOn]
+	if !ok {
+		return fmt.Errorf("based_on references unknown measure %s", *measure.BasedOn)
+	}
+	if basedOn.Type == enums.MeasurePeriodOverPeriod {
+		return fmt.Errorf("based_on cannot reference period_over_period measure %s", basedOn.Name)
+	}
+
+	group, timeframe := splitTimeframeField(view, *measure.BasedOnTime)
+	if group == nil {
+		return fmt.Errorf("based_on_time %s does not reference a dimension group of view %s", *measure.BasedOnTime, view.Name)
+	}
+	if !hasTimeframe(group, timeframe) {
+		return fmt.Errorf("based_on_time uses timeframe %s, which is not generated for dimension group %s", timeframe, group.Name)
+	}
+
+	granularity, ok := periodGranularity[timeframe]
+	if !ok {
+		return fmt.Errorf("based_on_time timeframe %s cannot be compared over periods", timeframe)
+	}
+	if periodGranularity[string(*measure.Period)] < granularity {
+		return fmt.Errorf("period %s is finer than based_on_time timeframe %s", *measure.Period, timeframe)
+	}
+
+	return nil
+}
+
+// splitTimeframeField splits a field such as created_month into the dimension group it
+// belongs to and its timeframe. The longest matching group name wins. Duration dimension
+// groups have no timeframes and are never matched.
+func splitTimeframeField(view *models.LookMLView, field string) (*models.LookMLDimensionGroup, string) {
+	var match *models.LookMLDimensionGroup
+	for i := range view.DimensionGroups {
+		group := &view.DimensionGroups[i]
+		if group.Type == dimGroupTypeDuration {
+			continue
+		}
+		if !strings.HasPrefix(field, group.Name+"_") || len(field) == len(group.Name)+1 {
+			continue
+		}
+		if match == nil || len(group.Name) > len(match.Name) {
+			match = group
+		}
+	}
+
+	if match == nil {
+		return nil, ""
+	}
+	return match, strings.TrimPrefix(field, match.Name+"_")
+}
+
+// hasTimeframe checks whether a dimension group generates a timeframe. Groups without an
+// explicit list get Looker's default timeframes.
+func hasTimeframe(group *models.LookMLDimensionGroup, timeframe string) bool {
+	for _, generated := range group.GeneratedTimeframes() {
+		if string(generated) == timeframe {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,201 @@
+package generators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/models"
+	"github.com/magnus-ffcg/go-dbt2lookml/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// periodPtr returns a pointer to a period over period period
+func periodPtr(period enums.LookerPeriod) *enums.LookerPeriod {
+	return &period
+}
+
+// periodKindPtr returns a pointer to a period over period kind
+func periodKindPtr(kind enums.LookerPeriodOverPeriodKind) *enums.LookerPeriodOverPeriodKind {
+	return &kind
+}
+
+// createTimeMeasureModel creates a model whose created_date column becomes the "created"
+// dimension group with the default DATE timeframes (raw, date, week, month, quarter, year)
+func createTimeMeasureModel(measures ...models.DbtMetaLookerMeasure) *models.DbtModel {
+	model := createMeasureSQLModel()
+	model.Path = "marts/orders.sql"
+	model.Meta = &models.DbtModelMeta{Looker: &models.DbtMetaLooker{Measures: append([]models.DbtMetaLookerMeasure{
+		{Name: utils.StringPtr("revenue"), Type: enums.MeasureSum, Column: utils.StringPtr("amount")},
+	}, measures...)}}
+	return model
+}
+
+func TestPeriodOverPeriodMeasures(t *testing.T) {
+	tests := []struct {
+		name        string
+		measure     models.DbtMetaLookerMeasure
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name: "valid year over year",
+			measure: models.DbtMetaLookerMeasure{
+				Name: utils.StringPtr("revenue_last_year"), Type: enums.MeasurePeriodOverPeriod,
+				BasedOn: utils.StringPtr("revenue"), BasedOnTime: utils.StringPtr("created_year"),
+				Period: periodPtr(enums.PeriodYear), Kind: periodKindPtr(enums.PeriodKindPrevious),
+			},
+		},
+		{
+			name: "valid month over month on a finer timeframe",
+			measure: models.DbtMetaLookerMeasure{
+				Name: utils.StringPtr("revenue_mom"), Type: enums.MeasurePeriodOverPeriod,
+				BasedOn: utils.StringPtr("revenue"), BasedOnTime: utils.StringPtr("created_date"),
+				Period: periodPtr(enums.PeriodMonth), Kind: periodKindPtr(enums.PeriodKindRelativeChange),
+			},
+		},
+		{
+			name: "unknown based_on measure",
+			measure: models.DbtMetaLookerMeasure{
+				Name: utils.StringPtr("revenue_last_year"), Type: enums.MeasurePeriodOverPeriod,
+				BasedOn: utils.StringPtr("sales"), BasedOnTime: utils.StringPtr("created_year"), Period: periodPtr(enums.PeriodYear),
+			},
+			expectError: true,
+			errorMsg:    "measure revenue_last_year: based_on references unknown measure sales",
+		},
+		{
+			name: "based_on_time is not a dimension group",
+			measure: models.DbtMetaLookerMeasure{
+				Name: utils.StringPtr("revenue_last_year"), Type: enums.MeasurePeriodOverPeriod,
+				BasedOn: utils.StringPtr("revenue"), BasedOnTime: utils.StringPtr("shipped_year"), Period: periodPtr(enums.PeriodYear),
+			},
+			expectError: true,
+			errorMsg:    "based_on_time shipped_year does not reference a dimension group of view orders",
+		},
+		{
+			name: "timeframe not generated",
+			measure: models.DbtMetaLookerMeasure{
+				Name: utils.StringPtr("revenue_last_year"), Type: enums.MeasurePeriodOverPeriod,
+				BasedOn: utils.StringPtr("revenue"), BasedOnTime: utils.StringPtr("created_fiscal_year"), Period: periodPtr(enums.PeriodYear),
+			},
+			expectError: true,
+			errorMsg:    "based_on_time uses timeframe fiscal_year, which is not generated for dimension group created",
+		},
+		{
+			name: "period finer than timeframe",
+			measure: models.DbtMetaLookerMeasure{
+				Name: utils.StringPtr("revenue_last_month"), Type: enums.MeasurePeriodOverPeriod,
+				BasedOn: utils.StringPtr("revenue"), BasedOnTime: utils.StringPtr("created_year"), Period: periodPtr(enums.PeriodMonth),
+			},
+			expectError: true,
+			errorMsg:    "period month is finer than based_on_time timeframe year",
+		},
+		{
+			name: "raw timeframe",
+			measure: models.DbtMetaLookerMeasure{
+				Name: utils.StringPtr("revenue_last_year"), Type: enums.MeasurePeriodOverPeriod,
+				BasedOn: utils.StringPtr("revenue"), BasedOnTime: utils.StringPtr("created_raw"), Period: periodPtr(enums.PeriodYear),
+			},
+			expectError: true,
+			errorMsg:    "based_on_time timeframe raw cannot be compared over periods",
+		},
+		{
+			name: "missing period",
+			measure: models.DbtMetaLookerMeasure{
+				Name: utils.StringPtr("revenue_last_year"), Type: enums.MeasurePeriodOverPeriod,
+				BasedOn: utils.StringPtr("revenue"), BasedOnTime: utils.StringPtr("created_year"),
+			},
+			expectError: true,
+			errorMsg:    "period is required for period_over_period measure: revenue_last_year",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view, err := NewViewGenerator(&config.Config{}).GenerateView(createTimeMeasureModel(tt.measure))
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, enums.MeasurePeriodOverPeriod, view.Measures[1].Type)
+			assert.Nil(t, view.Measures[1].SQL)
+		})
+	}
+}
+
+func TestFilteredTimeMeasures(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      string
+		expectError bool
+	}{
+		{name: "generated timeframe", filter: "created_date"},
+		{name: "qualified field", filter: "orders.created_month"},
+		{name: "regular dimension", filter: "amount"},
+		{name: "timeframe not generated", filter: "created_hour", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := createTimeMeasureModel(models.DbtMetaLookerMeasure{
+				Name:    utils.StringPtr("revenue_last_30_days"),
+				Type:    enums.MeasureSum,
+				Column:  utils.StringPtr("amount"),
+				Filters: []models.DbtMetaLookerMeasureFilter{{FilterDimension: tt.filter, FilterExpression: "last 30 days"}},
+			})
+
+			_, err := NewViewGenerator(&config.Config{}).GenerateView(model)
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "filter on created_hour uses timeframe hour, which is not generated for dimension group created")
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestHasTimeframe(t *testing.T) {
+	defaults := &models.LookMLDimensionGroup{Name: "created", Type: "time"}
+	assert.True(t, hasTimeframe(defaults, "date"))
+	assert.True(t, hasTimeframe(defaults, "year"))
+	assert.False(t, hasTimeframe(defaults, "fiscal_year"))
+	assert.False(t, hasTimeframe(defaults, "day_of_week"))
+
+	explicit := &models.LookMLDimensionGroup{Name: "created", Type: "time", Timeframes: []enums.LookerTimeFrame{enums.TimeFrameDate}}
+	assert.True(t, hasTimeframe(explicit, "date"))
+	assert.False(t, hasTimeframe(explicit, "year"))
+}
+
+func TestPeriodOverPeriodMeasures_Rendered(t *testing.T) {
+	outputDir := t.TempDir()
+	cfg := &config.Config{OutputDir: outputDir}
+
+	model := createTimeMeasureModel(models.DbtMetaLookerMeasure{
+		Name:              utils.StringPtr("revenue_yoy"),
+		Type:              enums.MeasurePeriodOverPeriod,
+		DbtMetaLookerBase: models.DbtMetaLookerBase{Label: utils.StringPtr("Revenue YoY")},
+		BasedOn:           utils.StringPtr("revenue"),
+		BasedOnTime:       utils.StringPtr("created_year"),
+		Period:            periodPtr(enums.PeriodYear),
+		Kind:              periodKindPtr(enums.PeriodKindDifference),
+	})
+
+	_, err := NewLookMLGenerator(cfg).GenerateAllWithOptions(context.Background(), []*models.DbtModel{model}, GenerationOptions{})
+	require.NoError(t, err)
+
+	content := readOutput(t, outputDir, "marts/orders.view.lkml")
+	assert.Contains(t, content, "  measure: revenue_yoy {\n"+
+		"    type: period_over_period\n"+
+		"    based_on: revenue\n"+
+		"    based_on_time: created_year\n"+
+		"    period: year\n"+
+		"    kind: difference\n"+
+		"    label: \"Revenue YoY\"\n"+
+		"  }\n")
+}
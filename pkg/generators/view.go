@@ -2,6 +2,7 @@ package generators
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/magnus-ffcg/go-dbt2lookml/internal/config"
@@ -15,6 +16,7 @@ var _ ViewGeneratorInterface = (*ViewGenerator)(nil)
 // ViewGenerator handles generation of LookML views
 type ViewGenerator struct {
 	config             *config.Config
+	naming             NamingStrategy
 	dimensionGenerator *DimensionGenerator
 	measureGenerator   *MeasureGenerator
 }
@@ -23,15 +25,26 @@ type ViewGenerator struct {
 func NewViewGenerator(cfg *config.Config) *ViewGenerator {
 	return &ViewGenerator{
 		config:             cfg,
+		naming:             NewNamingStrategy(cfg),
 		dimensionGenerator: NewDimensionGenerator(cfg),
 		measureGenerator:   NewMeasureGenerator(cfg),
 	}
 }
 
+// SetNamingStrategy replaces the naming strategy of the generator
+func (g *ViewGenerator) SetNamingStrategy(naming NamingStrategy) {
+	g.naming = naming
+	g.dimensionGenerator.SetNamingStrategy(naming)
+	g.measureGenerator.SetNamingStrategy(naming)
+}
+
 // GenerateView generates a LookML view from a dbt model
 func (g *ViewGenerator) GenerateView(model *models.DbtModel) (*models.LookMLView, error) {
 	// Create column collections once and reuse them
-	columnCollections := models.NewColumnCollections(model, nil)
+	columnCollections, err := newColumnCollections(g.config, model)
+	if err != nil {
+		return nil, err
+	}
 
 	view := &models.LookMLView{
 		Name:         g.getViewName(model),
@@ -40,6 +53,12 @@ func (g *ViewGenerator) GenerateView(model *models.DbtModel) (*models.LookMLView
 		Description:  g.getViewDescription(model),
 		Hidden:       g.getViewHidden(model),
 	}
+	if model.Meta != nil && model.Meta.Looker != nil {
+		view.Translations = model.Meta.Looker.Translations
+		if model.Meta.Looker.View != nil {
+			view.Extra = model.Meta.Looker.View.Extra
+		}
+	}
 
 	// Generate dimensions using the shared column collections
 	dimensions, err := g.generateDimensionsWithCollections(model, columnCollections)
@@ -50,6 +69,11 @@ func (g *ViewGenerator) GenerateView(model *models.DbtModel) (*models.LookMLView
 	// Add nested view reference dimensions to main view
 	nestedViewRefDimensions := g.generateNestedViewReferenceDimensions(model, columnCollections)
 	dimensions = append(dimensions, nestedViewRefDimensions...)
+	overflowDimensions, err := g.generateOverflowDimensions(model, columnCollections)
+	if err != nil {
+		return nil, err
+	}
+	dimensions = append(dimensions, overflowDimensions...)
 
 	view.Dimensions = dimensions
 
@@ -66,6 +90,13 @@ func (g *ViewGenerator) GenerateView(model *models.DbtModel) (*models.LookMLView
 		view.Dimensions = dimensions
 	}
 
+	// Pair latitude and longitude dimensions into location dimensions
+	locations, err := generateLocationDimensions(model, view.Dimensions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid locations: %w", err)
+	}
+	view.Dimensions = append(view.Dimensions, locations...)
+
 	// Generate measures
 	measures, err := g.generateMeasures(model)
 	if err != nil {
@@ -73,25 +104,38 @@ func (g *ViewGenerator) GenerateView(model *models.DbtModel) (*models.LookMLView
 	}
 	view.Measures = measures
 
+	if err := validateTimeMeasures(view); err != nil {
+		return nil, fmt.Errorf("invalid measures: %w", err)
+	}
+
+	// Govern fields holding personal data before the detail set leaves out hidden fields
+	if err := applyPIIMasking(g.config, view); err != nil {
+		return nil, err
+	}
+
+	// Add the detail set measures drill into
+	detailSet, err := g.generateDetailSet(model, view, columnCollections)
+	if err != nil {
+		return nil, fmt.Errorf("invalid detail set: %w", err)
+	}
+	applyDetailSet(view, detailSet)
+
+	if err := view.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid view: %w", err)
+	}
+
 	return view, nil
 }
 
 // getViewName gets the view name from the model
 func (g *ViewGenerator) getViewName(model *models.DbtModel) string {
-	if g.config.UseTableName {
-		// Extract just the table name from relation_name (remove project.dataset prefix and backticks)
-		parts := strings.Split(model.RelationName, ".")
-		tableName := parts[len(parts)-1]
-		tableName = strings.Trim(tableName, "`")
-		return strings.ToLower(tableName)
-	}
-	return model.Name
+	return g.naming.ViewName(model)
 }
 
 // getSQLTableName gets the SQL table name for the view
 func (g *ViewGenerator) getSQLTableName(model *models.DbtModel) string {
-	if g.config.UseTableName {
-		// When using table names, use the RelationName but remove individual backticks and add single backticks around the whole name
+	if g.config.GetNamingStrategy() == config.NamingStrategyTableName {
+		// When naming views by table name, use the RelationName but remove individual backticks and add single backticks around the whole name
 		relationName := model.RelationName
 		// Remove individual backticks: `project`.`dataset`.`table` -> project.dataset.table
 		relationName = strings.ReplaceAll(relationName, "`", "")
@@ -118,8 +162,8 @@ func (g *ViewGenerator) getViewLabel(model *models.DbtModel) *string {
 		return model.Meta.Looker.View.Label
 	}
 
-	// Return nil to omit label when not explicitly defined
-	return nil
+	label := g.dimensionGenerator.labels.Label(g.getViewName(model))
+	return &label
 }
 
 // getViewDescription gets the view description from model
@@ -144,7 +188,10 @@ func (g *ViewGenerator) getViewHidden(model *models.DbtModel) *bool {
 // generateDimensions generates dimensions for the view (legacy method)
 func (g *ViewGenerator) generateDimensions(model *models.DbtModel) ([]models.LookMLDimension, error) {
 	// Create column collections and delegate to the new method
-	columnCollections := models.NewColumnCollections(model, nil)
+	columnCollections, err := newColumnCollections(g.config, model)
+	if err != nil {
+		return nil, err
+	}
 	return g.generateDimensionsWithCollections(model, columnCollections)
 }
 
@@ -155,7 +202,8 @@ func (g *ViewGenerator) generateDimensionsWithCollections(model *models.DbtModel
 	// Generate dimensions for ALL main view columns (including those that will become dimension groups)
 	// This is needed to generate conflict dimensions for date/time fields before classification
 
-	for colName, column := range columnCollections.MainViewColumns {
+	for _, colName := range sortedColumnNames(columnCollections.MainViewColumns) {
+		column := columnCollections.MainViewColumns[colName]
 		// Create a proper deep copy of the column to avoid shared pointer issues
 		columnCopy := models.DbtModelColumn{
 			Name:         colName, // Use the full path from the map key
@@ -163,6 +211,7 @@ func (g *ViewGenerator) generateDimensionsWithCollections(model *models.DbtModel
 			IsPrimaryKey: column.IsPrimaryKey,
 			InnerTypes:   column.InnerTypes, // Slice is copied by value
 			Meta:         column.Meta,       // Pointer to metadata (shared is OK)
+			Tags:         column.Tags,
 		}
 
 		// Deep copy all pointer fields to avoid shared references
@@ -195,6 +244,13 @@ func (g *ViewGenerator) generateDimensionsWithCollections(model *models.DbtModel
 		if dimension != nil {
 			dimensions = append(dimensions, *dimension)
 		}
+
+		// JSON columns can declare paths to extract as dimensions of their own
+		jsonDimensions, err := g.dimensionGenerator.GenerateJSONPathDimensions(model, &columnCopy)
+		if err != nil {
+			return nil, err
+		}
+		dimensions = append(dimensions, jsonDimensions...)
 	}
 
 	return dimensions, nil
@@ -210,16 +266,16 @@ func (g *ViewGenerator) resolveConflicts(dimensions []models.LookMLDimension, di
 func (g *ViewGenerator) generateNestedViewReferenceDimensions(model *models.DbtModel, columnCollections *models.ColumnCollections) []models.LookMLDimension {
 	var dimensions []models.LookMLDimension
 
-	// Apply business rules for nested array processing
-	arrayRules := models.NewNestedArrayRules()
-
-	// For each nested view, create a corresponding reference dimension in main view
-	for arrayName, nestedCols := range columnCollections.NestedViewColumns {
-		// Skip arrays that exceed the maximum nesting depth
-		if !arrayRules.ShouldProcessArray(arrayName) {
-			continue
-		}
+	// For each nested view, create a corresponding reference dimension in main view. Arrays
+	// beyond the depth limit have no nested view in the column collections.
+	arrayNames := make([]string, 0, len(columnCollections.NestedViewColumns))
+	for arrayName := range columnCollections.NestedViewColumns {
+		arrayNames = append(arrayNames, arrayName)
+	}
+	sort.Strings(arrayNames)
 
+	for _, arrayName := range arrayNames {
+		nestedCols := columnCollections.NestedViewColumns[arrayName]
 		// Skip arrays that are nested inside other arrays
 		// e.g., "sales.f_sale_receipt_pseudo_keys" where "sales" is also an array
 		// Only include arrays that are direct children of the table or children of STRUCTs
@@ -243,32 +299,18 @@ func (g *ViewGenerator) generateNestedViewReferenceDimensions(model *models.DbtM
 			}
 		}
 
-		// Generate the nested view name (same logic as nested view generation)
-		var baseName string
-		if g.config.UseTableName {
-			// Extract table name from RelationName
-			parts := strings.Split(model.RelationName, ".")
-			tableName := parts[len(parts)-1]
-			tableName = strings.Trim(tableName, "`")
-			baseName = strings.ToLower(tableName)
-		} else {
-			baseName = model.Name
-		}
-
 		// Use OriginalName if available for proper PascalCase conversion
-		var nestedSuffix string
+		arrayPath := arrayName
 		if arrayOriginalName != "" {
-			nestedSuffix = utils.ToLookMLName(arrayOriginalName)
-		} else {
-			nestedSuffix = utils.ToLookMLName(arrayName)
+			arrayPath = arrayOriginalName
 		}
 
 		// The dimension name in the main view should be just the array name (short form)
 		// e.g., "sales", "supplier_information"
-		dimensionName := nestedSuffix
+		dimensionName := g.naming.FieldName(arrayPath)
 
 		// SQL reference should be the full nested view name
-		sqlRef := fmt.Sprintf("%s__%s", baseName, nestedSuffix)
+		sqlRef := g.naming.NestedViewName(model, arrayPath)
 
 		// Create hidden dimension that references the nested view
 		hidden := true
@@ -285,12 +327,38 @@ func (g *ViewGenerator) generateNestedViewReferenceDimensions(model *models.DbtM
 	return dimensions
 }
 
+// generateOverflowDimensions generates JSON string dimensions for the arrays of the main view
+// nested beyond the depth limit, when they are not dropped
+func (g *ViewGenerator) generateOverflowDimensions(model *models.DbtModel, columnCollections *models.ColumnCollections) ([]models.LookMLDimension, error) {
+	flatten, err := flattensOverflowArrays(g.config, model)
+	if err != nil || !flatten {
+		return nil, err
+	}
+
+	var dimensions []models.LookMLDimension
+	for _, arrayName := range overflowArrayNames(columnCollections, "") {
+		column := columnCollections.OverflowArrays[""][arrayName]
+		path := column.Name
+		if column.OriginalName != nil && *column.OriginalName != "" {
+			path = *column.OriginalName
+		}
+
+		dimension := overflowDimension(g.naming.FieldName(path), fmt.Sprintf("${TABLE}.%s", path), &column)
+		dimension.GroupLabel = g.dimensionGenerator.GetDimensionGroupLabel(&column)
+		dimension.GroupItemLabel = g.dimensionGenerator.getDimensionGroupItemLabel(&column)
+		dimensions = append(dimensions, dimension)
+	}
+	return dimensions, nil
+}
+
 // generateDimensionGroups generates dimension groups for the view
 func (g *ViewGenerator) generateDimensionGroups(model *models.DbtModel, columnCollections *models.ColumnCollections) ([]models.LookMLDimensionGroup, error) {
 	var dimensionGroups []models.LookMLDimensionGroup
 
 	// Only process main view columns, not nested columns
-	for _, column := range columnCollections.MainViewColumns {
+	columnNames := sortedColumnNames(columnCollections.MainViewColumns)
+	for _, columnName := range columnNames {
+		column := columnCollections.MainViewColumns[columnName]
 		// Only process columns that should be dimension groups
 		if !g.shouldBeDimensionGroup(column) {
 			continue
@@ -306,12 +374,41 @@ func (g *ViewGenerator) generateDimensionGroups(model *models.DbtModel, columnCo
 		}
 	}
 
+	// RANGE columns get a dimension group for each bound
+	for _, columnName := range columnNames {
+		column := columnCollections.MainViewColumns[columnName]
+		rangeGroups, err := g.dimensionGenerator.GenerateRangeDimensionGroups(model, &column)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate dimension groups for column %s: %w", column.Name, err)
+		}
+		dimensionGroups = append(dimensionGroups, rangeGroups...)
+	}
+
+	// Duration dimension groups measure the time between pairs of columns
+	durationGroups, err := g.dimensionGenerator.GenerateDurationDimensionGroups(model, columnCollections.MainViewColumns)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool, len(dimensionGroups))
+	for _, dimensionGroup := range dimensionGroups {
+		existing[dimensionGroup.Name] = true
+	}
+	for _, dimensionGroup := range durationGroups {
+		if existing[dimensionGroup.Name] {
+			return nil, fmt.Errorf("duration %s conflicts with an existing dimension group", dimensionGroup.Name)
+		}
+		dimensionGroups = append(dimensionGroups, dimensionGroup)
+	}
+
 	return dimensionGroups, nil
 }
 
-// generateMeasures generates measures for the view
+// generateMeasures generates measures for the view: model measures, column measures
+// ordered by column, template measures, then the default count. Expressions of derived
+// measures are resolved against all of them, and every measure is validated.
 func (g *ViewGenerator) generateMeasures(model *models.DbtModel) ([]models.LookMLMeasure, error) {
 	var measures []models.LookMLMeasure
+	expressions := make(map[string]string)
 
 	// Generate measures from model meta
 	if model.Meta != nil && model.Meta.Looker != nil {
@@ -323,19 +420,83 @@ func (g *ViewGenerator) generateMeasures(model *models.DbtModel) ([]models.LookM
 
 			if measure != nil {
 				measures = append(measures, *measure)
+				if measureMeta.Expression != nil {
+					expressions[measure.Name] = *measureMeta.Expression
+				}
+			}
+		}
+	}
+
+	// Generate measures declared on columns
+	columnCollections, err := newColumnCollections(g.config, model)
+	if err != nil {
+		return nil, err
+	}
+	mainViewColumns := columnCollections.MainViewColumns
+	for _, columnName := range sortedColumnNames(mainViewColumns) {
+		column := mainViewColumns[columnName]
+		if column.Meta == nil || column.Meta.Looker == nil {
+			continue
+		}
+		for _, measureMeta := range column.Meta.Looker.Measures {
+			measure, err := g.measureGenerator.GenerateColumnMeasure(model, &column, &measureMeta)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate measure for column %s: %w", columnName, err)
 			}
+			measures = append(measures, *measure)
 		}
 	}
 
+	// Generate measures from configured templates; measures declared in meta take precedence
+	templateMeasures, err := g.measureGenerator.GenerateTemplateMeasures(model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate template measures: %w", err)
+	}
+	existing := make(map[string]bool, len(measures))
+	for _, measure := range measures {
+		existing[measure.Name] = true
+	}
+	for _, measure := range templateMeasures {
+		if existing[measure.Name] {
+			continue
+		}
+		existing[measure.Name] = true
+		measures = append(measures, *measure)
+	}
+
 	// Generate default count measure
 	countMeasure := g.measureGenerator.GenerateDefaultCountMeasure(model)
 	if countMeasure != nil {
 		measures = append(measures, *countMeasure)
 	}
 
+	// Derived measures can reference any of the measures above
+	if err := resolveDerivedMeasures(measures, expressions); err != nil {
+		return nil, err
+	}
+
+	if err := validateMeasures(measures); err != nil {
+		return nil, err
+	}
+
 	return measures, nil
 }
 
+// validateMeasures validates measures and checks that their names are unique
+func validateMeasures(measures []models.LookMLMeasure) error {
+	seen := make(map[string]bool, len(measures))
+	for _, measure := range measures {
+		if err := measure.Validate(); err != nil {
+			return err
+		}
+		if seen[measure.Name] {
+			return fmt.Errorf("duplicate measure name: %s", measure.Name)
+		}
+		seen[measure.Name] = true
+	}
+	return nil
+}
+
 // shouldBeDimensionGroup determines if a column should be a dimension group
 func (g *ViewGenerator) shouldBeDimensionGroup(column models.DbtModelColumn) bool {
 	if column.DataType == nil {
@@ -351,11 +512,11 @@ func (g *ViewGenerator) GenerateNestedView(model *models.DbtModel, arrayColumn *
 	// This would implement nested view generation for ARRAY<STRUCT> columns
 	// Simplified implementation for now
 
-	nestedViewName := fmt.Sprintf("%s__%s", model.Name, *arrayColumn.LookMLName)
+	nestedViewName := g.naming.NestedViewName(model, *arrayColumn.LookMLName)
 
 	view := &models.LookMLView{
 		Name:         nestedViewName,
-		SQLTableName: fmt.Sprintf("${%s.SQL_TABLE_NAME}", model.Name),
+		SQLTableName: fmt.Sprintf("${%s.SQL_TABLE_NAME}", g.getViewName(model)),
 	}
 
 	// Generate dimensions for nested fields
@@ -363,3 +524,14 @@ func (g *ViewGenerator) GenerateNestedView(model *models.DbtModel, arrayColumn *
 
 	return view, nil
 }
+
+// sortedColumnNames returns the names of the columns in order, so fields are generated in
+// the same order on every run
+func sortedColumnNames(columns map[string]models.DbtModelColumn) []string {
+	names := make([]string, 0, len(columns))
+	for name := range columns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -92,8 +92,9 @@ func TestViewGenerator_GenerateView(t *testing.T) {
 					Looker: &models.DbtMetaLooker{
 						Measures: []models.DbtMetaLookerMeasure{
 							{
-								Name: viewStringPtr("total_amount"),
-								Type: enums.MeasureSum,
+								Name:   viewStringPtr("total_amount"),
+								Type:   enums.MeasureSum,
+								Column: viewStringPtr("amount"),
 							},
 						},
 					},
@@ -165,6 +166,25 @@ func TestViewGenerator_UseTableName(t *testing.T) {
 	assert.Equal(t, "`project.dataset.actual_table_name`", view.SQLTableName)
 }
 
+func TestViewGenerator_TableNameStrategyMatchesUseTableName(t *testing.T) {
+	model := &models.DbtModel{
+		DbtNode:      models.DbtNode{Name: "model_name"},
+		RelationName: "`project`.`dataset`.`actual_table_name`",
+		Schema:       "test_schema",
+	}
+
+	useTableName, err := NewViewGenerator(&config.Config{UseTableName: true}).GenerateView(model)
+	require.NoError(t, err)
+	strategy, err := NewViewGenerator(&config.Config{
+		Naming: config.NamingConfig{Strategy: config.NamingStrategyTableName},
+	}).GenerateView(model)
+	require.NoError(t, err)
+
+	assert.Equal(t, "`project.dataset.actual_table_name`", strategy.SQLTableName)
+	assert.Equal(t, useTableName.Name, strategy.Name)
+	assert.Equal(t, useTableName.SQLTableName, strategy.SQLTableName)
+}
+
 func TestViewGenerator_ViewAttributes(t *testing.T) {
 	cfg := &config.Config{}
 	generator := NewViewGenerator(cfg)
@@ -341,8 +361,9 @@ func TestViewGenerator_MeasureGeneration(t *testing.T) {
 					Looker: &models.DbtMetaLooker{
 						Measures: []models.DbtMetaLookerMeasure{
 							{
-								Name: viewStringPtr("total_amount"),
-								Type: enums.MeasureSum,
+								Name:   viewStringPtr("total_amount"),
+								Type:   enums.MeasureSum,
+								Column: viewStringPtr("amount"),
 							},
 						},
 					},
@@ -815,3 +836,87 @@ func viewStringPtr(s string) *string {
 func viewBoolPtr(b bool) *bool {
 	return &b
 }
+
+func TestViewGenerator_ColumnMeasures(t *testing.T) {
+	generator := NewViewGenerator(&config.Config{})
+
+	columnMeasures := func(measures ...models.DbtMetaLookerMeasure) *models.DbtModelColumnMeta {
+		return &models.DbtModelColumnMeta{Looker: &models.DbtMetaLooker{Measures: measures}}
+	}
+
+	tests := []struct {
+		name          string
+		modelMeasures []models.DbtMetaLookerMeasure
+		columns       map[string]models.DbtModelColumn
+		expectedNames []string
+		errorMsg      string
+	}{
+		{
+			name: "shorthand measures on columns",
+			columns: map[string]models.DbtModelColumn{
+				"quantity": {Name: "quantity", DataType: viewStringPtr("INT64"), Meta: columnMeasures(
+					models.DbtMetaLookerMeasure{Type: enums.MeasureSum},
+				)},
+				"amount": {Name: "amount", DataType: viewStringPtr("NUMERIC"), Meta: columnMeasures(
+					models.DbtMetaLookerMeasure{Type: enums.MeasureSum},
+					models.DbtMetaLookerMeasure{Type: enums.MeasureAverage},
+					models.DbtMetaLookerMeasure{Type: enums.MeasureMax},
+				)},
+			},
+			expectedNames: []string{"sum_amount", "average_amount", "max_amount", "sum_quantity", "count"},
+		},
+		{
+			name:          "model measure with column",
+			modelMeasures: []models.DbtMetaLookerMeasure{{Type: enums.MeasureSum, Name: viewStringPtr("total"), Column: viewStringPtr("amount")}},
+			columns: map[string]models.DbtModelColumn{
+				"amount": {Name: "amount", DataType: viewStringPtr("NUMERIC")},
+			},
+			expectedNames: []string{"total", "count"},
+		},
+		{
+			name:          "model measure without sql is invalid",
+			modelMeasures: []models.DbtMetaLookerMeasure{{Type: enums.MeasureSum, Name: viewStringPtr("total")}},
+			columns: map[string]models.DbtModelColumn{
+				"amount": {Name: "amount", DataType: viewStringPtr("NUMERIC")},
+			},
+			errorMsg: "measure SQL is required for type sum in measure: total",
+		},
+		{
+			name:          "duplicate measure names",
+			modelMeasures: []models.DbtMetaLookerMeasure{{Type: enums.MeasureSum, Name: viewStringPtr("sum_amount"), Column: viewStringPtr("amount")}},
+			columns: map[string]models.DbtModelColumn{
+				"amount": {Name: "amount", DataType: viewStringPtr("NUMERIC"), Meta: columnMeasures(
+					models.DbtMetaLookerMeasure{Type: enums.MeasureSum},
+				)},
+			},
+			errorMsg: "duplicate measure name: sum_amount",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := &models.DbtModel{
+				DbtNode:      models.DbtNode{Name: "orders"},
+				RelationName: "`project.dataset.orders`",
+				Columns:      tt.columns,
+				Meta: &models.DbtModelMeta{
+					Looker: &models.DbtMetaLooker{Measures: tt.modelMeasures},
+				},
+			}
+
+			view, err := generator.GenerateView(model)
+			if tt.errorMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			names := make([]string, 0, len(view.Measures))
+			for _, measure := range view.Measures {
+				names = append(names, measure.Name)
+			}
+			assert.Equal(t, tt.expectedNames, names)
+		})
+	}
+}
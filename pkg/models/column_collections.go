@@ -9,10 +9,19 @@ type ColumnCollections struct {
 	MainViewColumns   map[string]DbtModelColumn            // Columns for the main view
 	NestedViewColumns map[string]map[string]DbtModelColumn // array_name -> columns for nested views
 	ExcludedColumns   map[string]DbtModelColumn            // Columns excluded from all views
+	OverflowArrays    map[string]map[string]DbtModelColumn // parent array ("" for the main view) -> arrays nested beyond the depth limit
 }
 
-// FromModel creates column collections from a dbt model with optimized processing
+// FromModel creates column collections from a dbt model with optimized processing, unnesting
+// arrays up to MaxNestedArrayDepth
 func NewColumnCollections(model *DbtModel, arrayModels []string) *ColumnCollections {
+	return NewColumnCollectionsWithRules(model, arrayModels, NewNestedArrayRules())
+}
+
+// NewColumnCollectionsWithRules creates column collections unnesting the arrays the rules
+// allow. Deeper arrays get no nested view: the outermost of them is collected in
+// OverflowArrays under its closest unnested parent array, and the columns inside it are excluded.
+func NewColumnCollectionsWithRules(model *DbtModel, arrayModels []string, rules *NestedArrayRules) *ColumnCollections {
 	if arrayModels == nil {
 		arrayModels = []string{}
 	}
@@ -37,12 +46,38 @@ func NewColumnCollections(model *DbtModel, arrayModels []string) *ColumnCollecti
 		}
 	}
 
+	// Arrays nested beyond the depth limit are not unnested
+	tooDeepArrays := make(map[string]bool)
+	for arrayName := range arrayModelNames {
+		if !rules.ShouldProcessArray(arrayName) {
+			tooDeepArrays[arrayName] = true
+		}
+	}
+	for arrayName := range tooDeepArrays {
+		delete(arrayModelNames, arrayName)
+	}
+
 	// Single-pass column classification with proper nested array handling
 	mainViewColumns := make(map[string]DbtModelColumn)
 	nestedViewColumns := make(map[string]map[string]DbtModelColumn)
 	excludedColumns := make(map[string]DbtModelColumn)
+	overflowArrays := make(map[string]map[string]DbtModelColumn)
 
 	for colName, column := range allColumns {
+		// Columns inside an array beyond the depth limit are left out with it
+		if findArrayParent(colName, tooDeepArrays) != "" {
+			excludedColumns[colName] = column
+			continue
+		}
+		if tooDeepArrays[colName] {
+			parent := findArrayParent(colName, arrayModelNames)
+			if overflowArrays[parent] == nil {
+				overflowArrays[parent] = make(map[string]DbtModelColumn)
+			}
+			overflowArrays[parent][colName] = column
+			continue
+		}
+
 		// Check if column should be excluded from all views
 		if shouldExcludeFromAllViews(column, hierarchy) {
 			excludedColumns[colName] = column
@@ -107,6 +142,7 @@ func NewColumnCollections(model *DbtModel, arrayModels []string) *ColumnCollecti
 		MainViewColumns:   mainViewColumns,
 		NestedViewColumns: nestedViewColumns,
 		ExcludedColumns:   excludedColumns,
+		OverflowArrays:    overflowArrays,
 	}
 }
 
@@ -368,7 +368,55 @@ func TestColumnCollections_NonArrayStructHandling(t *testing.T) {
 	assert.NotContains(t, collections.NestedViewColumns, "metadata")
 }
 
+func TestColumnCollections_DepthLimit(t *testing.T) {
+	model := &DbtModel{
+		DbtNode: DbtNode{
+			Name: "test_model",
+		},
+		Columns: map[string]DbtModelColumn{
+			"id":                   {Name: "id", DataType: stringPtr("INT64")},
+			"lines":                {Name: "lines", DataType: stringPtr("ARRAY<STRUCT<sku STRING, parts ARRAY<STRUCT<code STRING, tags ARRAY<STRING>>>>>")},
+			"lines.sku":            {Name: "lines.sku", DataType: stringPtr("STRING"), Nested: true},
+			"lines.parts":          {Name: "lines.parts", DataType: stringPtr("ARRAY<STRUCT<code STRING, tags ARRAY<STRING>>>"), Nested: true},
+			"lines.parts.code":     {Name: "lines.parts.code", DataType: stringPtr("STRING"), Nested: true},
+			"lines.parts.tags":     {Name: "lines.parts.tags", DataType: stringPtr("ARRAY<STRING>"), Nested: true},
+			"notes":                {Name: "notes", DataType: stringPtr("ARRAY<STRING>")},
+			"lines.parts.tags.raw": {Name: "lines.parts.tags.raw", DataType: stringPtr("STRING"), Nested: true},
+		},
+	}
+
+	collections := NewColumnCollectionsWithRules(model, nil, NewNestedArrayRulesWithDepth(1))
+	require.NotNil(t, collections)
+
+	// Only top-level arrays are unnested
+	assert.Contains(t, collections.NestedViewColumns, "lines")
+	assert.Contains(t, collections.NestedViewColumns, "notes")
+	assert.NotContains(t, collections.NestedViewColumns, "lines.parts")
+	assert.NotContains(t, collections.NestedViewColumns, "lines.parts.tags")
+	assert.NotContains(t, collections.NestedViewColumns["lines"], "lines.parts")
+
+	// The outermost array beyond the limit belongs to its parent, its columns are excluded
+	assert.Equal(t, []string{"lines.parts"}, keys(collections.OverflowArrays["lines"]))
+	assert.Contains(t, collections.ExcludedColumns, "lines.parts.code")
+	assert.Contains(t, collections.ExcludedColumns, "lines.parts.tags")
+	assert.Contains(t, collections.ExcludedColumns, "lines.parts.tags.raw")
+
+	// The default limit unnests all of them
+	collections = NewColumnCollections(model, nil)
+	assert.Contains(t, collections.NestedViewColumns, "lines.parts")
+	assert.Contains(t, collections.NestedViewColumns, "lines.parts.tags")
+	assert.Empty(t, collections.OverflowArrays)
+}
+
 // Helper functions
+func keys(columns map[string]DbtModelColumn) []string {
+	names := make([]string, 0, len(columns))
+	for name := range columns {
+		names = append(names, name)
+	}
+	return names
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -28,6 +28,7 @@
 package models
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -149,7 +150,8 @@ type DbtCatalog struct {
 
 // DbtModelColumnMeta represents metadata about a column in a dbt model
 type DbtModelColumnMeta struct {
-	Looker *DbtMetaLooker `json:"looker,omitempty" yaml:"looker,omitempty"`
+	Looker      *DbtMetaLooker `json:"looker,omitempty" yaml:"looker,omitempty"`
+	ContainsPII *bool          `json:"contains_pii,omitempty" yaml:"contains_pii,omitempty"` // true governs the column as personal data, false exempts a tagged column
 }
 
 // DbtModelColumn represents a column in a dbt model
@@ -162,8 +164,16 @@ type DbtModelColumn struct {
 	DataType       *string             `json:"data_type,omitempty" yaml:"data_type,omitempty"`
 	InnerTypes     []string            `json:"inner_types" yaml:"inner_types"`
 	Meta           *DbtModelColumnMeta `json:"meta,omitempty" yaml:"meta,omitempty"`
+	Tags           []string            `json:"tags,omitempty" yaml:"tags,omitempty"`
 	Nested         bool                `json:"nested" yaml:"nested"`
 	IsPrimaryKey   bool                `json:"is_primary_key" yaml:"is_primary_key"`
+	Constraints    []DbtConstraint     `json:"constraints,omitempty" yaml:"constraints,omitempty"`
+}
+
+// DbtConstraint represents a constraint of a dbt model or column
+type DbtConstraint struct {
+	Type    enums.DbtConstraintType `json:"type" yaml:"type"`
+	Columns []string                `json:"columns,omitempty" yaml:"columns,omitempty"` // Of model constraints
 }
 
 // ProcessColumn processes the column and sets derived fields
@@ -182,6 +192,13 @@ func (c *DbtModelColumn) ProcessColumn() {
 	// Convert to lowercase for processing
 	c.Name = strings.ToLower(c.Name)
 
+	// A primary_key constraint on the column marks it as the primary key
+	for _, constraint := range c.Constraints {
+		if constraint.Type == enums.ConstraintPrimaryKey {
+			c.IsPrimaryKey = true
+		}
+	}
+
 	// Generate LookML names
 	c.generateLookMLNames()
 
@@ -227,6 +244,23 @@ func isLowerCaseWithoutUnderscore(s string) bool {
 // DbtModelMeta represents metadata about a dbt model
 type DbtModelMeta struct {
 	Looker *DbtMetaLooker `json:"looker,omitempty" yaml:"looker,omitempty"`
+
+	// Values holds every meta key of the model, e.g. a domain used in output paths
+	Values map[string]interface{} `json:"-" yaml:"-"`
+}
+
+// UnmarshalJSON decodes the looker settings and keeps all meta keys in Values
+func (m *DbtModelMeta) UnmarshalJSON(data []byte) error {
+	type metaAlias DbtModelMeta
+	var meta metaAlias
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &meta.Values); err != nil {
+		return err
+	}
+	*m = DbtModelMeta(meta)
+	return nil
 }
 
 // DbtModel represents a dbt model
@@ -234,12 +268,17 @@ type DbtModel struct {
 	DbtNode
 	ResourceType string                    `json:"resource_type" yaml:"resource_type"`
 	RelationName string                    `json:"relation_name" yaml:"relation_name"`
+	Alias        string                    `json:"alias,omitempty" yaml:"alias,omitempty"`
+	PackageName  string                    `json:"package_name,omitempty" yaml:"package_name,omitempty"`
+	FQN          []string                  `json:"fqn,omitempty" yaml:"fqn,omitempty"`
+	Database     string                    `json:"database" yaml:"database"`
 	Schema       string                    `json:"schema" yaml:"schema"`
 	Description  string                    `json:"description" yaml:"description"`
 	Columns      map[string]DbtModelColumn `json:"columns" yaml:"columns"`
 	Tags         []string                  `json:"tags" yaml:"tags"`
 	Meta         *DbtModelMeta             `json:"meta,omitempty" yaml:"meta,omitempty"`
 	Path         string                    `json:"path" yaml:"path"`
+	Constraints  []DbtConstraint           `json:"constraints,omitempty" yaml:"constraints,omitempty"`
 }
 
 // NormalizeColumnNames converts all column names to lowercase for case-insensitive matching
@@ -253,6 +292,22 @@ func (m *DbtModel) NormalizeColumnNames() {
 	m.Columns = normalizedColumns
 }
 
+// ApplyConstraints marks the columns of primary_key model constraints as primary keys. Column
+// constraints are applied by ProcessColumn.
+func (m *DbtModel) ApplyConstraints() {
+	for _, constraint := range m.Constraints {
+		if constraint.Type != enums.ConstraintPrimaryKey {
+			continue
+		}
+		for _, columnName := range constraint.Columns {
+			if column, ok := m.Columns[strings.ToLower(columnName)]; ok {
+				column.IsPrimaryKey = true
+				m.Columns[strings.ToLower(columnName)] = column
+			}
+		}
+	}
+}
+
 // DbtManifestMetadata represents metadata about a dbt manifest
 type DbtManifestMetadata struct {
 	AdapterType string `json:"adapter_type" yaml:"adapter_type"`
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
@@ -18,16 +19,68 @@ type DbtMetaLookerBase struct {
 	Label       *string `json:"label,omitempty" yaml:"label,omitempty"`
 	Description *string `json:"description,omitempty" yaml:"description,omitempty"`
 	Hidden      *bool   `json:"hidden,omitempty" yaml:"hidden,omitempty"`
+
+	// Extra holds LookML parameters without a typed setting, rendered verbatim
+	Extra map[string]interface{} `json:"extra,omitempty" yaml:"extra,omitempty"`
+}
+
+// DbtMetaLookerLink represents a link of a dimension or measure
+type DbtMetaLookerLink struct {
+	Label   string  `json:"label" yaml:"label"`
+	URL     string  `json:"url" yaml:"url"`
+	IconURL *string `json:"icon_url,omitempty" yaml:"icon_url,omitempty"`
+}
+
+// DbtMetaLookerTranslation holds the label and description of a view or field in one locale
+type DbtMetaLookerTranslation struct {
+	Label          *string `json:"label,omitempty" yaml:"label,omitempty"`
+	Description    *string `json:"description,omitempty" yaml:"description,omitempty"`
+	GroupLabel     *string `json:"group_label,omitempty" yaml:"group_label,omitempty"`
+	GroupItemLabel *string `json:"group_item_label,omitempty" yaml:"group_item_label,omitempty"`
+}
+
+// DbtMetaLookerFieldParameters represents LookML parameters shared by dimensions and measures
+type DbtMetaLookerFieldParameters struct {
+	Links          []DbtMetaLookerLink `json:"links,omitempty" yaml:"links,omitempty"`
+	HTML           *string             `json:"html,omitempty" yaml:"html,omitempty"`
+	Tags           []string            `json:"tags,omitempty" yaml:"tags,omitempty"`
+	RequiredFields []string            `json:"required_fields,omitempty" yaml:"required_fields,omitempty"`
+	Alias          []string            `json:"alias,omitempty" yaml:"alias,omitempty"`
+
+	RequiredAccessGrants []string `json:"required_access_grants,omitempty" yaml:"required_access_grants,omitempty"`
 }
 
 // DbtMetaLookerDimension represents Looker-specific metadata for a dimension
 type DbtMetaLookerDimension struct {
 	DbtMetaLookerBase
-	ConvertTZ       *bool                        `json:"convert_tz,omitempty" yaml:"convert_tz,omitempty"`
-	GroupLabel      *string                      `json:"group_label,omitempty" yaml:"group_label,omitempty"`
-	ValueFormatName *enums.LookerValueFormatName `json:"value_format_name,omitempty" yaml:"value_format_name,omitempty"`
-	Timeframes      []enums.LookerTimeFrame      `json:"timeframes,omitempty" yaml:"timeframes,omitempty"`
-	CanFilter       interface{}                  `json:"can_filter,omitempty" yaml:"can_filter,omitempty"` // Can be bool or string
+	DbtMetaLookerFieldParameters
+	ConvertTZ        *bool                        `json:"convert_tz,omitempty" yaml:"convert_tz,omitempty"`
+	GroupLabel       *string                      `json:"group_label,omitempty" yaml:"group_label,omitempty"`
+	ValueFormatName  *enums.LookerValueFormatName `json:"value_format_name,omitempty" yaml:"value_format_name,omitempty"`
+	Timeframes       []enums.LookerTimeFrame      `json:"timeframes,omitempty" yaml:"timeframes,omitempty"`
+	CanFilter        interface{}                  `json:"can_filter,omitempty" yaml:"can_filter,omitempty"` // Can be bool or string
+	Drill            *bool                        `json:"drill,omitempty" yaml:"drill,omitempty"`           // Include in (true) or leave out of (false) the view's detail set
+	MapLayerName     *string                      `json:"map_layer_name,omitempty" yaml:"map_layer_name,omitempty"`
+	JSONPaths        map[string]string            `json:"json_paths,omitempty" yaml:"json_paths,omitempty"` // Dimensions extracted from a JSON column, by name
+	OrderByField     *string                      `json:"order_by_field,omitempty" yaml:"order_by_field,omitempty"`
+	SuggestDimension *string                      `json:"suggest_dimension,omitempty" yaml:"suggest_dimension,omitempty"`
+	CaseSensitive    *bool                        `json:"case_sensitive,omitempty" yaml:"case_sensitive,omitempty"`
+}
+
+// DbtMetaLookerDuration represents a duration dimension group measuring the time between two date/time columns
+type DbtMetaLookerDuration struct {
+	Name      string                         `json:"name,omitempty" yaml:"name,omitempty"`
+	Start     string                         `json:"start" yaml:"start"`
+	End       string                         `json:"end" yaml:"end"`
+	Intervals []enums.LookerDurationInterval `json:"intervals,omitempty" yaml:"intervals,omitempty"`
+}
+
+// DbtMetaLookerLocation represents a location dimension built from a latitude and a longitude dimension
+type DbtMetaLookerLocation struct {
+	Name      string  `json:"name" yaml:"name"`
+	Latitude  string  `json:"latitude" yaml:"latitude"`
+	Longitude string  `json:"longitude" yaml:"longitude"`
+	Label     *string `json:"label,omitempty" yaml:"label,omitempty"`
 }
 
 // DbtMetaLookerMeasureFilter represents a filter for Looker measures
@@ -39,31 +92,86 @@ type DbtMetaLookerMeasureFilter struct {
 // DbtMetaLookerMeasure represents Looker metadata for a measure
 type DbtMetaLookerMeasure struct {
 	DbtMetaLookerBase
+	DbtMetaLookerFieldParameters
 	// Required fields
 	Type enums.LookerMeasureType `json:"type" yaml:"type"`
 
 	// Common optional fields
 	Name            *string                      `json:"name,omitempty" yaml:"name,omitempty"`
+	SQL             *string                      `json:"sql,omitempty" yaml:"sql,omitempty"`               // SQL expression to aggregate
+	Column          *string                      `json:"column,omitempty" yaml:"column,omitempty"`         // Column to aggregate, referenced by its dimension
+	Expression      *string                      `json:"expression,omitempty" yaml:"expression,omitempty"` // Arithmetic over other measures, for type number
 	GroupLabel      *string                      `json:"group_label,omitempty" yaml:"group_label,omitempty"`
 	ValueFormatName *enums.LookerValueFormatName `json:"value_format_name,omitempty" yaml:"value_format_name,omitempty"`
 	Filters         []DbtMetaLookerMeasureFilter `json:"filters,omitempty" yaml:"filters,omitempty"`
+	DrillFields     []string                     `json:"drill_fields,omitempty" yaml:"drill_fields,omitempty"` // Overrides [detail*]; [] disables drilling
 
 	// Fields specific to certain measure types
 	Approximate          *bool   `json:"approximate,omitempty" yaml:"approximate,omitempty"`                     // For count_distinct
 	ApproximateThreshold *int    `json:"approximate_threshold,omitempty" yaml:"approximate_threshold,omitempty"` // For count_distinct
 	Precision            *int    `json:"precision,omitempty" yaml:"precision,omitempty"`                         // For average, sum
-	SQLDistinctKey       *string `json:"sql_distinct_key,omitempty" yaml:"sql_distinct_key,omitempty"`           // For count_distinct
+	SQLDistinctKey       *string `json:"sql_distinct_key,omitempty" yaml:"sql_distinct_key,omitempty"`           // For count_distinct and the *_distinct measures
 	Percentile           *int    `json:"percentile,omitempty" yaml:"percentile,omitempty"`                       // For percentile measures
+
+	// Fields of period_over_period measures
+	BasedOn     *string                           `json:"based_on,omitempty" yaml:"based_on,omitempty"`           // Measure to compare
+	BasedOnTime *string                           `json:"based_on_time,omitempty" yaml:"based_on_time,omitempty"` // Dimension group timeframe, e.g. created_year
+	Period      *enums.LookerPeriod               `json:"period,omitempty" yaml:"period,omitempty"`
+	Kind        *enums.LookerPeriodOverPeriodKind `json:"kind,omitempty" yaml:"kind,omitempty"`
+
+	Translations map[string]DbtMetaLookerTranslation `json:"translations,omitempty" yaml:"translations,omitempty"` // By locale
+}
+
+// UnmarshalJSON accepts both measure objects and the shorthand form, where a measure
+// is given by its type only (e.g. measures: [sum, average, max])
+func (m *DbtMetaLookerMeasure) UnmarshalJSON(data []byte) error {
+	var measureType string
+	if err := json.Unmarshal(data, &measureType); err == nil {
+		*m = DbtMetaLookerMeasure{Type: enums.LookerMeasureType(measureType)}
+		return nil
+	}
+
+	type measureAlias DbtMetaLookerMeasure
+	var measure measureAlias
+	if err := json.Unmarshal(data, &measure); err != nil {
+		return err
+	}
+	*m = DbtMetaLookerMeasure(measure)
+	return nil
 }
 
 // ValidateMeasureAttributes validates that measure attributes are compatible with the measure type
 func (m *DbtMetaLookerMeasure) ValidateMeasureAttributes() error {
 	measureType := m.Type
 
+	if m.SQL != nil && m.Column != nil {
+		return fmt.Errorf("sql and column cannot both be set")
+	}
+
+	if m.Expression != nil {
+		if measureType != enums.MeasureNumber {
+			return fmt.Errorf("expression can only be used with number measures")
+		}
+		if m.SQL != nil || m.Column != nil {
+			return fmt.Errorf("expression cannot be combined with sql or column")
+		}
+	}
+
+	if measureType == enums.MeasurePeriodOverPeriod {
+		if m.SQL != nil || m.Column != nil || m.Expression != nil {
+			return fmt.Errorf("period_over_period measures cannot set sql, column or expression")
+		}
+	} else if m.BasedOn != nil || m.BasedOnTime != nil || m.Period != nil || m.Kind != nil {
+		return fmt.Errorf("based_on, based_on_time, period and kind can only be used with period_over_period measures")
+	}
+
 	// Validate type-specific attributes
-	if (m.Approximate != nil || m.ApproximateThreshold != nil || m.SQLDistinctKey != nil) &&
-		measureType != enums.MeasureCountDistinct {
-		return fmt.Errorf("approximate, approximate_threshold, and sql_distinct_key can only be used with count_distinct measures")
+	if (m.Approximate != nil || m.ApproximateThreshold != nil) && measureType != enums.MeasureCountDistinct {
+		return fmt.Errorf("approximate and approximate_threshold can only be used with count_distinct measures")
+	}
+
+	if m.SQLDistinctKey != nil && !isDistinctMeasure(measureType) {
+		return fmt.Errorf("sql_distinct_key can only be used with count_distinct and *_distinct measures")
 	}
 
 	if m.Percentile != nil && !isPercentileMeasure(string(measureType)) {
@@ -77,6 +185,15 @@ func (m *DbtMetaLookerMeasure) ValidateMeasureAttributes() error {
 	return nil
 }
 
+// isDistinctMeasure checks if the measure type aggregates distinct values by sql_distinct_key
+func isDistinctMeasure(measureType enums.LookerMeasureType) bool {
+	switch measureType {
+	case enums.MeasureCountDistinct, enums.MeasureSumDistinct, enums.MeasureAverageDistinct, enums.MeasureMedianDistinct:
+		return true
+	}
+	return false
+}
+
 // isPercentileMeasure checks if the measure type is a percentile measure
 func isPercentileMeasure(measureType string) bool {
 	return len(measureType) > 10 && measureType[:10] == "percentile"
@@ -84,33 +201,88 @@ func isPercentileMeasure(measureType string) bool {
 
 // DbtMetaLookerJoin represents Looker-specific metadata for joins
 type DbtMetaLookerJoin struct {
-	JoinModel    *string                       `json:"join_model,omitempty" yaml:"join_model,omitempty"`
-	SQLON        *string                       `json:"sql_on,omitempty" yaml:"sql_on,omitempty"`
-	Type         *enums.LookerJoinType         `json:"type,omitempty" yaml:"type,omitempty"`
-	Relationship *enums.LookerRelationshipType `json:"relationship,omitempty" yaml:"relationship,omitempty"`
+	JoinModel     *string                       `json:"join_model,omitempty" yaml:"join_model,omitempty"` // dbt model name or ref() string
+	Alias         *string                       `json:"alias,omitempty" yaml:"alias,omitempty"`           // Join name, rendered with from: <view>
+	SQLON         *string                       `json:"sql_on,omitempty" yaml:"sql_on,omitempty"`
+	Type          *enums.LookerJoinType         `json:"type,omitempty" yaml:"type,omitempty"`
+	Relationship  *enums.LookerRelationshipType `json:"relationship,omitempty" yaml:"relationship,omitempty"`
+	ViewLabel     *string                       `json:"view_label,omitempty" yaml:"view_label,omitempty"`
+	Fields        []string                      `json:"fields,omitempty" yaml:"fields,omitempty"`
+	RequiredJoins []string                      `json:"required_joins,omitempty" yaml:"required_joins,omitempty"`
+}
+
+// DbtMetaLookerConditionalFilter represents a conditionally_filter setting for an explore
+type DbtMetaLookerConditionalFilter struct {
+	Filters map[string]string `json:"filters,omitempty" yaml:"filters,omitempty"` // field -> filter expression
+	Unless  []string          `json:"unless,omitempty" yaml:"unless,omitempty"`
+}
+
+// DbtMetaLookerExplore represents Looker metadata for the explore generated for a model
+type DbtMetaLookerExplore struct {
+	DbtMetaLookerBase
+	GroupLabel          *string                         `json:"group_label,omitempty" yaml:"group_label,omitempty"`
+	AlwaysFilter        map[string]string               `json:"always_filter,omitempty" yaml:"always_filter,omitempty"` // field -> filter expression
+	ConditionallyFilter *DbtMetaLookerConditionalFilter `json:"conditionally_filter,omitempty" yaml:"conditionally_filter,omitempty"`
+	SQLAlwaysWhere      *string                         `json:"sql_always_where,omitempty" yaml:"sql_always_where,omitempty"`
+	AccessFilter        map[string]string               `json:"access_filter,omitempty" yaml:"access_filter,omitempty"` // user attribute -> field
+	Fields              []string                        `json:"fields,omitempty" yaml:"fields,omitempty"`
+	PersistWith         *string                         `json:"persist_with,omitempty" yaml:"persist_with,omitempty"`
+	Tags                []string                        `json:"tags,omitempty" yaml:"tags,omitempty"`
+	AlwaysJoin          []string                        `json:"always_join,omitempty" yaml:"always_join,omitempty"`
+}
+
+// DbtMetaLookerNestedArrays overrides the nested_arrays options for the arrays of a model
+type DbtMetaLookerNestedArrays struct {
+	MaxDepth *int    `json:"max_depth,omitempty" yaml:"max_depth,omitempty"`
+	Overflow *string `json:"overflow,omitempty" yaml:"overflow,omitempty"` // drop or json
+	Offset   *bool   `json:"offset,omitempty" yaml:"offset,omitempty"`
+}
+
+// DbtMetaLookerUnnest sets how an array column is joined into the explore of its model
+type DbtMetaLookerUnnest struct {
+	Join         *string                       `json:"join,omitempty" yaml:"join,omitempty"`                 // left or cross
+	Relationship *enums.LookerRelationshipType `json:"relationship,omitempty" yaml:"relationship,omitempty"` // one_to_many or one_to_one
 }
 
 // DbtMetaLooker represents Looker metadata for a model
 type DbtMetaLooker struct {
-	View      *DbtMetaLookerBase      `json:"view,omitempty" yaml:"view,omitempty"`
-	Dimension *DbtMetaLookerDimension `json:"dimension,omitempty" yaml:"dimension,omitempty"`
-	Measures  []DbtMetaLookerMeasure  `json:"measures,omitempty" yaml:"measures,omitempty"`
-	Joins     []DbtMetaLookerJoin     `json:"joins,omitempty" yaml:"joins,omitempty"`
+	View         *DbtMetaLookerBase         `json:"view,omitempty" yaml:"view,omitempty"`
+	Dimension    *DbtMetaLookerDimension    `json:"dimension,omitempty" yaml:"dimension,omitempty"`
+	Measures     []DbtMetaLookerMeasure     `json:"measures,omitempty" yaml:"measures,omitempty"`
+	Joins        []DbtMetaLookerJoin        `json:"joins,omitempty" yaml:"joins,omitempty"`
+	Explore      *DbtMetaLookerExplore      `json:"explore,omitempty" yaml:"explore,omitempty"`
+	Model        *string                    `json:"model,omitempty" yaml:"model,omitempty"`                 // Name of the generated model file
+	AutoMeasures *bool                      `json:"auto_measures,omitempty" yaml:"auto_measures,omitempty"` // false opts out of measure templates
+	DrillFields  []string                   `json:"drill_fields,omitempty" yaml:"drill_fields,omitempty"`   // Fields of the view's detail set
+	Locations    []DbtMetaLookerLocation    `json:"locations,omitempty" yaml:"locations,omitempty"`
+	Durations    []DbtMetaLookerDuration    `json:"durations,omitempty" yaml:"durations,omitempty"`
+	NestedArrays *DbtMetaLookerNestedArrays `json:"nested_arrays,omitempty" yaml:"nested_arrays,omitempty"`
+	Unnest       *DbtMetaLookerUnnest       `json:"unnest,omitempty" yaml:"unnest,omitempty"` // Of an array column
+
+	Translations map[string]DbtMetaLookerTranslation `json:"translations,omitempty" yaml:"translations,omitempty"` // Of the view or column, by locale
 }
 
 // LookMLDimension represents a dimension in LookML
 type LookMLDimension struct {
-	Name            string                       `json:"name" yaml:"name"`
-	Type            string                       `json:"type" yaml:"type"`
-	SQL             string                       `json:"sql" yaml:"sql"`
-	Label           *string                      `json:"label,omitempty" yaml:"label,omitempty"`
-	Description     *string                      `json:"description,omitempty" yaml:"description,omitempty"`
-	Hidden          *bool                        `json:"hidden,omitempty" yaml:"hidden,omitempty"`
-	GroupLabel      *string                      `json:"group_label,omitempty" yaml:"group_label,omitempty"`
-	GroupItemLabel  *string                      `json:"group_item_label,omitempty" yaml:"group_item_label,omitempty"`
-	ValueFormatName *enums.LookerValueFormatName `json:"value_format_name,omitempty" yaml:"value_format_name,omitempty"`
-	CanFilter       *bool                        `json:"can_filter,omitempty" yaml:"can_filter,omitempty"`
-	ConvertTZ       *bool                        `json:"convert_tz,omitempty" yaml:"convert_tz,omitempty"`
+	Name             string                       `json:"name" yaml:"name"`
+	Type             string                       `json:"type" yaml:"type"`
+	SQL              string                       `json:"sql" yaml:"sql"`
+	PrimaryKey       *bool                        `json:"primary_key,omitempty" yaml:"primary_key,omitempty"`
+	Label            *string                      `json:"label,omitempty" yaml:"label,omitempty"`
+	Description      *string                      `json:"description,omitempty" yaml:"description,omitempty"`
+	Hidden           *bool                        `json:"hidden,omitempty" yaml:"hidden,omitempty"`
+	GroupLabel       *string                      `json:"group_label,omitempty" yaml:"group_label,omitempty"`
+	GroupItemLabel   *string                      `json:"group_item_label,omitempty" yaml:"group_item_label,omitempty"`
+	ValueFormatName  *enums.LookerValueFormatName `json:"value_format_name,omitempty" yaml:"value_format_name,omitempty"`
+	CanFilter        *bool                        `json:"can_filter,omitempty" yaml:"can_filter,omitempty"`
+	ConvertTZ        *bool                        `json:"convert_tz,omitempty" yaml:"convert_tz,omitempty"`
+	SQLLatitude      string                       `json:"sql_latitude,omitempty" yaml:"sql_latitude,omitempty"`
+	SQLLongitude     string                       `json:"sql_longitude,omitempty" yaml:"sql_longitude,omitempty"`
+	MapLayerName     *string                      `json:"map_layer_name,omitempty" yaml:"map_layer_name,omitempty"`
+	OrderByField     *string                      `json:"order_by_field,omitempty" yaml:"order_by_field,omitempty"`
+	SuggestDimension *string                      `json:"suggest_dimension,omitempty" yaml:"suggest_dimension,omitempty"`
+	CaseSensitive    *bool                        `json:"case_sensitive,omitempty" yaml:"case_sensitive,omitempty"`
+	LookMLFieldParameters
 }
 
 // Validate checks if the dimension has all required fields
@@ -121,7 +293,20 @@ func (d *LookMLDimension) Validate() error {
 	if d.Type == "" {
 		return fmt.Errorf("dimension type is required for dimension: %s", d.Name)
 	}
-	if d.SQL == "" {
+
+	// Location dimensions can be built from a latitude and a longitude instead of sql
+	hasCoordinates := d.SQLLatitude != "" || d.SQLLongitude != ""
+	if hasCoordinates {
+		if d.Type != "location" {
+			return fmt.Errorf("sql_latitude and sql_longitude are only allowed for location dimensions: %s", d.Name)
+		}
+		if d.SQLLatitude == "" || d.SQLLongitude == "" {
+			return fmt.Errorf("location dimension %s needs both sql_latitude and sql_longitude", d.Name)
+		}
+		if d.SQL != "" {
+			return fmt.Errorf("location dimension %s cannot combine sql with sql_latitude and sql_longitude", d.Name)
+		}
+	} else if d.SQL == "" {
 		return fmt.Errorf("dimension SQL is required for dimension: %s", d.Name)
 	}
 
@@ -138,38 +323,155 @@ func (d *LookMLDimension) Validate() error {
 		return fmt.Errorf("invalid dimension type '%s' for dimension: %s", d.Type, d.Name)
 	}
 
+	if err := d.LookMLFieldParameters.Validate(reservedDimensionParameters); err != nil {
+		return fmt.Errorf("invalid parameters in dimension %s: %w", d.Name, err)
+	}
+
 	return nil
 }
 
 // LookMLDimensionGroup represents a dimension group in LookML
 type LookMLDimensionGroup struct {
-	Name        string                  `json:"name" yaml:"name"`
-	Type        string                  `json:"type" yaml:"type"`
-	SQL         string                  `json:"sql" yaml:"sql"`
-	Label       *string                 `json:"label,omitempty" yaml:"label,omitempty"`
-	Description *string                 `json:"description,omitempty" yaml:"description,omitempty"`
-	Hidden      *bool                   `json:"hidden,omitempty" yaml:"hidden,omitempty"`
-	GroupLabel  *string                 `json:"group_label,omitempty" yaml:"group_label,omitempty"`
-	Timeframes  []enums.LookerTimeFrame `json:"timeframes,omitempty" yaml:"timeframes,omitempty"`
-	ConvertTZ   *bool                   `json:"convert_tz,omitempty" yaml:"convert_tz,omitempty"`
+	Name        string                    `json:"name" yaml:"name"`
+	Type        string                    `json:"type" yaml:"type"`
+	SQL         string                    `json:"sql" yaml:"sql"`
+	Label       *string                   `json:"label,omitempty" yaml:"label,omitempty"`
+	Description *string                   `json:"description,omitempty" yaml:"description,omitempty"`
+	Hidden      *bool                     `json:"hidden,omitempty" yaml:"hidden,omitempty"`
+	GroupLabel  *string                   `json:"group_label,omitempty" yaml:"group_label,omitempty"`
+	Timeframes  []enums.LookerTimeFrame   `json:"timeframes,omitempty" yaml:"timeframes,omitempty"`
+	ConvertTZ   *bool                     `json:"convert_tz,omitempty" yaml:"convert_tz,omitempty"`
+	Datatype    *enums.LookerTimeDatatype `json:"datatype,omitempty" yaml:"datatype,omitempty"`
+
+	// Duration dimension groups measure the time between sql_start and sql_end
+	SQLStart  string                         `json:"sql_start,omitempty" yaml:"sql_start,omitempty"`
+	SQLEnd    string                         `json:"sql_end,omitempty" yaml:"sql_end,omitempty"`
+	Intervals []enums.LookerDurationInterval `json:"intervals,omitempty" yaml:"intervals,omitempty"`
+
+	LookMLFieldParameters
+}
+
+// durationIntervals lists the valid intervals of a duration dimension group, which are
+// also the fields Looker creates when no intervals are given
+var durationIntervals = []enums.LookerDurationInterval{
+	enums.IntervalSecond,
+	enums.IntervalMinute,
+	enums.IntervalHour,
+	enums.IntervalDay,
+	enums.IntervalWeek,
+	enums.IntervalMonth,
+	enums.IntervalQuarter,
+	enums.IntervalYear,
+}
+
+// IsValidDurationInterval checks whether an interval can be used in a duration dimension group
+func IsValidDurationInterval(interval enums.LookerDurationInterval) bool {
+	for _, valid := range durationIntervals {
+		if interval == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// GeneratedTimeframes returns the timeframes Looker creates for a time dimension group:
+// its explicit timeframes, or Looker's defaults when none are set
+func (dg *LookMLDimensionGroup) GeneratedTimeframes() []enums.LookerTimeFrame {
+	if len(dg.Timeframes) == 0 {
+		return defaultTimeframes
+	}
+	return dg.Timeframes
+}
+
+// FieldNames returns the names of the fields Looker creates for the dimension group: one per
+// timeframe (e.g. created_date), or one per interval for duration groups (e.g. days_open)
+func (dg *LookMLDimensionGroup) FieldNames() []string {
+	var names []string
+	if dg.Type == "duration" {
+		intervals := dg.Intervals
+		if len(intervals) == 0 {
+			intervals = durationIntervals
+		}
+		for _, interval := range intervals {
+			names = append(names, fmt.Sprintf("%ss_%s", interval, dg.Name))
+		}
+		return names
+	}
+
+	for _, timeframe := range dg.GeneratedTimeframes() {
+		names = append(names, fmt.Sprintf("%s_%s", dg.Name, timeframe))
+	}
+	return names
+}
+
+// Validate checks if the dimension group has all required fields. Duration dimension
+// groups need sql_start and sql_end instead of sql.
+func (dg *LookMLDimensionGroup) Validate() error {
+	if dg.Name == "" {
+		return fmt.Errorf("dimension group name is required")
+	}
+	if dg.Type == "" {
+		return fmt.Errorf("dimension group type is required for dimension group: %s", dg.Name)
+	}
+
+	if err := dg.LookMLFieldParameters.Validate(reservedDimensionGroupParameters); err != nil {
+		return fmt.Errorf("invalid parameters in dimension group %s: %w", dg.Name, err)
+	}
+
+	if dg.Type != "duration" {
+		if dg.SQLStart != "" || dg.SQLEnd != "" || len(dg.Intervals) > 0 {
+			return fmt.Errorf("sql_start, sql_end and intervals are only allowed for duration dimension groups: %s", dg.Name)
+		}
+		if dg.SQL == "" {
+			return fmt.Errorf("dimension group SQL is required for dimension group: %s", dg.Name)
+		}
+		for _, timeframe := range dg.Timeframes {
+			if !timeframe.IsValid() {
+				return fmt.Errorf("invalid timeframe '%s' for dimension group: %s", timeframe, dg.Name)
+			}
+			if dg.Datatype != nil && *dg.Datatype == enums.TimeDatatypeDate && timeframe.IsTimeOfDay() {
+				return fmt.Errorf("timeframe %s needs a time of day, which DATE columns lack, in dimension group: %s", timeframe, dg.Name)
+			}
+		}
+		return nil
+	}
+
+	if dg.SQL != "" || len(dg.Timeframes) > 0 {
+		return fmt.Errorf("duration dimension group %s cannot set sql or timeframes", dg.Name)
+	}
+	if dg.SQLStart == "" || dg.SQLEnd == "" {
+		return fmt.Errorf("duration dimension group %s needs both sql_start and sql_end", dg.Name)
+	}
+	for _, interval := range dg.Intervals {
+		if !IsValidDurationInterval(interval) {
+			return fmt.Errorf("invalid interval '%s' for dimension group: %s", interval, dg.Name)
+		}
+	}
+	return nil
 }
 
 // LookMLMeasure represents a measure in LookML
 type LookMLMeasure struct {
-	Name                 string                       `json:"name" yaml:"name"`
-	Type                 enums.LookerMeasureType      `json:"type" yaml:"type"`
-	SQL                  *string                      `json:"sql,omitempty" yaml:"sql,omitempty"`
-	Label                *string                      `json:"label,omitempty" yaml:"label,omitempty"`
-	Description          *string                      `json:"description,omitempty" yaml:"description,omitempty"`
-	Hidden               *bool                        `json:"hidden,omitempty" yaml:"hidden,omitempty"`
-	GroupLabel           *string                      `json:"group_label,omitempty" yaml:"group_label,omitempty"`
-	ValueFormatName      *enums.LookerValueFormatName `json:"value_format_name,omitempty" yaml:"value_format_name,omitempty"`
-	Approximate          *bool                        `json:"approximate,omitempty" yaml:"approximate,omitempty"`
-	ApproximateThreshold *int                         `json:"approximate_threshold,omitempty" yaml:"approximate_threshold,omitempty"`
-	Precision            *int                         `json:"precision,omitempty" yaml:"precision,omitempty"`
-	SQLDistinctKey       *string                      `json:"sql_distinct_key,omitempty" yaml:"sql_distinct_key,omitempty"`
-	Percentile           *int                         `json:"percentile,omitempty" yaml:"percentile,omitempty"`
-	Filters              []DbtMetaLookerMeasureFilter `json:"filters,omitempty" yaml:"filters,omitempty"`
+	Name                 string                            `json:"name" yaml:"name"`
+	Type                 enums.LookerMeasureType           `json:"type" yaml:"type"`
+	SQL                  *string                           `json:"sql,omitempty" yaml:"sql,omitempty"`
+	Label                *string                           `json:"label,omitempty" yaml:"label,omitempty"`
+	Description          *string                           `json:"description,omitempty" yaml:"description,omitempty"`
+	Hidden               *bool                             `json:"hidden,omitempty" yaml:"hidden,omitempty"`
+	GroupLabel           *string                           `json:"group_label,omitempty" yaml:"group_label,omitempty"`
+	ValueFormatName      *enums.LookerValueFormatName      `json:"value_format_name,omitempty" yaml:"value_format_name,omitempty"`
+	Approximate          *bool                             `json:"approximate,omitempty" yaml:"approximate,omitempty"`
+	ApproximateThreshold *int                              `json:"approximate_threshold,omitempty" yaml:"approximate_threshold,omitempty"`
+	Precision            *int                              `json:"precision,omitempty" yaml:"precision,omitempty"`
+	SQLDistinctKey       *string                           `json:"sql_distinct_key,omitempty" yaml:"sql_distinct_key,omitempty"`
+	Percentile           *int                              `json:"percentile,omitempty" yaml:"percentile,omitempty"`
+	Filters              []DbtMetaLookerMeasureFilter      `json:"filters,omitempty" yaml:"filters,omitempty"`
+	BasedOn              *string                           `json:"based_on,omitempty" yaml:"based_on,omitempty"`
+	BasedOnTime          *string                           `json:"based_on_time,omitempty" yaml:"based_on_time,omitempty"`
+	Period               *enums.LookerPeriod               `json:"period,omitempty" yaml:"period,omitempty"`
+	Kind                 *enums.LookerPeriodOverPeriodKind `json:"kind,omitempty" yaml:"kind,omitempty"`
+	DrillFields          []string                          `json:"drill_fields,omitempty" yaml:"drill_fields,omitempty"`
+	LookMLFieldParameters
 }
 
 // Validate checks if the measure has all required fields and valid attributes
@@ -181,15 +483,26 @@ func (m *LookMLMeasure) Validate() error {
 		return fmt.Errorf("measure type is required for measure: %s", m.Name)
 	}
 
+	if m.Type == enums.MeasurePeriodOverPeriod {
+		return m.validatePeriodOverPeriod()
+	}
+
 	// Most measure types require SQL (except count)
 	if m.Type != enums.MeasureCount && m.SQL == nil {
 		return fmt.Errorf("measure SQL is required for type %s in measure: %s", m.Type, m.Name)
 	}
 
 	// Validate type-specific attributes
-	if (m.Approximate != nil || m.ApproximateThreshold != nil || m.SQLDistinctKey != nil) &&
-		m.Type != enums.MeasureCountDistinct {
-		return fmt.Errorf("approximate, approximate_threshold, and sql_distinct_key can only be used with count_distinct measures in measure: %s", m.Name)
+	if (m.Approximate != nil || m.ApproximateThreshold != nil) && m.Type != enums.MeasureCountDistinct {
+		return fmt.Errorf("approximate and approximate_threshold can only be used with count_distinct measures in measure: %s", m.Name)
+	}
+
+	if m.SQLDistinctKey != nil && !isDistinctMeasure(m.Type) {
+		return fmt.Errorf("sql_distinct_key can only be used with count_distinct and *_distinct measures in measure: %s", m.Name)
+	}
+
+	if err := m.LookMLFieldParameters.Validate(reservedMeasureParameters); err != nil {
+		return fmt.Errorf("invalid parameters in measure %s: %w", m.Name, err)
 	}
 
 	if m.Percentile != nil && !isPercentileMeasure(string(m.Type)) {
@@ -203,6 +516,40 @@ func (m *LookMLMeasure) Validate() error {
 	return nil
 }
 
+// validatePeriodOverPeriod checks the parameters of a period_over_period measure. Whether
+// based_on and based_on_time exist in the view is checked when the view is generated.
+func (m *LookMLMeasure) validatePeriodOverPeriod() error {
+	if m.SQL != nil {
+		return fmt.Errorf("period_over_period measure %s cannot set sql", m.Name)
+	}
+	if m.BasedOn == nil || *m.BasedOn == "" {
+		return fmt.Errorf("based_on is required for period_over_period measure: %s", m.Name)
+	}
+	if m.BasedOnTime == nil || *m.BasedOnTime == "" {
+		return fmt.Errorf("based_on_time is required for period_over_period measure: %s", m.Name)
+	}
+	if m.Period == nil {
+		return fmt.Errorf("period is required for period_over_period measure: %s", m.Name)
+	}
+
+	switch *m.Period {
+	case enums.PeriodDate, enums.PeriodWeek, enums.PeriodMonth, enums.PeriodQuarter,
+		enums.PeriodFiscalQuarter, enums.PeriodYear, enums.PeriodFiscalYear:
+	default:
+		return fmt.Errorf("period_over_period measure %s has invalid period: %s", m.Name, *m.Period)
+	}
+
+	if m.Kind != nil {
+		switch *m.Kind {
+		case enums.PeriodKindPrevious, enums.PeriodKindDifference, enums.PeriodKindRelativeChange:
+		default:
+			return fmt.Errorf("period_over_period measure %s has invalid kind: %s", m.Name, *m.Kind)
+		}
+	}
+
+	return nil
+}
+
 // LookMLView represents a view in LookML
 type LookMLView struct {
 	Name            string                 `json:"name" yaml:"name"`
@@ -213,6 +560,27 @@ type LookMLView struct {
 	Dimensions      []LookMLDimension      `json:"dimensions,omitempty" yaml:"dimensions,omitempty"`
 	DimensionGroups []LookMLDimensionGroup `json:"dimension_groups,omitempty" yaml:"dimension_groups,omitempty"`
 	Measures        []LookMLMeasure        `json:"measures,omitempty" yaml:"measures,omitempty"`
+	Sets            []LookMLSet            `json:"sets,omitempty" yaml:"sets,omitempty"`
+	Extra           map[string]interface{} `json:"extra,omitempty" yaml:"extra,omitempty"` // Rendered verbatim after label and description
+
+	Translations map[string]DbtMetaLookerTranslation `json:"translations,omitempty" yaml:"translations,omitempty"` // Written to strings files, not rendered
+}
+
+// LookMLSet represents a named set of fields in a view, e.g. the detail set measures drill into
+type LookMLSet struct {
+	Name   string   `json:"name" yaml:"name"`
+	Fields []string `json:"fields" yaml:"fields"`
+}
+
+// Validate checks if the set has a name and fields
+func (s *LookMLSet) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("set name is required")
+	}
+	if len(s.Fields) == 0 {
+		return fmt.Errorf("set %s has no fields", s.Name)
+	}
+	return nil
 }
 
 // Validate checks if the view has all required fields and validates child elements
@@ -224,6 +592,10 @@ func (v *LookMLView) Validate() error {
 		return fmt.Errorf("view sql_table_name is required for view: %s", v.Name)
 	}
 
+	if err := ValidateExtraParameters(v.Extra, reservedViewParameters); err != nil {
+		return fmt.Errorf("invalid parameters in view %s: %w", v.Name, err)
+	}
+
 	// Validate all dimensions
 	for i, dimension := range v.Dimensions {
 		if err := dimension.Validate(); err != nil {
@@ -231,6 +603,13 @@ func (v *LookMLView) Validate() error {
 		}
 	}
 
+	// Validate all dimension groups
+	for i, dimensionGroup := range v.DimensionGroups {
+		if err := dimensionGroup.Validate(); err != nil {
+			return fmt.Errorf("invalid dimension group at index %d in view %s: %w", i, v.Name, err)
+		}
+	}
+
 	// Validate all measures
 	for i, measure := range v.Measures {
 		if err := measure.Validate(); err != nil {
@@ -238,24 +617,251 @@ func (v *LookMLView) Validate() error {
 		}
 	}
 
+	// Validate all sets
+	for i, set := range v.Sets {
+		if err := set.Validate(); err != nil {
+			return fmt.Errorf("invalid set at index %d in view %s: %w", i, v.Name, err)
+		}
+	}
+
 	return nil
 }
 
+// defaultTimeframes lists the fields Looker creates for a time dimension group without explicit timeframes
+var defaultTimeframes = []enums.LookerTimeFrame{
+	enums.TimeFrameRaw,
+	enums.TimeFrameTime,
+	enums.TimeFrameDate,
+	enums.TimeFrameWeek,
+	enums.TimeFrameMonth,
+	enums.TimeFrameQuarter,
+	enums.TimeFrameYear,
+}
+
+// FieldNames returns the names of all fields the view exposes.
+// Dimension groups are expanded into one field per timeframe (e.g. created_date), and
+// duration dimension groups into one field per interval (e.g. days_open).
+func (v *LookMLView) FieldNames() map[string]bool {
+	names := make(map[string]bool)
+
+	for _, dimension := range v.Dimensions {
+		names[dimension.Name] = true
+	}
+
+	for i := range v.DimensionGroups {
+		for _, name := range v.DimensionGroups[i].FieldNames() {
+			names[name] = true
+		}
+	}
+
+	for _, measure := range v.Measures {
+		names[measure.Name] = true
+	}
+
+	return names
+}
+
 // LookMLJoin represents a join in LookML explores
 type LookMLJoin struct {
-	Name         string                        `json:"name" yaml:"name"`
-	ViewLabel    *string                       `json:"view_label,omitempty" yaml:"view_label,omitempty"`
-	SQL          *string                       `json:"sql,omitempty" yaml:"sql,omitempty"`
-	Type         *enums.LookerJoinType         `json:"type,omitempty" yaml:"type,omitempty"`
-	Relationship *enums.LookerRelationshipType `json:"relationship,omitempty" yaml:"relationship,omitempty"`
+	Name          string                        `json:"name" yaml:"name"`
+	From          *string                       `json:"from,omitempty" yaml:"from,omitempty"`
+	ViewLabel     *string                       `json:"view_label,omitempty" yaml:"view_label,omitempty"`
+	SQL           *string                       `json:"sql,omitempty" yaml:"sql,omitempty"`
+	SQLOn         *string                       `json:"sql_on,omitempty" yaml:"sql_on,omitempty"`
+	Type          *enums.LookerJoinType         `json:"type,omitempty" yaml:"type,omitempty"`
+	Relationship  *enums.LookerRelationshipType `json:"relationship,omitempty" yaml:"relationship,omitempty"`
+	Fields        []string                      `json:"fields,omitempty" yaml:"fields,omitempty"`
+	RequiredJoins []string                      `json:"required_joins,omitempty" yaml:"required_joins,omitempty"`
+}
+
+// Validate validates the join structure
+func (j *LookMLJoin) Validate() error {
+	if j.Name == "" {
+		return fmt.Errorf("join name is required")
+	}
+	if j.SQL != nil && j.SQLOn != nil {
+		return fmt.Errorf("join %s cannot set both sql and sql_on", j.Name)
+	}
+	if j.Type != nil {
+		switch *j.Type {
+		case enums.JoinLeftOuter, enums.JoinFullOuter, enums.JoinInner, enums.JoinCross:
+		default:
+			return fmt.Errorf("join %s has invalid type: %s", j.Name, *j.Type)
+		}
+	}
+	if j.Relationship != nil {
+		switch *j.Relationship {
+		case enums.RelationshipManyToOne, enums.RelationshipManyToMany, enums.RelationshipOneToOne, enums.RelationshipOneToMany:
+		default:
+			return fmt.Errorf("join %s has invalid relationship: %s", j.Name, *j.Relationship)
+		}
+	}
+	return nil
+}
+
+// LookMLFilter represents a field filter in always_filter and conditionally_filter
+type LookMLFilter struct {
+	Field      string `json:"field" yaml:"field"`
+	Expression string `json:"expression" yaml:"expression"`
+}
+
+// LookMLConditionalFilter represents the conditionally_filter parameter of an explore
+type LookMLConditionalFilter struct {
+	Filters []LookMLFilter `json:"filters" yaml:"filters"`
+	Unless  []string       `json:"unless,omitempty" yaml:"unless,omitempty"`
+}
+
+// LookMLAccessFilter restricts explore rows to the values of a user attribute
+type LookMLAccessFilter struct {
+	Field         string `json:"field" yaml:"field"`
+	UserAttribute string `json:"user_attribute" yaml:"user_attribute"`
 }
 
 // LookMLExplore represents an explore in LookML
 type LookMLExplore struct {
-	Name        string       `json:"name" yaml:"name"`
-	ViewName    string       `json:"view_name" yaml:"view_name"`
-	Label       *string      `json:"label,omitempty" yaml:"label,omitempty"`
-	Description *string      `json:"description,omitempty" yaml:"description,omitempty"`
-	Hidden      *bool        `json:"hidden,omitempty" yaml:"hidden,omitempty"`
-	Joins       []LookMLJoin `json:"joins,omitempty" yaml:"joins,omitempty"`
+	Name                string                   `json:"name" yaml:"name"`
+	ViewName            string                   `json:"view_name" yaml:"view_name"`
+	Label               *string                  `json:"label,omitempty" yaml:"label,omitempty"`
+	GroupLabel          *string                  `json:"group_label,omitempty" yaml:"group_label,omitempty"`
+	Description         *string                  `json:"description,omitempty" yaml:"description,omitempty"`
+	Hidden              *bool                    `json:"hidden,omitempty" yaml:"hidden,omitempty"`
+	AlwaysFilter        []LookMLFilter           `json:"always_filter,omitempty" yaml:"always_filter,omitempty"`
+	ConditionallyFilter *LookMLConditionalFilter `json:"conditionally_filter,omitempty" yaml:"conditionally_filter,omitempty"`
+	SQLAlwaysWhere      *string                  `json:"sql_always_where,omitempty" yaml:"sql_always_where,omitempty"`
+	AccessFilters       []LookMLAccessFilter     `json:"access_filters,omitempty" yaml:"access_filters,omitempty"`
+	Fields              []string                 `json:"fields,omitempty" yaml:"fields,omitempty"`
+	PersistWith         *string                  `json:"persist_with,omitempty" yaml:"persist_with,omitempty"`
+	Tags                []string                 `json:"tags,omitempty" yaml:"tags,omitempty"`
+	AlwaysJoin          []string                 `json:"always_join,omitempty" yaml:"always_join,omitempty"`
+	Joins               []LookMLJoin             `json:"joins,omitempty" yaml:"joins,omitempty"`
+	Extra               map[string]interface{}   `json:"extra,omitempty" yaml:"extra,omitempty"` // Rendered verbatim before the joins
+}
+
+// Validate validates the explore structure and its joins
+func (e *LookMLExplore) Validate() error {
+	if e.Name == "" {
+		return fmt.Errorf("explore name is required")
+	}
+	if e.ViewName == "" {
+		return fmt.Errorf("explore view_name is required for explore: %s", e.Name)
+	}
+
+	if err := ValidateExtraParameters(e.Extra, reservedExploreParameters); err != nil {
+		return fmt.Errorf("invalid parameters in explore %s: %w", e.Name, err)
+	}
+
+	for _, filter := range e.AlwaysFilter {
+		if filter.Field == "" || filter.Expression == "" {
+			return fmt.Errorf("always_filter in explore %s requires a field and an expression", e.Name)
+		}
+	}
+
+	if e.ConditionallyFilter != nil {
+		if len(e.ConditionallyFilter.Filters) == 0 {
+			return fmt.Errorf("conditionally_filter in explore %s requires at least one filter", e.Name)
+		}
+		for _, filter := range e.ConditionallyFilter.Filters {
+			if filter.Field == "" || filter.Expression == "" {
+				return fmt.Errorf("conditionally_filter in explore %s requires a field and an expression", e.Name)
+			}
+		}
+	}
+
+	for _, accessFilter := range e.AccessFilters {
+		if accessFilter.Field == "" || accessFilter.UserAttribute == "" {
+			return fmt.Errorf("access_filter in explore %s requires a field and a user_attribute", e.Name)
+		}
+	}
+
+	for i, join := range e.Joins {
+		if err := join.Validate(); err != nil {
+			return fmt.Errorf("invalid join at index %d in explore %s: %w", i, e.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// LookMLDatagroup represents a datagroup in a LookML model file
+type LookMLDatagroup struct {
+	Name            string  `json:"name" yaml:"name"`
+	Label           *string `json:"label,omitempty" yaml:"label,omitempty"`
+	Description     *string `json:"description,omitempty" yaml:"description,omitempty"`
+	SQLTrigger      *string `json:"sql_trigger,omitempty" yaml:"sql_trigger,omitempty"`
+	IntervalTrigger *string `json:"interval_trigger,omitempty" yaml:"interval_trigger,omitempty"`
+	MaxCacheAge     *string `json:"max_cache_age,omitempty" yaml:"max_cache_age,omitempty"`
+}
+
+// Validate validates the datagroup structure
+func (d *LookMLDatagroup) Validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("datagroup name is required")
+	}
+	if d.SQLTrigger == nil && d.IntervalTrigger == nil {
+		return fmt.Errorf("datagroup %s requires sql_trigger or interval_trigger", d.Name)
+	}
+	return nil
+}
+
+// LookMLAccessGrant represents an access_grant in a LookML model file
+type LookMLAccessGrant struct {
+	Name          string   `json:"name" yaml:"name"`
+	UserAttribute string   `json:"user_attribute" yaml:"user_attribute"`
+	AllowedValues []string `json:"allowed_values" yaml:"allowed_values"`
+}
+
+// Validate validates the access grant structure
+func (a *LookMLAccessGrant) Validate() error {
+	if a.Name == "" {
+		return fmt.Errorf("access grant name is required")
+	}
+	if a.UserAttribute == "" {
+		return fmt.Errorf("access grant %s requires user_attribute", a.Name)
+	}
+	if len(a.AllowedValues) == 0 {
+		return fmt.Errorf("access grant %s requires allowed_values", a.Name)
+	}
+	return nil
+}
+
+// LookMLModel represents a LookML model file
+type LookMLModel struct {
+	Name         string              `json:"name" yaml:"name"`
+	Connection   string              `json:"connection" yaml:"connection"`
+	Includes     []string            `json:"includes,omitempty" yaml:"includes,omitempty"`
+	Datagroups   []LookMLDatagroup   `json:"datagroups,omitempty" yaml:"datagroups,omitempty"`
+	AccessGrants []LookMLAccessGrant `json:"access_grants,omitempty" yaml:"access_grants,omitempty"`
+
+	// Calendar settings of the week and fiscal timeframes
+	FiscalMonthOffset int                       `json:"fiscal_month_offset,omitempty" yaml:"fiscal_month_offset,omitempty"`
+	WeekStartDay      *enums.LookerWeekStartDay `json:"week_start_day,omitempty" yaml:"week_start_day,omitempty"`
+
+	Explores []LookMLExplore `json:"explores,omitempty" yaml:"explores,omitempty"` // Rendered as refinements of the generated explores
+}
+
+// Validate validates the model structure
+func (m *LookMLModel) Validate() error {
+	if m.Name == "" {
+		return fmt.Errorf("model name is required")
+	}
+	if m.Connection == "" {
+		return fmt.Errorf("model connection is required for model: %s", m.Name)
+	}
+	if m.FiscalMonthOffset < -11 || m.FiscalMonthOffset > 11 {
+		return fmt.Errorf("fiscal_month_offset must be between -11 and 11 in model %s", m.Name)
+	}
+
+	for i, datagroup := range m.Datagroups {
+		if err := datagroup.Validate(); err != nil {
+			return fmt.Errorf("invalid datagroup at index %d in model %s: %w", i, m.Name, err)
+		}
+	}
+
+	for i, grant := range m.AccessGrants {
+		if err := grant.Validate(); err != nil {
+			return fmt.Errorf("invalid access grant at index %d in model %s: %w", i, m.Name, err)
+		}
+	}
+
+	return nil
 }
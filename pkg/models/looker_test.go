@@ -1,10 +1,12 @@
 package models
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/magnus-ffcg/go-dbt2lookml/pkg/enums"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestDbtMetaLookerMeasure_ValidateMeasureAttributes tests measure validation
@@ -74,6 +76,51 @@ func TestDbtMetaLookerMeasure_ValidateMeasureAttributes(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "valid expression on number measure",
+			measure: DbtMetaLookerMeasure{
+				Type:       enums.MeasureNumber,
+				Expression: stringPtr("gross_profit / revenue"),
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid expression on sum measure",
+			measure: DbtMetaLookerMeasure{
+				Type:       enums.MeasureSum,
+				Expression: stringPtr("revenue - cost"),
+			},
+			expectError: true,
+			errorMsg:    "expression can only be used with number measures",
+		},
+		{
+			name: "invalid expression with sql",
+			measure: DbtMetaLookerMeasure{
+				Type:       enums.MeasureNumber,
+				Expression: stringPtr("revenue - cost"),
+				SQL:        stringPtr("${revenue} - ${cost}"),
+			},
+			expectError: true,
+			errorMsg:    "expression cannot be combined with sql or column",
+		},
+		{
+			name: "invalid column on period_over_period measure",
+			measure: DbtMetaLookerMeasure{
+				Type:   enums.MeasurePeriodOverPeriod,
+				Column: stringPtr("amount"),
+			},
+			expectError: true,
+			errorMsg:    "period_over_period measures cannot set sql, column or expression",
+		},
+		{
+			name: "invalid based_on on sum measure",
+			measure: DbtMetaLookerMeasure{
+				Type:    enums.MeasureSum,
+				BasedOn: stringPtr("revenue"),
+			},
+			expectError: true,
+			errorMsg:    "can only be used with period_over_period measures",
+		},
 	}
 
 	for _, tt := range tests {
@@ -188,6 +235,34 @@ func TestLookMLView_Structure(t *testing.T) {
 	assert.Equal(t, "Customer View", *view.Label)
 }
 
+// TestLookMLView_FieldNames tests that dimension groups are expanded per timeframe
+func TestLookMLView_FieldNames(t *testing.T) {
+	view := LookMLView{
+		Name:       "orders",
+		Dimensions: []LookMLDimension{{Name: "id"}},
+		DimensionGroups: []LookMLDimensionGroup{
+			{Name: "created", Timeframes: []enums.LookerTimeFrame{enums.TimeFrameDate, enums.TimeFrameMonth}},
+			{Name: "updated"},
+			{Name: "open", Type: "duration", Intervals: []enums.LookerDurationInterval{enums.IntervalDay}},
+		},
+		Measures: []LookMLMeasure{{Name: "count", Type: enums.MeasureCount}},
+	}
+
+	names := view.FieldNames()
+
+	assert.True(t, names["id"])
+	assert.True(t, names["count"])
+	assert.True(t, names["created_date"])
+	assert.True(t, names["created_month"])
+	assert.False(t, names["created_year"])
+	assert.False(t, names["created"])
+	assert.True(t, names["updated_raw"])
+	assert.True(t, names["updated_year"])
+	assert.True(t, names["days_open"])
+	assert.False(t, names["hours_open"])
+	assert.False(t, names["open_date"])
+}
+
 // TestLookMLExplore_Structure tests explore structure
 func TestLookMLExplore_Structure(t *testing.T) {
 	label := "Customer Analysis"
@@ -311,3 +386,23 @@ func boolPtr(b bool) *bool {
 func intPtr(i int) *int {
 	return &i
 }
+
+func TestDbtMetaLookerMeasure_UnmarshalJSON(t *testing.T) {
+	var meta DbtMetaLooker
+	err := json.Unmarshal([]byte(`{"measures": ["sum", {"type": "max", "name": "latest", "column": "amount"}, "average"]}`), &meta)
+	require.NoError(t, err)
+	require.Len(t, meta.Measures, 3)
+
+	assert.Equal(t, enums.MeasureSum, meta.Measures[0].Type)
+	assert.Nil(t, meta.Measures[0].Name)
+
+	assert.Equal(t, enums.MeasureMax, meta.Measures[1].Type)
+	require.NotNil(t, meta.Measures[1].Name)
+	assert.Equal(t, "latest", *meta.Measures[1].Name)
+	require.NotNil(t, meta.Measures[1].Column)
+	assert.Equal(t, "amount", *meta.Measures[1].Column)
+
+	assert.Equal(t, enums.MeasureAverage, meta.Measures[2].Type)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"measures": [1]}`), &meta))
+}
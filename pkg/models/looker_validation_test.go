@@ -78,6 +78,49 @@ func TestLookMLDimension_Validate(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "valid location from coordinates",
+			dimension: LookMLDimension{
+				Name:         "store_location",
+				Type:         "location",
+				SQLLatitude:  "${store_latitude}",
+				SQLLongitude: "${store_longitude}",
+			},
+			expectError: false,
+		},
+		{
+			name: "location without longitude",
+			dimension: LookMLDimension{
+				Name:        "store_location",
+				Type:        "location",
+				SQLLatitude: "${store_latitude}",
+			},
+			expectError: true,
+			errorMsg:    "needs both sql_latitude and sql_longitude",
+		},
+		{
+			name: "coordinates on a string dimension",
+			dimension: LookMLDimension{
+				Name:         "store",
+				Type:         "string",
+				SQLLatitude:  "${store_latitude}",
+				SQLLongitude: "${store_longitude}",
+			},
+			expectError: true,
+			errorMsg:    "only allowed for location dimensions",
+		},
+		{
+			name: "location with sql and coordinates",
+			dimension: LookMLDimension{
+				Name:         "store_location",
+				Type:         "location",
+				SQL:          "${TABLE}.location",
+				SQLLatitude:  "${store_latitude}",
+				SQLLongitude: "${store_longitude}",
+			},
+			expectError: true,
+			errorMsg:    "cannot combine sql",
+		},
 	}
 
 	for _, tt := range tests {
@@ -95,6 +138,110 @@ func TestLookMLDimension_Validate(t *testing.T) {
 	}
 }
 
+func TestLookMLDimensionGroup_Validate(t *testing.T) {
+	dateDatatype := enums.TimeDatatypeDate
+
+	tests := []struct {
+		name           string
+		dimensionGroup LookMLDimensionGroup
+		expectError    bool
+		errorMsg       string
+	}{
+		{
+			name:           "valid time dimension group",
+			dimensionGroup: LookMLDimensionGroup{Name: "created", Type: "time", SQL: "${TABLE}.created_at"},
+			expectError:    false,
+		},
+		{
+			name: "valid duration",
+			dimensionGroup: LookMLDimensionGroup{
+				Name: "open", Type: "duration", SQLStart: "${TABLE}.created_at", SQLEnd: "${TABLE}.resolved_at",
+				Intervals: []enums.LookerDurationInterval{enums.IntervalHour, enums.IntervalDay},
+			},
+			expectError: false,
+		},
+		{
+			name:           "missing SQL",
+			dimensionGroup: LookMLDimensionGroup{Name: "created", Type: "time"},
+			expectError:    true,
+			errorMsg:       "dimension group SQL is required",
+		},
+		{
+			name:           "duration without end",
+			dimensionGroup: LookMLDimensionGroup{Name: "open", Type: "duration", SQLStart: "${TABLE}.created_at"},
+			expectError:    true,
+			errorMsg:       "needs both sql_start and sql_end",
+		},
+		{
+			name: "duration with timeframes",
+			dimensionGroup: LookMLDimensionGroup{
+				Name: "open", Type: "duration", SQLStart: "${TABLE}.created_at", SQLEnd: "${TABLE}.resolved_at",
+				Timeframes: []enums.LookerTimeFrame{enums.TimeFrameDate},
+			},
+			expectError: true,
+			errorMsg:    "cannot set sql or timeframes",
+		},
+		{
+			name: "invalid interval",
+			dimensionGroup: LookMLDimensionGroup{
+				Name: "open", Type: "duration", SQLStart: "${TABLE}.created_at", SQLEnd: "${TABLE}.resolved_at",
+				Intervals: []enums.LookerDurationInterval{"fortnight"},
+			},
+			expectError: true,
+			errorMsg:    "invalid interval 'fortnight'",
+		},
+		{
+			name: "intervals on a time dimension group",
+			dimensionGroup: LookMLDimensionGroup{
+				Name: "created", Type: "time", SQL: "${TABLE}.created_at",
+				Intervals: []enums.LookerDurationInterval{enums.IntervalDay},
+			},
+			expectError: true,
+			errorMsg:    "only allowed for duration dimension groups",
+		},
+		{
+			name: "date parts on a DATE column",
+			dimensionGroup: LookMLDimensionGroup{
+				Name: "order", Type: "time", SQL: "${TABLE}.order_date", Datatype: &dateDatatype,
+				Timeframes: []enums.LookerTimeFrame{enums.TimeFrameDayOfWeek, enums.TimeFrameWeekOfYear, enums.TimeFrameFiscalQuarter},
+			},
+			expectError: false,
+		},
+		{
+			name: "unknown timeframe",
+			dimensionGroup: LookMLDimensionGroup{
+				Name: "created", Type: "time", SQL: "${TABLE}.created_at",
+				Timeframes: []enums.LookerTimeFrame{"fortnight"},
+			},
+			expectError: true,
+			errorMsg:    "invalid timeframe 'fortnight'",
+		},
+		{
+			name: "hour on a DATE column",
+			dimensionGroup: LookMLDimensionGroup{
+				Name: "order", Type: "time", SQL: "${TABLE}.order_date", Datatype: &dateDatatype,
+				Timeframes: []enums.LookerTimeFrame{enums.TimeFrameDate, enums.TimeFrameHour},
+			},
+			expectError: true,
+			errorMsg:    "timeframe hour needs a time of day, which DATE columns lack, in dimension group: order",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dimensionGroup.Validate()
+			if tt.expectError {
+				assert.Error(t, err)
+				if tt.errorMsg != "" {
+					assert.Contains(t, err.Error(), tt.errorMsg)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestLookMLMeasure_Validate(t *testing.T) {
 	sql := "${TABLE}.amount"
 
@@ -147,7 +294,7 @@ func TestLookMLMeasure_Validate(t *testing.T) {
 				Approximate: boolPtr(true),
 			},
 			expectError: true,
-			errorMsg:    "approximate, approximate_threshold, and sql_distinct_key can only be used with count_distinct",
+			errorMsg:    "approximate and approximate_threshold can only be used with count_distinct",
 		},
 		{
 			name: "valid count_distinct with approximate",
@@ -169,6 +316,41 @@ func TestLookMLMeasure_Validate(t *testing.T) {
 			expectError: true,
 			errorMsg:    "precision can only be used with average or sum",
 		},
+		{
+			name: "valid period_over_period",
+			measure: LookMLMeasure{
+				Name:        "revenue_last_year",
+				Type:        enums.MeasurePeriodOverPeriod,
+				BasedOn:     stringPtr("revenue"),
+				BasedOnTime: stringPtr("created_year"),
+				Period:      periodPtr(enums.PeriodYear),
+			},
+			expectError: false,
+		},
+		{
+			name: "period_over_period without based_on_time",
+			measure: LookMLMeasure{
+				Name:    "revenue_last_year",
+				Type:    enums.MeasurePeriodOverPeriod,
+				BasedOn: stringPtr("revenue"),
+				Period:  periodPtr(enums.PeriodYear),
+			},
+			expectError: true,
+			errorMsg:    "based_on_time is required",
+		},
+		{
+			name: "period_over_period with invalid kind",
+			measure: LookMLMeasure{
+				Name:        "revenue_last_year",
+				Type:        enums.MeasurePeriodOverPeriod,
+				BasedOn:     stringPtr("revenue"),
+				BasedOnTime: stringPtr("created_year"),
+				Period:      periodPtr(enums.PeriodYear),
+				Kind:        kindPtr("ratio"),
+			},
+			expectError: true,
+			errorMsg:    "invalid kind: ratio",
+		},
 	}
 
 	for _, tt := range tests {
@@ -253,6 +435,25 @@ func TestLookMLView_Validate(t *testing.T) {
 			expectError: true,
 			errorMsg:    "invalid measure",
 		},
+		{
+			name: "valid set",
+			view: LookMLView{
+				Name:         "test_view",
+				SQLTableName: "schema.table",
+				Sets:         []LookMLSet{{Name: "detail", Fields: []string{"id"}}},
+			},
+			expectError: false,
+		},
+		{
+			name: "set without fields",
+			view: LookMLView{
+				Name:         "test_view",
+				SQLTableName: "schema.table",
+				Sets:         []LookMLSet{{Name: "detail"}},
+			},
+			expectError: true,
+			errorMsg:    "set detail has no fields",
+		},
 	}
 
 	for _, tt := range tests {
@@ -269,3 +470,212 @@ func TestLookMLView_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestLookMLModel_Validate(t *testing.T) {
+	trigger := "SELECT CURRENT_DATE()"
+
+	tests := []struct {
+		name        string
+		model       LookMLModel
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name: "valid model",
+			model: LookMLModel{
+				Name:       "analytics",
+				Connection: "bigquery",
+				Datagroups: []LookMLDatagroup{{Name: "daily", SQLTrigger: &trigger}},
+			},
+			expectError: false,
+		},
+		{
+			name:        "missing name",
+			model:       LookMLModel{Connection: "bigquery"},
+			expectError: true,
+			errorMsg:    "model name is required",
+		},
+		{
+			name:        "missing connection",
+			model:       LookMLModel{Name: "analytics"},
+			expectError: true,
+			errorMsg:    "model connection is required",
+		},
+		{
+			name: "datagroup without trigger",
+			model: LookMLModel{
+				Name:       "analytics",
+				Connection: "bigquery",
+				Datagroups: []LookMLDatagroup{{Name: "daily"}},
+			},
+			expectError: true,
+			errorMsg:    "requires sql_trigger or interval_trigger",
+		},
+		{
+			name: "access grant without allowed values",
+			model: LookMLModel{
+				Name:         "analytics",
+				Connection:   "bigquery",
+				AccessGrants: []LookMLAccessGrant{{Name: "can_see_pii", UserAttribute: "pii_access"}},
+			},
+			expectError: true,
+			errorMsg:    "access grant can_see_pii requires allowed_values",
+		},
+		{
+			name:        "fiscal month offset out of range",
+			model:       LookMLModel{Name: "analytics", Connection: "bigquery", FiscalMonthOffset: 12},
+			expectError: true,
+			errorMsg:    "fiscal_month_offset must be between -11 and 11",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.model.Validate()
+			if tt.expectError {
+				assert.Error(t, err)
+				if tt.errorMsg != "" {
+					assert.Contains(t, err.Error(), tt.errorMsg)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLookMLJoin_Validate(t *testing.T) {
+	sql := "LEFT JOIN UNNEST(${orders.lines}) AS orders__lines"
+	sqlOn := "${orders.customer_id} = ${customers.id}"
+	invalidType := enums.LookerJoinType("outer")
+	invalidRelationship := enums.LookerRelationshipType("many")
+
+	tests := []struct {
+		name        string
+		join        LookMLJoin
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:        "valid sql_on join",
+			join:        LookMLJoin{Name: "customers", SQLOn: &sqlOn},
+			expectError: false,
+		},
+		{
+			name:        "missing name",
+			join:        LookMLJoin{SQLOn: &sqlOn},
+			expectError: true,
+			errorMsg:    "join name is required",
+		},
+		{
+			name:        "sql and sql_on",
+			join:        LookMLJoin{Name: "customers", SQL: &sql, SQLOn: &sqlOn},
+			expectError: true,
+			errorMsg:    "cannot set both sql and sql_on",
+		},
+		{
+			name:        "invalid type",
+			join:        LookMLJoin{Name: "customers", Type: &invalidType},
+			expectError: true,
+			errorMsg:    "invalid type",
+		},
+		{
+			name:        "invalid relationship",
+			join:        LookMLJoin{Name: "customers", Relationship: &invalidRelationship},
+			expectError: true,
+			errorMsg:    "invalid relationship",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.join.Validate()
+			if tt.expectError {
+				assert.Error(t, err)
+				if tt.errorMsg != "" {
+					assert.Contains(t, err.Error(), tt.errorMsg)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLookMLExplore_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		explore     LookMLExplore
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name: "valid explore",
+			explore: LookMLExplore{
+				Name:          "orders",
+				ViewName:      "orders",
+				AlwaysFilter:  []LookMLFilter{{Field: "orders.status", Expression: "complete"}},
+				AccessFilters: []LookMLAccessFilter{{Field: "orders.region", UserAttribute: "region"}},
+			},
+			expectError: false,
+		},
+		{
+			name:        "missing name",
+			explore:     LookMLExplore{ViewName: "orders"},
+			expectError: true,
+			errorMsg:    "explore name is required",
+		},
+		{
+			name:        "missing view name",
+			explore:     LookMLExplore{Name: "orders"},
+			expectError: true,
+			errorMsg:    "explore view_name is required",
+		},
+		{
+			name:        "always filter without expression",
+			explore:     LookMLExplore{Name: "orders", ViewName: "orders", AlwaysFilter: []LookMLFilter{{Field: "orders.status"}}},
+			expectError: true,
+			errorMsg:    "always_filter in explore orders requires a field and an expression",
+		},
+		{
+			name:        "conditionally filter without filters",
+			explore:     LookMLExplore{Name: "orders", ViewName: "orders", ConditionallyFilter: &LookMLConditionalFilter{Unless: []string{"orders.id"}}},
+			expectError: true,
+			errorMsg:    "requires at least one filter",
+		},
+		{
+			name:        "access filter without user attribute",
+			explore:     LookMLExplore{Name: "orders", ViewName: "orders", AccessFilters: []LookMLAccessFilter{{Field: "orders.region"}}},
+			expectError: true,
+			errorMsg:    "requires a field and a user_attribute",
+		},
+		{
+			name:        "invalid join",
+			explore:     LookMLExplore{Name: "orders", ViewName: "orders", Joins: []LookMLJoin{{}}},
+			expectError: true,
+			errorMsg:    "invalid join at index 0 in explore orders",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.explore.Validate()
+			if tt.expectError {
+				assert.Error(t, err)
+				if tt.errorMsg != "" {
+					assert.Contains(t, err.Error(), tt.errorMsg)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func periodPtr(period enums.LookerPeriod) *enums.LookerPeriod {
+	return &period
+}
+
+func kindPtr(kind enums.LookerPeriodOverPeriodKind) *enums.LookerPeriodOverPeriodKind {
+	return &kind
+}
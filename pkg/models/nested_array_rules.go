@@ -2,7 +2,8 @@ package models
 
 import "strings"
 
-// MaxNestedArrayDepth defines the maximum nesting level for array processing.
+// MaxNestedArrayDepth defines the default maximum nesting level for array processing.
+// nested_arrays.max_depth and meta.looker.nested_arrays.max_depth override it.
 //
 // Nesting levels:
 //   - Level 1: items (0 dots in path)
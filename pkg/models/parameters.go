@@ -0,0 +1,159 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// extraParameterKey matches the names of LookML parameters
+var extraParameterKey = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Parameters that are generated from typed settings and cannot be set through extra
+var (
+	reservedFieldParameters = []string{
+		"type", "sql", "label", "description", "hidden", "group_label",
+		"link", "html", "tags", "required_fields", "alias", "required_access_grants",
+	}
+	reservedDimensionParameters = append([]string{
+		"group_item_label", "sql_latitude", "sql_longitude", "map_layer_name",
+		"order_by_field", "suggest_dimension", "case_sensitive",
+	}, reservedFieldParameters...)
+	reservedDimensionGroupParameters = append([]string{
+		"timeframes", "convert_tz", "datatype", "sql_start", "sql_end", "intervals",
+	}, reservedFieldParameters...)
+	reservedMeasureParameters = append([]string{
+		"based_on", "based_on_time", "period", "kind", "value_format_name", "precision",
+		"percentile", "approximate", "approximate_threshold", "sql_distinct_key",
+		"filters", "drill_fields",
+	}, reservedFieldParameters...)
+	reservedViewParameters = []string{
+		"sql_table_name", "label", "description", "dimension", "dimension_group", "measure", "set",
+	}
+	reservedExploreParameters = []string{
+		"view_name", "label", "group_label", "description", "hidden", "always_filter",
+		"conditionally_filter", "sql_always_where", "access_filter", "fields", "persist_with",
+		"tags", "always_join", "join",
+	}
+)
+
+// LookMLFieldParameters holds the parameters shared by dimensions, dimension groups and
+// measures that are passed through from meta
+type LookMLFieldParameters struct {
+	Links          []DbtMetaLookerLink    `json:"links,omitempty" yaml:"links,omitempty"`
+	HTML           *string                `json:"html,omitempty" yaml:"html,omitempty"`
+	Tags           []string               `json:"tags,omitempty" yaml:"tags,omitempty"`
+	RequiredFields []string               `json:"required_fields,omitempty" yaml:"required_fields,omitempty"`
+	Alias          []string               `json:"alias,omitempty" yaml:"alias,omitempty"`
+	Extra          map[string]interface{} `json:"extra,omitempty" yaml:"extra,omitempty"` // Rendered verbatim after the other parameters
+
+	RequiredAccessGrants []string `json:"required_access_grants,omitempty" yaml:"required_access_grants,omitempty"`
+	// PIIReason records why the field is governed as personal data. It is reported, not rendered.
+	PIIReason string `json:"pii_reason,omitempty" yaml:"pii_reason,omitempty"`
+	// Translations of the label and description by locale are written to strings files
+	Translations map[string]DbtMetaLookerTranslation `json:"translations,omitempty" yaml:"translations,omitempty"`
+}
+
+// Validate checks that links are complete and that extra parameters can be rendered
+func (p *LookMLFieldParameters) Validate(reserved []string) error {
+	for _, link := range p.Links {
+		if link.Label == "" || link.URL == "" {
+			return fmt.Errorf("link requires a label and a url")
+		}
+		if strings.ContainsAny(link.Label+link.URL, "\n\r") || (link.IconURL != nil && strings.ContainsAny(*link.IconURL, "\n\r")) {
+			return fmt.Errorf("link %s cannot contain line breaks", link.Label)
+		}
+	}
+	if p.HTML != nil && strings.Contains(*p.HTML, ";;") {
+		return fmt.Errorf("html cannot contain ;;")
+	}
+	return ValidateExtraParameters(p.Extra, reserved)
+}
+
+// ValidateExtraParameters checks that extra parameters are valid LookML parameter names that
+// do not replace a generated parameter, and that their values cannot break out of the block
+// they are rendered into. Values are strings, numbers, booleans, lists of those, or nested
+// parameter blocks.
+func ValidateExtraParameters(extra map[string]interface{}, reserved []string) error {
+	keys := make([]string, 0, len(extra))
+	for key := range extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !extraParameterKey.MatchString(key) {
+			return fmt.Errorf("extra parameter %q is not a valid LookML parameter name", key)
+		}
+		for _, name := range reserved {
+			if key == name {
+				return fmt.Errorf("extra parameter %s is generated and cannot be set through extra", key)
+			}
+		}
+		if err := validateExtraValue(key, extra[key]); err != nil {
+			return fmt.Errorf("extra parameter %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// validateExtraValue checks a single extra parameter value
+func validateExtraValue(key string, value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		return validateExtraString(key, v)
+	case bool, int, int64, float64:
+		return nil
+	case []interface{}:
+		for _, item := range v {
+			switch item := item.(type) {
+			case string:
+				if err := validateExtraString("", item); err != nil {
+					return err
+				}
+			case bool, int, int64, float64:
+			default:
+				return fmt.Errorf("list items must be strings, numbers or booleans")
+			}
+		}
+		return nil
+	case []string:
+		for _, item := range v {
+			if err := validateExtraString("", item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]interface{}:
+		return ValidateExtraParameters(v, nil)
+	case nil:
+		return fmt.Errorf("value is empty")
+	default:
+		return fmt.Errorf("unsupported value of type %T", value)
+	}
+}
+
+// validateExtraString checks that a string cannot end its parameter early or open a block.
+// SQL and HTML parameters are terminated by ;; and may span lines.
+func validateExtraString(key, value string) error {
+	if strings.Contains(value, ";;") {
+		return fmt.Errorf("value cannot contain ;;")
+	}
+	if IsTerminatedParameter(key) {
+		return nil
+	}
+	if strings.ContainsAny(value, "\n\r{}") {
+		return fmt.Errorf("value cannot contain line breaks or braces")
+	}
+	if (strings.Count(value, `"`)-strings.Count(value, `\"`))%2 == 1 {
+		return fmt.Errorf("value has an unbalanced quote")
+	}
+	return nil
+}
+
+// IsTerminatedParameter reports whether a LookML parameter takes a value terminated by ;;,
+// like sql, sql_* and html
+func IsTerminatedParameter(key string) bool {
+	return key == "sql" || strings.HasPrefix(key, "sql_") || key == "html" || key == "expression"
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateExtraParameters(t *testing.T) {
+	tests := []struct {
+		name        string
+		extra       map[string]interface{}
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name: "scalars, lists and blocks",
+			extra: map[string]interface{}{
+				"value_format":        `"$#,##0.00"`,
+				"can_filter":          false,
+				"precision":           2.0,
+				"suggestions":         []interface{}{`"a"`, `"b"`},
+				"sql_preamble":        "-- multi\nline",
+				"required_access":     []string{"finance"},
+				"view_label":          `"Orders"`,
+				"suggest_persist_for": `"24 hours"`,
+				"action": map[string]interface{}{
+					"label": `"Send"`,
+					"url":   `"https://example.com/send"`,
+				},
+			},
+		},
+		{
+			name:        "invalid name",
+			extra:       map[string]interface{}{"Value-Format": `"0"`},
+			expectError: true,
+			errorMsg:    `extra parameter "Value-Format" is not a valid LookML parameter name`,
+		},
+		{
+			name:        "generated parameter",
+			extra:       map[string]interface{}{"label": `"Total"`},
+			expectError: true,
+			errorMsg:    "extra parameter label is generated and cannot be set through extra",
+		},
+		{
+			name:        "terminator in value",
+			extra:       map[string]interface{}{"sql_where": "1 = 1 ;; dimension: x {"},
+			expectError: true,
+			errorMsg:    "extra parameter sql_where: value cannot contain ;;",
+		},
+		{
+			name:        "line break outside sql",
+			extra:       map[string]interface{}{"view_label": "\"Orders\"\n  hidden: yes"},
+			expectError: true,
+			errorMsg:    "value cannot contain line breaks or braces",
+		},
+		{
+			name:        "unbalanced quote",
+			extra:       map[string]interface{}{"view_label": `"Orders`},
+			expectError: true,
+			errorMsg:    "value has an unbalanced quote",
+		},
+		{
+			name:        "nested list",
+			extra:       map[string]interface{}{"suggestions": []interface{}{[]interface{}{"a"}}},
+			expectError: true,
+			errorMsg:    "list items must be strings, numbers or booleans",
+		},
+		{
+			name:        "empty value",
+			extra:       map[string]interface{}{"view_label": nil},
+			expectError: true,
+			errorMsg:    "value is empty",
+		},
+		{
+			name:        "invalid name in block",
+			extra:       map[string]interface{}{"action": map[string]interface{}{"URL": `"x"`}},
+			expectError: true,
+			errorMsg:    `extra parameter action: extra parameter "URL" is not a valid LookML parameter name`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateExtraParameters(tt.extra, reservedDimensionParameters)
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLookMLFieldParameters_Validate(t *testing.T) {
+	html := "<b>{{ value }}</b>"
+
+	tests := []struct {
+		name        string
+		dimension   LookMLDimension
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name: "valid parameters",
+			dimension: LookMLDimension{
+				Name: "status", Type: "string", SQL: "${TABLE}.status",
+				OrderByField: stringPtr("status_rank"),
+				LookMLFieldParameters: LookMLFieldParameters{
+					Links: []DbtMetaLookerLink{{Label: "Search", URL: "https://example.com?q={{ value }}"}},
+					HTML:  &html,
+					Tags:  []string{"pii"},
+					Extra: map[string]interface{}{"view_label": `"Status"`},
+				},
+			},
+		},
+		{
+			name: "link without url",
+			dimension: LookMLDimension{
+				Name: "status", Type: "string", SQL: "${TABLE}.status",
+				LookMLFieldParameters: LookMLFieldParameters{Links: []DbtMetaLookerLink{{Label: "Search"}}},
+			},
+			expectError: true,
+			errorMsg:    "invalid parameters in dimension status: link requires a label and a url",
+		},
+		{
+			name: "link with a line break",
+			dimension: LookMLDimension{
+				Name: "status", Type: "string", SQL: "${TABLE}.status",
+				LookMLFieldParameters: LookMLFieldParameters{Links: []DbtMetaLookerLink{{Label: "Search", URL: "https://example.com\n}"}}},
+			},
+			expectError: true,
+			errorMsg:    "link Search cannot contain line breaks",
+		},
+		{
+			name: "extra sets a typed parameter",
+			dimension: LookMLDimension{
+				Name: "status", Type: "string", SQL: "${TABLE}.status",
+				LookMLFieldParameters: LookMLFieldParameters{Extra: map[string]interface{}{"order_by_field": "status_rank"}},
+			},
+			expectError: true,
+			errorMsg:    "extra parameter order_by_field is generated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dimension.Validate()
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -182,6 +182,78 @@ func TestDbtParser_ModelFiltering(t *testing.T) {
 	}
 }
 
+// TestDbtParser_PrimaryKeyConstraints tests that primary_key constraints of columns and
+// models mark primary keys, including columns only the catalog knows
+func TestDbtParser_PrimaryKeyConstraints(t *testing.T) {
+	manifest := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"adapter_type": "bigquery",
+		},
+		"nodes": map[string]interface{}{
+			"model.test.orders": map[string]interface{}{
+				"name":          "orders",
+				"resource_type": "model",
+				"unique_id":     "model.test.orders",
+				"relation_name": "`project.dataset.orders`",
+				"columns": map[string]interface{}{
+					"OrderId": map[string]interface{}{
+						"name":        "OrderId",
+						"constraints": []interface{}{map[string]interface{}{"type": "primary_key"}},
+					},
+					"status": map[string]interface{}{
+						"name":        "status",
+						"constraints": []interface{}{map[string]interface{}{"type": "not_null"}},
+					},
+				},
+			},
+			"model.test.order_lines": map[string]interface{}{
+				"name":          "order_lines",
+				"resource_type": "model",
+				"unique_id":     "model.test.order_lines",
+				"relation_name": "`project.dataset.order_lines`",
+				"columns":       map[string]interface{}{},
+				"constraints": []interface{}{
+					map[string]interface{}{"type": "primary_key", "columns": []interface{}{"order_id", "LineNumber"}},
+				},
+			},
+		},
+	}
+	catalog := map[string]interface{}{
+		"nodes": map[string]interface{}{
+			"model.test.orders": map[string]interface{}{
+				"columns": map[string]interface{}{
+					"OrderId": map[string]interface{}{"name": "OrderId", "type": "INT64"},
+					"status":  map[string]interface{}{"name": "status", "type": "STRING"},
+				},
+			},
+			"model.test.order_lines": map[string]interface{}{
+				"columns": map[string]interface{}{
+					"order_id":   map[string]interface{}{"name": "order_id", "type": "INT64"},
+					"LineNumber": map[string]interface{}{"name": "LineNumber", "type": "INT64"},
+					"sku":        map[string]interface{}{"name": "sku", "type": "STRING"},
+				},
+			},
+		},
+	}
+
+	parser, err := NewDbtParser(&config.Config{}, manifest, catalog)
+	require.NoError(t, err)
+	dbtModels, err := parser.GetModels()
+	require.NoError(t, err)
+	require.Len(t, dbtModels, 2)
+
+	primaryKeys := make(map[string][]string)
+	for _, model := range dbtModels {
+		for name, column := range model.Columns {
+			if column.IsPrimaryKey {
+				primaryKeys[model.Name] = append(primaryKeys[model.Name], name)
+			}
+		}
+	}
+	assert.ElementsMatch(t, []string{"orderid"}, primaryKeys["orders"])
+	assert.ElementsMatch(t, []string{"order_id", "linenumber"}, primaryKeys["order_lines"])
+}
+
 // TestDbtParser_ErrorHandling tests error conditions
 func TestDbtParser_ErrorHandling(t *testing.T) {
 	tests := []struct {
@@ -46,6 +46,12 @@ func (p *CatalogParser) ProcessModelColumns(model *models.DbtModel) (*models.Dbt
 	processedModel := *model
 	processedColumns := make(map[string]models.DbtModelColumn)
 
+	// Columns documented in the manifest carry the meta, tags and primary key of schema.yml
+	manifestColumns := make(map[string]models.DbtModelColumn, len(model.Columns))
+	for columnName, column := range model.Columns {
+		manifestColumns[strings.ToLower(columnName)] = column
+	}
+
 	// Always create columns from catalog (manifest columns are typically empty)
 	for catalogColumnName, catalogColumn := range catalogNode.Columns {
 		// Create a new model column from catalog data
@@ -65,11 +71,22 @@ func (p *CatalogParser) ProcessModelColumns(model *models.DbtModel) (*models.Dbt
 			newColumn.OriginalName = &originalNameCopy
 		}
 
+		if manifestColumn, found := manifestColumns[catalogColumnName]; found {
+			newColumn.Meta = manifestColumn.Meta
+			newColumn.Tags = manifestColumn.Tags
+			newColumn.IsPrimaryKey = manifestColumn.IsPrimaryKey
+			if newColumn.Description == nil {
+				newColumn.Description = manifestColumn.Description
+			}
+		}
+
 		newColumn.ProcessColumn()
 		processedColumns[catalogColumnName] = newColumn
 	}
 
 	processedModel.Columns = processedColumns
+	// Model constraints may name columns only the catalog knows
+	processedModel.ApplyConstraints()
 
 	return &processedModel, nil
 }
@@ -474,6 +474,56 @@ func TestCatalogParser_ProcessModelColumnsPreservesOriginalName(t *testing.T) {
 	assert.NotEqual(t, fmt.Sprintf("%p", col1.OriginalName), fmt.Sprintf("%p", col3.OriginalName), "Should have different pointers")
 }
 
+// TestCatalogParser_ProcessModelColumnsKeepsManifestMeta tests that the meta, tags and
+// description documented in the manifest survive the catalog merge
+func TestCatalogParser_ProcessModelColumnsKeepsManifestMeta(t *testing.T) {
+	description := "Customer email address"
+	label := "Email"
+	model := &models.DbtModel{
+		DbtNode: models.DbtNode{
+			Name:     "customers",
+			UniqueID: "model.test.customers",
+		},
+		Columns: map[string]models.DbtModelColumn{
+			"Email": {
+				Name:        "Email",
+				Description: &description,
+				Tags:        []string{"pii"},
+				Meta: &models.DbtModelColumnMeta{Looker: &models.DbtMetaLooker{
+					Dimension: &models.DbtMetaLookerDimension{DbtMetaLookerBase: models.DbtMetaLookerBase{Label: &label}},
+				}},
+			},
+		},
+	}
+
+	catalog := &models.DbtCatalog{
+		Nodes: map[string]models.DbtCatalogNode{
+			"model.test.customers": {
+				Columns: map[string]models.DbtCatalogNodeColumn{
+					"email":   {Name: "email", Type: "STRING", DataType: "STRING"},
+					"country": {Name: "country", Type: "STRING", DataType: "STRING"},
+				},
+			},
+		},
+	}
+
+	parser := NewCatalogParser(catalog, map[string]interface{}{}, &config.Config{})
+
+	processedModel, err := parser.ProcessModelColumns(model)
+	require.NoError(t, err)
+
+	email := processedModel.Columns["email"]
+	assert.Equal(t, []string{"pii"}, email.Tags)
+	require.NotNil(t, email.Description)
+	assert.Equal(t, description, *email.Description)
+	require.NotNil(t, email.Meta)
+	assert.Equal(t, &label, email.Meta.Looker.Dimension.Label)
+
+	country := processedModel.Columns["country"]
+	assert.Nil(t, country.Meta)
+	assert.Empty(t, country.Tags)
+}
+
 // TestCatalogParser_GetColumnInnerTypes tests getting inner types for complex columns
 func TestCatalogParser_GetColumnInnerTypes(t *testing.T) {
 	catalog := &models.DbtCatalog{
@@ -0,0 +1,257 @@
+package parsers
+
+import (
+	"strings"
+)
+
+// LookMLBlock represents a named block in a LookML file, such as
+// `view: orders { ... }` or `dimension: id { ... }`.
+//
+// The parser is intentionally lightweight: it understands enough of the LookML
+// grammar (strings, comments, lists and `;;`-terminated SQL values) to locate
+// blocks and their simple parameters, so that existing files can be inspected
+// and partially rewritten without losing hand-written content.
+type LookMLBlock struct {
+	Kind     string            // Block keyword, e.g. "view", "explore", "dimension"
+	Name     string            // Block name, e.g. "orders" or "+orders" for refinements
+	Params   map[string]string // Simple parameters directly inside the block (raw values)
+	Children []LookMLBlock     // Named blocks nested directly inside this block
+	Start    int               // Byte offset of the first character of the block keyword
+	End      int               // Byte offset just after the closing brace
+	BodyFrom int               // Byte offset just after the opening brace
+	BodyTo   int               // Byte offset of the closing brace
+
+	// ParamRanges locates each simple parameter, from its key to the end of its value
+	// (including the `;;` of SQL values)
+	ParamRanges map[string]LookMLRange
+}
+
+// LookMLRange is a range of byte offsets in a LookML file
+type LookMLRange struct {
+	Start int
+	End   int
+}
+
+// Text returns the source text of the block from its keyword to the closing brace.
+func (b *LookMLBlock) Text(content string) string {
+	return content[b.Start:b.End]
+}
+
+// Body returns the source text between the block's braces.
+func (b *LookMLBlock) Body(content string) string {
+	return content[b.BodyFrom:b.BodyTo]
+}
+
+// ParamText returns the source text of a simple parameter from its key to the end of its value.
+func (b *LookMLBlock) ParamText(content, key string) (string, bool) {
+	paramRange, ok := b.ParamRanges[key]
+	if !ok {
+		return "", false
+	}
+	return content[paramRange.Start:paramRange.End], true
+}
+
+// FindChild returns the first direct child block with the given kind and name.
+func (b *LookMLBlock) FindChild(kind, name string) *LookMLBlock {
+	for i := range b.Children {
+		if b.Children[i].Kind == kind && b.Children[i].Name == name {
+			return &b.Children[i]
+		}
+	}
+	return nil
+}
+
+// ParseLookMLBlocks parses the top-level named blocks of a LookML file.
+// Unparseable trailing content is ignored rather than reported, since the
+// parser is only used to inspect files that Looker itself validates.
+func ParseLookMLBlocks(content string) []LookMLBlock {
+	blocks, _, _, _ := parseLookMLRange(content, 0)
+	return blocks
+}
+
+// parseLookMLRange parses parameters and blocks starting at pos until a closing
+// brace or the end of the content. It returns the blocks, the simple parameters
+// with their ranges and the position of the closing brace (or len(content)).
+func parseLookMLRange(content string, pos int) ([]LookMLBlock, map[string]string, map[string]LookMLRange, int) {
+	var blocks []LookMLBlock
+	params := make(map[string]string)
+	ranges := make(map[string]LookMLRange)
+
+	for pos < len(content) {
+		pos = skipLookMLSpaceAndComments(content, pos)
+		if pos >= len(content) {
+			break
+		}
+
+		if content[pos] == '}' {
+			return blocks, params, ranges, pos
+		}
+
+		keyStart := pos
+		key := readLookMLIdentifier(content, pos)
+		if key == "" {
+			// Not a parameter; skip the character and keep scanning
+			pos++
+			continue
+		}
+		pos += len(key)
+		if pos >= len(content) || content[pos] != ':' {
+			continue
+		}
+		pos++
+
+		// SQL-like values run until the `;;` terminator and may contain braces
+		if isLookMLSQLParameter(key) {
+			end := strings.Index(content[pos:], ";;")
+			if end == -1 {
+				params[key] = strings.TrimSpace(content[pos:])
+				ranges[key] = LookMLRange{Start: keyStart, End: len(content)}
+				return blocks, params, ranges, len(content)
+			}
+			params[key] = strings.TrimSpace(content[pos : pos+end])
+			pos += end + 2
+			ranges[key] = LookMLRange{Start: keyStart, End: pos}
+			continue
+		}
+
+		pos = skipLookMLInlineSpace(content, pos)
+		if pos >= len(content) {
+			break
+		}
+
+		switch content[pos] {
+		case '"':
+			end := skipLookMLString(content, pos)
+			params[key] = content[pos:end]
+			ranges[key] = LookMLRange{Start: keyStart, End: end}
+			pos = end
+		case '[':
+			end := skipLookMLList(content, pos)
+			params[key] = content[pos:end]
+			ranges[key] = LookMLRange{Start: keyStart, End: end}
+			pos = end
+		case '{':
+			// Anonymous block such as `link: { ... }`
+			_, _, _, closing := parseLookMLRange(content, pos+1)
+			end := min(closing+1, len(content))
+			params[key] = content[pos:end]
+			ranges[key] = LookMLRange{Start: keyStart, End: end}
+			pos = closing + 1
+		default:
+			nameEnd := pos
+			for nameEnd < len(content) && !isLookMLValueTerminator(content[nameEnd]) {
+				nameEnd++
+			}
+			name := content[pos:nameEnd]
+			next := skipLookMLSpaceAndComments(content, nameEnd)
+			if next < len(content) && content[next] == '{' {
+				children, childParams, childRanges, closing := parseLookMLRange(content, next+1)
+				end := min(closing+1, len(content))
+				blocks = append(blocks, LookMLBlock{
+					Kind:        key,
+					Name:        name,
+					Params:      childParams,
+					Children:    children,
+					Start:       keyStart,
+					End:         end,
+					BodyFrom:    next + 1,
+					BodyTo:      min(closing, len(content)),
+					ParamRanges: childRanges,
+				})
+				pos = end
+			} else {
+				params[key] = name
+				ranges[key] = LookMLRange{Start: keyStart, End: nameEnd}
+				pos = nameEnd
+			}
+		}
+	}
+
+	return blocks, params, ranges, len(content)
+}
+
+// isLookMLSQLParameter reports whether a parameter value is terminated by `;;`
+func isLookMLSQLParameter(key string) bool {
+	return strings.HasPrefix(key, "sql") || key == "html" || key == "expression"
+}
+
+// readLookMLIdentifier reads a parameter name starting at pos
+func readLookMLIdentifier(content string, pos int) string {
+	end := pos
+	for end < len(content) {
+		c := content[end]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' {
+			end++
+			continue
+		}
+		break
+	}
+	return content[pos:end]
+}
+
+// isLookMLValueTerminator reports whether a character ends an unquoted value
+func isLookMLValueTerminator(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '{' || c == '}' || c == '#'
+}
+
+// skipLookMLSpaceAndComments skips whitespace and `#` comments
+func skipLookMLSpaceAndComments(content string, pos int) int {
+	for pos < len(content) {
+		switch content[pos] {
+		case ' ', '\t', '\n', '\r':
+			pos++
+		case '#':
+			for pos < len(content) && content[pos] != '\n' {
+				pos++
+			}
+		default:
+			return pos
+		}
+	}
+	return pos
+}
+
+// skipLookMLInlineSpace skips spaces and tabs on the current line
+func skipLookMLInlineSpace(content string, pos int) int {
+	for pos < len(content) && (content[pos] == ' ' || content[pos] == '\t') {
+		pos++
+	}
+	return pos
+}
+
+// skipLookMLString returns the position just after the string starting at pos
+func skipLookMLString(content string, pos int) int {
+	pos++ // opening quote
+	for pos < len(content) {
+		switch content[pos] {
+		case '\\':
+			pos += 2
+			continue
+		case '"':
+			return pos + 1
+		}
+		pos++
+	}
+	return len(content)
+}
+
+// skipLookMLList returns the position just after the list starting at pos
+func skipLookMLList(content string, pos int) int {
+	depth := 0
+	for pos < len(content) {
+		switch content[pos] {
+		case '"':
+			pos = skipLookMLString(content, pos)
+			continue
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return pos + 1
+			}
+		}
+		pos++
+	}
+	return len(content)
+}
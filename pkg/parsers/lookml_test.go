@@ -0,0 +1,93 @@
+package parsers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseLookMLBlocks tests parsing of views, fields and explores from LookML text
+func TestParseLookMLBlocks(t *testing.T) {
+	content := `# Refinements for orders
+include: "/generated/orders.view.lkml"
+
+view: +orders {
+  label: "Orders"
+
+  dimension: status {
+    label: "Order Status"
+  }
+
+  dimension: status_upper {
+    type: string
+    sql: {% if status._is_filtered %} UPPER(${status}) {% else %} ${status} {% endif %} ;;
+  }
+
+  measure: total {
+    type: sum
+    sql: ${amount} ;;
+    filters: [status: "complete"]
+  }
+}
+
+explore: +orders {
+  hidden: no
+}
+`
+
+	blocks := ParseLookMLBlocks(content)
+	require.Len(t, blocks, 2)
+
+	view := blocks[0]
+	assert.Equal(t, "view", view.Kind)
+	assert.Equal(t, "+orders", view.Name)
+	assert.Equal(t, `"Orders"`, view.Params["label"])
+	label, ok := view.ParamText(content, "label")
+	require.True(t, ok)
+	assert.Equal(t, `label: "Orders"`, label)
+	require.Len(t, view.Children, 3)
+
+	assert.Equal(t, "dimension", view.Children[0].Kind)
+	assert.Equal(t, "status", view.Children[0].Name)
+	assert.NotContains(t, view.Children[0].Params, "sql")
+
+	// Liquid braces inside SQL must not end the block
+	upper := view.FindChild("dimension", "status_upper")
+	require.NotNil(t, upper)
+	assert.Contains(t, upper.Params["sql"], "UPPER(${status})")
+	sql, ok := upper.ParamText(content, "sql")
+	require.True(t, ok)
+	assert.True(t, strings.HasPrefix(sql, "sql: {% if"))
+	assert.True(t, strings.HasSuffix(sql, "{% endif %} ;;"))
+
+	total := view.FindChild("measure", "total")
+	require.NotNil(t, total)
+	assert.Equal(t, `[status: "complete"]`, total.Params["filters"])
+	assert.Equal(t, "measure: total {", total.Text(content)[:len("measure: total {")])
+
+	explore := blocks[1]
+	assert.Equal(t, "explore", explore.Kind)
+	assert.Equal(t, "+orders", explore.Name)
+	assert.Equal(t, "no", explore.Params["hidden"])
+	assert.Contains(t, explore.Body(content), "hidden: no")
+	_, ok = explore.ParamText(content, "label")
+	assert.False(t, ok)
+}
+
+// TestParseLookMLBlocks_Malformed tests that unterminated content does not panic
+func TestParseLookMLBlocks_Malformed(t *testing.T) {
+	tests := []string{
+		"",
+		"view: orders {",
+		"view: orders {\n  dimension: id {\n    sql: ${TABLE}.id",
+		`view: orders { label: "unterminated }`,
+	}
+
+	for _, content := range tests {
+		assert.NotPanics(t, func() {
+			ParseLookMLBlocks(content)
+		})
+	}
+}
@@ -158,6 +158,7 @@ func (p *ModelParser) convertToModel(node interface{}) *models.DbtModel {
 		column.ProcessColumn()
 		model.Columns[name] = column
 	}
+	model.ApplyConstraints()
 
 	return &model
 }
@@ -237,32 +237,38 @@ func ToTitleCase(s string) string {
 // since there's no case information to work with. Ensure your source data maintains
 // proper case conventions (e.g., "SupplierInformation") for correct conversion.
 func ToLookMLName(s string) string {
+	return ToLookMLNameWithSeparator(s, "__")
+}
+
+// ToLookMLNameWithSeparator converts a column name to LookML naming convention like
+// ToLookMLName, joining the parts of nested column names with the given separator.
+func ToLookMLNameWithSeparator(s, separator string) string {
 	if s == "" {
 		return s
 	}
 
 	// Split by dots to handle nested column names
 	parts := strings.Split(s, ".")
-	var convertedParts []string
+	convertedParts := make([]string, 0, len(parts))
 
 	for _, part := range parts {
 		// Convert each part from PascalCase/camelCase to snake_case
 		snakePart := CamelToSnake(part)
-		convertedParts = append(convertedParts, snakePart)
-	}
 
-	// Join with double underscores (LookML convention for nested fields)
-	lookmlName := strings.Join(convertedParts, "__")
+		// Sanitize any remaining special characters (but preserve underscores) - using pre-compiled regex
+		snakePart = sanitizeInvalidChars.ReplaceAllString(snakePart, "_")
 
-	// Sanitize any remaining special characters (but preserve underscores) - using pre-compiled regex
-	lookmlName = sanitizeInvalidChars.ReplaceAllString(lookmlName, "_")
+		// Replace 3 or more underscores with double underscores - using pre-compiled regex
+		snakePart = threeOrMoreUnderscores.ReplaceAllString(snakePart, "__")
 
-	// Remove consecutive underscores (but preserve double underscores)
-	// Replace 3 or more underscores with double underscores - using pre-compiled regex
-	lookmlName = threeOrMoreUnderscores.ReplaceAllString(lookmlName, "__")
+		// Remove leading/trailing underscores
+		snakePart = strings.Trim(snakePart, "_")
+		if snakePart != "" {
+			convertedParts = append(convertedParts, snakePart)
+		}
+	}
 
-	// Remove leading/trailing underscores
-	lookmlName = strings.Trim(lookmlName, "_")
+	lookmlName := strings.Join(convertedParts, separator)
 
 	// Ensure it doesn't start with a number
 	if len(lookmlName) > 0 && unicode.IsDigit(rune(lookmlName[0])) {
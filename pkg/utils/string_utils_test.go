@@ -152,6 +152,13 @@ func TestToLookMLName(t *testing.T) {
 	}
 }
 
+// TestToLookMLNameWithSeparator tests nested names with a custom separator
+func TestToLookMLNameWithSeparator(t *testing.T) {
+	assert.Equal(t, "classification_item_group_code", ToLookMLNameWithSeparator("Classification.ItemGroup.Code", "_"))
+	assert.Equal(t, "item___gtin_id", ToLookMLNameWithSeparator("Item.GTINId", "___"))
+	assert.Equal(t, "supplier_information", ToLookMLNameWithSeparator("SupplierInformation", "_"))
+}
+
 // TestSnakeToCamel tests snake_case to CamelCase conversion
 func TestSnakeToCamel(t *testing.T) {
 	tests := []struct {
@@ -173,7 +173,7 @@ func TestGenerateNestedLookMLWithExplore(t *testing.T) {
 	assert.FileExists(t, expectedOutputPath, "Generated LookML file should exist")
 
 	// Load expected fixture (if it exists)
-	expectedFixturePath := "fixtures/expected/dq_icasoi_current.view.lkml"
+	expectedFixturePath := filepath.Join("..", "fixtures", "expected", "dq_icasoi_current.view.lkml")
 	if _, err := os.Stat(expectedFixturePath); err == nil {
 		// Compare using new utilities
 		testIntegration.compareWithExpectedOutput(t, expectedOutputPath, expectedFixturePath, models[0])
@@ -204,7 +204,7 @@ func TestGenerateSalesWasteLookMLWithExplore(t *testing.T) {
 	assert.FileExists(t, expectedOutputPath, "Generated LookML file should exist")
 
 	// Load expected fixture (if it exists)
-	expectedFixturePath := "fixtures/expected/f_store_sales_waste_day_v1.view.lkml"
+	expectedFixturePath := filepath.Join("..", "fixtures", "expected", "f_store_sales_waste_day_v1.view.lkml")
 	if _, err := os.Stat(expectedFixturePath); err == nil {
 		// Compare using new utilities
 		testIntegration.compareWithExpectedOutput(t, expectedOutputPath, expectedFixturePath, models[0])
@@ -225,6 +225,7 @@ func TestGenerateDItemV3ComplexStructsWithExplore(t *testing.T) {
 	// Create test configuration
 	cfg := testIntegration.createTestConfig("conlaybi_item_versioned__d_item")
 	cfg.OutputDir = "output/test_d_item_v3"
+	testIntegration.outputDir = cfg.OutputDir
 
 	// Generate LookML
 	models, err := testIntegration.generateLookML(cfg)
@@ -236,7 +237,7 @@ func TestGenerateDItemV3ComplexStructsWithExplore(t *testing.T) {
 	assert.FileExists(t, expectedOutputPath, "Generated LookML file should exist")
 
 	// Load expected fixture (if it exists)
-	expectedFixturePath := "fixtures/expected/d_item_v3.view.lkml"
+	expectedFixturePath := filepath.Join("..", "fixtures", "expected", "d_item_v3.view.lkml")
 	if _, err := os.Stat(expectedFixturePath); err == nil {
 		// Compare using new utilities
 		testIntegration.compareWithExpectedOutput(t, expectedOutputPath, expectedFixturePath, models[0])
@@ -607,14 +608,13 @@ func TestFixtureComparison(t *testing.T) {
 			expectedStr := string(expectedContent)
 
 			t.Logf("  Generated: %d bytes, Expected: %d bytes", len(generatedContent), len(expectedContent))
+			assert.Equal(t, expectedStr, generatedStr, "Generated LookML should match %s", expectedFile)
 
 			// Count views
 			genViews := countOccurrences(generatedStr, "\nview:")
 			expViews := countOccurrences(expectedStr, "\nview:")
 
 			t.Logf("  Views: %d (expected %d)", genViews, expViews)
-			// Note: Not asserting exact view count to allow for formatting/ordering differences
-			// The semantic checks below will verify content correctness
 
 			// Count dimensions per view for more precise comparison
 			genViewDims := countDimensionsPerView(generatedStr)
@@ -625,9 +625,6 @@ func TestFixtureComparison(t *testing.T) {
 				t.Logf("  Main view dimensions: %d (expected %d)", genViewDims[0].Dimensions, expViewDims[0].Dimensions)
 				t.Logf("  Main view dimension groups: %d (expected %d)", genViewDims[0].DimensionGroups, expViewDims[0].DimensionGroups)
 				t.Logf("  Main view measures: %d (expected %d)", genViewDims[0].Measures, expViewDims[0].Measures)
-
-				// Note: Not asserting exact counts to allow for formatting/ordering differences
-				// The quality checks below verify semantic correctness
 			}
 
 			// Quality checks